@@ -1,7 +1,11 @@
 package v1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"sort"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -17,6 +21,26 @@ const (
 	// TypeSynced resources are believed to be in sync with the
 	// Kubernetes resources that manage their lifecycle.
 	TypeSynced ConditionType = "Synced"
+
+	// TypeInUseByReferencers resources are referenced by other resources,
+	// which delays their deletion.
+	TypeInUseByReferencers ConditionType = "InUseByReferencers"
+
+	// TypeReferencesResolved resources have resolved all of their
+	// references to other resources.
+	TypeReferencesResolved ConditionType = "ReferencesResolved"
+
+	// TypeHealthy resources are believed to be functioning normally, rather
+	// than merely existing.
+	TypeHealthy ConditionType = "Healthy"
+
+	// TypeDrifted resources are believed to have external resources that
+	// differ from their desired state.
+	TypeDrifted ConditionType = "Drifted"
+
+	// TypeAsyncOperation resources may have an operation on their external
+	// resource in progress.
+	TypeAsyncOperation ConditionType = "AsyncOperation"
 )
 
 // A ConditionReason represents the reason a resource is in a condition.
@@ -37,6 +61,36 @@ const (
 	ReasonReconcilePaused  ConditionReason = "ReconcilePaused"
 )
 
+// Reasons a resource is or is not in use by referencers.
+const (
+	ReasonInUse ConditionReason = "InUse"
+)
+
+// Reasons a resource's references are or are not resolved.
+const (
+	ReasonReferencesResolved   ConditionReason = "ReferencesResolved"
+	ReasonReferencesUnresolved ConditionReason = "ReferencesUnresolved"
+)
+
+// Reasons a resource is or is not healthy.
+const (
+	ReasonHealthy  ConditionReason = "Healthy"
+	ReasonDegraded ConditionReason = "Degraded"
+)
+
+// Reasons a resource has or has not drifted.
+const (
+	ReasonDrifted ConditionReason = "Drifted"
+	ReasonNoDrift ConditionReason = "NoDrift"
+)
+
+// Reasons a resource does or does not have an asynchronous operation in
+// progress.
+const (
+	ReasonAsyncOperationOngoing  ConditionReason = "Ongoing"
+	ReasonAsyncOperationFinished ConditionReason = "Finished"
+)
+
 // A Condition that may apply to a resource.
 type Condition struct {
 	// Type of this condition. At most one of each condition type may apply to
@@ -57,6 +111,14 @@ type Condition struct {
 	// one status to another, if any.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration represents the .metadata.generation that the
+	// condition was set based upon. For instance, if .metadata.generation is
+	// currently 12, but the .status.conditions[x].observedGeneration is 9, the
+	// condition is out of date with respect to the current state of the
+	// resource.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // Equal returns true if the condition is identical to the supplied condition,
@@ -65,7 +127,8 @@ func (c Condition) Equal(other Condition) bool {
 	return c.Type == other.Type &&
 		c.Status == other.Status &&
 		c.Reason == other.Reason &&
-		c.Message == other.Message
+		c.Message == other.Message &&
+		c.ObservedGeneration == other.ObservedGeneration
 }
 
 // WithMessage returns a condition by adding the provided message to existing
@@ -75,6 +138,20 @@ func (c Condition) WithMessage(msg string) Condition {
 	return c
 }
 
+// WithReason returns a condition by replacing the reason of the existing
+// condition with the provided reason.
+func (c Condition) WithReason(r ConditionReason) Condition {
+	c.Reason = r
+	return c
+}
+
+// WithObservedGeneration returns a condition by setting the provided
+// generation as the observed generation of the existing condition.
+func (c Condition) WithObservedGeneration(gen int64) Condition {
+	c.ObservedGeneration = gen
+	return c
+}
+
 // Conditions are implemented as a slice rather than a map to comply
 // with Kubernetes API conventions. Ideally we'd comply by using a map that
 // marshalled to a JSON array, but doing so confuses the CRD schema generator.
@@ -111,7 +188,8 @@ func (s *ConditionedStatus) GetCondition(ct ConditionType) Condition {
 
 // SetConditions sets the supplied conditions, replacing any existing conditions
 // of the same type. This is a no-op if all supplied conditions are identical,
-// ignoring the last transition time, to those already set.
+// ignoring the last transition time, to those already set. The last transition
+// time of an existing condition is preserved unless its status changes.
 func (s *ConditionedStatus) SetConditions(c ...Condition) {
 	for _, new := range c {
 		exists := false
@@ -125,6 +203,10 @@ func (s *ConditionedStatus) SetConditions(c ...Condition) {
 				continue
 			}
 
+			if existing.Status == new.Status {
+				new.LastTransitionTime = existing.LastTransitionTime
+			}
+
 			s.Conditions[i] = new
 			exists = true
 		}
@@ -134,6 +216,26 @@ func (s *ConditionedStatus) SetConditions(c ...Condition) {
 	}
 }
 
+// SetCondition sets the supplied condition, replacing any existing condition
+// of the same type as described by SetConditions.
+func (s *ConditionedStatus) SetCondition(c Condition) {
+	s.SetConditions(c)
+}
+
+// SetConditionIfDiffers sets the supplied condition as described by
+// SetConditions, and returns true if doing so changed the status. Setting a
+// condition identical to an existing one, ignoring the last transition time,
+// does not change the status.
+func (s *ConditionedStatus) SetConditionIfDiffers(c Condition) bool {
+	for _, existing := range s.Conditions {
+		if existing.Type == c.Type && existing.Equal(c) {
+			return false
+		}
+	}
+	s.SetConditions(c)
+	return true
+}
+
 // Equal returns true if the status is identical to the supplied status,
 // ignoring the LastTransitionTimes and order of statuses.
 func (s *ConditionedStatus) Equal(other *ConditionedStatus) bool {
@@ -164,6 +266,22 @@ func (s *ConditionedStatus) Equal(other *ConditionedStatus) bool {
 	return true
 }
 
+// ConditionsHash returns a stable hash of the status' conditions, ignoring
+// the LastTransitionTimes and order of conditions. Two statuses that are Equal
+// have the same hash.
+func (s *ConditionedStatus) ConditionsHash() string {
+	sc := make([]Condition, len(s.Conditions))
+	copy(sc, s.Conditions)
+	sort.Slice(sc, func(i, j int) bool { return sc[i].Type < sc[j].Type })
+
+	h := sha256.New()
+	for _, c := range sc {
+		// Fields are NUL separated so that they can't run into each other.
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%d\x00", c.Type, c.Status, c.Reason, c.Message, c.ObservedGeneration)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // Creating returns a condition that indicates the resource is currently
 // being created.
 func Creating() Condition {
@@ -245,3 +363,110 @@ func ReconcilePaused() Condition {
 		Reason:             ReasonReconcilePaused,
 	}
 }
+
+// InUseByReferencers returns a condition that indicates the resource is
+// referenced by the supplied referencers, and that its deletion is delayed
+// until they no longer reference it.
+func InUseByReferencers(referencers ...string) Condition {
+	return Condition{
+		Type:               TypeInUseByReferencers,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInUse,
+		Message:            "in use by " + strings.Join(referencers, ", "),
+	}
+}
+
+// ReferencesResolved returns a condition that indicates the resource has
+// resolved all of its references to other resources.
+func ReferencesResolved() Condition {
+	return Condition{
+		Type:               TypeReferencesResolved,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReferencesResolved,
+	}
+}
+
+// ReferencesUnresolved returns a condition that indicates the resource could
+// not resolve the supplied references, each of which should describe a
+// reference field and why it could not be resolved.
+func ReferencesUnresolved(unresolved ...string) Condition {
+	return Condition{
+		Type:               TypeReferencesResolved,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReferencesUnresolved,
+		Message:            strings.Join(unresolved, "; "),
+	}
+}
+
+// Healthy returns a condition that indicates the resource is currently
+// observed to be functioning normally.
+func Healthy() Condition {
+	return Condition{
+		Type:               TypeHealthy,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+	}
+}
+
+// Degraded returns a condition that indicates the resource is available, but
+// is currently observed not to be functioning normally, for example because
+// its API reports some of its components are failing.
+func Degraded() Condition {
+	return Condition{
+		Type:               TypeHealthy,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDegraded,
+	}
+}
+
+// Drifted returns a condition that indicates the external resource differs
+// from its desired state, for example because it was changed outside of
+// Kubernetes. The message should describe how it differs.
+func Drifted(diff string) Condition {
+	return Condition{
+		Type:               TypeDrifted,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDrifted,
+		Message:            diff,
+	}
+}
+
+// NoDrift returns a condition that indicates the external resource matches
+// its desired state.
+func NoDrift() Condition {
+	return Condition{
+		Type:               TypeDrifted,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoDrift,
+	}
+}
+
+// AsyncOperationOngoing returns a condition that indicates an asynchronous
+// operation on the external resource, such as a long running create, is in
+// progress.
+func AsyncOperationOngoing() Condition {
+	return Condition{
+		Type:               TypeAsyncOperation,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAsyncOperationOngoing,
+	}
+}
+
+// AsyncOperationFinished returns a condition that indicates no asynchronous
+// operation on the external resource is in progress.
+func AsyncOperationFinished() Condition {
+	return Condition{
+		Type:               TypeAsyncOperation,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAsyncOperationFinished,
+	}
+}
@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetConditions(t *testing.T) {
+	then := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(then.Add(time.Hour))
+
+	withTime := func(c Condition, t metav1.Time) Condition {
+		c.LastTransitionTime = t
+		return c
+	}
+
+	withGeneration := func(c Condition, g int64) Condition {
+		c.ObservedGeneration = g
+		return c
+	}
+
+	cases := map[string]struct {
+		reason string
+		cs     *ConditionedStatus
+		c      []Condition
+		want   *ConditionedStatus
+	}{
+		"Identical": {
+			reason: "Setting an identical condition should be a no-op.",
+			cs:     NewConditionedStatus(withTime(Available(), then)),
+			c:      []Condition{withTime(Available(), now)},
+			want:   NewConditionedStatus(withTime(Available(), then)),
+		},
+		"MessageChanged": {
+			reason: "The last transition time should be preserved if the status does not change.",
+			cs:     NewConditionedStatus(withTime(Creating(), then)),
+			c:      []Condition{withTime(Creating().WithMessage("still creating"), now)},
+			want:   NewConditionedStatus(withTime(Creating().WithMessage("still creating"), then)),
+		},
+		"StatusChanged": {
+			reason: "The last transition time should be updated if the status changes.",
+			cs:     NewConditionedStatus(withTime(Creating(), then)),
+			c:      []Condition{withTime(Available(), now)},
+			want:   NewConditionedStatus(withTime(Available(), now)),
+		},
+		"GenerationChanged": {
+			reason: "A condition observed at a newer generation should replace an otherwise identical condition.",
+			cs:     NewConditionedStatus(withTime(Available(), then)),
+			c:      []Condition{withGeneration(withTime(Available(), now), 2)},
+			want:   NewConditionedStatus(withGeneration(withTime(Available(), then), 2)),
+		},
+		"New": {
+			reason: "A condition of a new type should be appended.",
+			cs:     NewConditionedStatus(withTime(Available(), then)),
+			c:      []Condition{withTime(ReconcileSuccess(), now)},
+			want:   NewConditionedStatus(withTime(Available(), then), withTime(ReconcileSuccess(), now)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.cs.SetConditions(tc.c...)
+			if diff := cmp.Diff(tc.want, tc.cs); diff != "" {
+				t.Errorf("\n%s\nSetConditions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConditionBuilders(t *testing.T) {
+	got := Unavailable().
+		WithReason("Provisioning").
+		WithMessage("waiting for the external resource").
+		WithObservedGeneration(3)
+
+	want := Condition{
+		Type:               TypeReady,
+		Status:             Unavailable().Status,
+		Reason:             "Provisioning",
+		Message:            "waiting for the external resource",
+		ObservedGeneration: 3,
+		LastTransitionTime: got.LastTransitionTime,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unavailable().With...(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestConditionsHash(t *testing.T) {
+	then := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(then.Add(time.Hour))
+
+	withTime := func(c Condition, t metav1.Time) Condition {
+		c.LastTransitionTime = t
+		return c
+	}
+
+	cases := map[string]struct {
+		reason string
+		a      *ConditionedStatus
+		b      *ConditionedStatus
+		want   bool
+	}{
+		"Identical": {
+			reason: "Identical conditions should have the same hash.",
+			a:      NewConditionedStatus(Available(), ReconcileSuccess()),
+			b:      NewConditionedStatus(Available(), ReconcileSuccess()),
+			want:   true,
+		},
+		"DifferentTimeAndOrder": {
+			reason: "Conditions that differ only in transition time and order should have the same hash.",
+			a:      NewConditionedStatus(withTime(Available(), then), withTime(ReconcileSuccess(), then)),
+			b:      NewConditionedStatus(withTime(ReconcileSuccess(), now), withTime(Available(), now)),
+			want:   true,
+		},
+		"DifferentMessage": {
+			reason: "Conditions with different messages should have different hashes.",
+			a:      NewConditionedStatus(Available()),
+			b:      NewConditionedStatus(Available().WithMessage("hi")),
+			want:   false,
+		},
+		"DifferentConditions": {
+			reason: "Different sets of conditions should have different hashes.",
+			a:      NewConditionedStatus(Available()),
+			b:      NewConditionedStatus(Available(), ReconcileSuccess()),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.a.ConditionsHash() == tc.b.ConditionsHash()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConditionsHash(...) equal: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetConditionIfDiffers(t *testing.T) {
+	then := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(then.Add(time.Hour))
+
+	withTime := func(c Condition, t metav1.Time) Condition {
+		c.LastTransitionTime = t
+		return c
+	}
+
+	type want struct {
+		changed bool
+		cs      *ConditionedStatus
+	}
+
+	cases := map[string]struct {
+		reason string
+		cs     *ConditionedStatus
+		c      Condition
+		want   want
+	}{
+		"Identical": {
+			reason: "Setting an identical condition should not change the status or its transition time.",
+			cs:     NewConditionedStatus(withTime(Available(), then)),
+			c:      withTime(Available(), now),
+			want:   want{changed: false, cs: NewConditionedStatus(withTime(Available(), then))},
+		},
+		"Different": {
+			reason: "Setting a different condition should change the status.",
+			cs:     NewConditionedStatus(withTime(Available(), then)),
+			c:      withTime(Unavailable(), now),
+			want:   want{changed: true, cs: NewConditionedStatus(withTime(Unavailable(), now))},
+		},
+		"New": {
+			reason: "Setting a condition of a new type should change the status.",
+			cs:     NewConditionedStatus(),
+			c:      withTime(Available(), now),
+			want:   want{changed: true, cs: NewConditionedStatus(withTime(Available(), now))},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := tc.cs.SetConditionIfDiffers(tc.c)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("\n%s\nSetConditionIfDiffers(...): -want changed, +got changed:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cs, tc.cs); diff != "" {
+				t.Errorf("\n%s\nSetConditionIfDiffers(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+)
+
+const externalIDSeparator = ":"
+
+// Error strings.
+const (
+	errFmtInvalidExternalID = "invalid external ID %q: must be provider:scope:id"
+)
+
+// An ExternalID identifies an external resource, so that tooling can
+// correlate a managed resource with the external resource it manages.
+type ExternalID struct {
+	// Provider of the external resource, for example aws.
+	Provider string `json:"provider"`
+
+	// Scope of the external resource within its provider, for example a
+	// region or account. It may not contain colons.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// ID of the external resource within its scope, for example an ARN.
+	ID string `json:"id"`
+}
+
+// String returns the external ID formatted as provider:scope:id. The scope is
+// empty if it is unset, for example provider::id.
+func (e ExternalID) String() string {
+	return strings.Join([]string{e.Provider, e.Scope, e.ID}, externalIDSeparator)
+}
+
+// ParseExternalID parses an external ID formatted as described by
+// ExternalID.String. The ID may contain colons; the provider and scope may
+// not.
+func ParseExternalID(s string) (ExternalID, error) {
+	parts := strings.SplitN(s, externalIDSeparator, 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return ExternalID{}, fmt.Errorf(errFmtInvalidExternalID, s)
+	}
+	return ExternalID{Provider: parts[0], Scope: parts[1], ID: parts[2]}, nil
+}
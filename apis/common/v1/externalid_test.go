@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseExternalID(t *testing.T) {
+	type want struct {
+		id  ExternalID
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Scoped": {
+			reason: "An external ID with a scope should be parsed.",
+			s:      "aws:eu-west-1:arn:aws:s3:::cool",
+			want:   want{id: ExternalID{Provider: "aws", Scope: "eu-west-1", ID: "arn:aws:s3:::cool"}},
+		},
+		"Unscoped": {
+			reason: "An external ID without a scope should be parsed.",
+			s:      "github::krateoplatformops/cool",
+			want:   want{id: ExternalID{Provider: "github", ID: "krateoplatformops/cool"}},
+		},
+		"MissingID": {
+			reason: "An external ID without an ID should be invalid.",
+			s:      "aws:eu-west-1:",
+			want:   want{err: true},
+		},
+		"Malformed": {
+			reason: "An external ID without separators should be invalid.",
+			s:      "cool",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseExternalID(tc.s)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nParseExternalID(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nParseExternalID(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if err == nil && got.String() != tc.s {
+				t.Errorf("\n%s\nString(): want %q, got %q", tc.reason, tc.s, got.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A DeletionPolicy determines what should happen to the external resource
+// when a managed resource is deleted.
+// +kubebuilder:validation:Enum=orphan;delete
+type DeletionPolicy string
+
+// Deletion policies. They have the same values as the deletion policy
+// annotation.
+const (
+	// DeletionOrphan means the external resource will be orphaned when its
+	// managed resource is deleted.
+	DeletionOrphan DeletionPolicy = "orphan"
+
+	// DeletionDelete means the external resource will be deleted when its
+	// managed resource is deleted.
+	DeletionDelete DeletionPolicy = "delete"
+)
+
+// A ReconcilePolicy controls how often a managed resource is reconciled. It
+// overrides the defaults of the provider for a single managed resource.
+type ReconcilePolicy struct {
+	// PollInterval is how often the external resource is observed once it is
+	// up to date.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// MaxBackoff is the longest the provider will wait before retrying a
+	// failed reconcile.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// RetryBudget is the number of consecutive failed reconciles that are
+	// retried with backoff. Once it is exhausted failed reconciles are only
+	// retried after the poll interval, or when the managed resource changes.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RetryBudget *int64 `json:"retryBudget,omitempty"`
+}
+
+// A ResourceSpec defines the desired state of a managed resource. It is
+// intended to be embedded in the spec of managed resource kinds.
+type ResourceSpec struct {
+	// DeletionPolicy specifies what will happen to the external resource when
+	// the managed resource is deleted. It takes precedence over the
+	// deletion policy annotation.
+	// +optional
+	// +kubebuilder:default=delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// ManagementPolicies specify the actions that may be taken on the
+	// external resource. They take precedence over the management policy
+	// annotation. Empty management policies defer to the annotation, which
+	// allows every action by default.
+	// +optional
+	ManagementPolicies ManagementPolicies `json:"managementPolicies,omitempty"`
+
+	// ProviderConfigReference specifies how the provider that will be used to
+	// create, observe, update, and delete this managed resource should be
+	// configured.
+	// +optional
+	ProviderConfigReference *Reference `json:"providerConfigRef,omitempty"`
+
+	// WriteConnectionSecretToReference specifies the namespace and name of a
+	// secret to which any connection details for this managed resource should
+	// be written.
+	// +optional
+	WriteConnectionSecretToReference *Reference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// ReconcilePolicy controls how often this managed resource is reconciled.
+	// +optional
+	ReconcilePolicy *ReconcilePolicy `json:"reconcilePolicy,omitempty"`
+}
+
+// SetDeletionPolicy of the managed resource.
+func (s *ResourceSpec) SetDeletionPolicy(p DeletionPolicy) {
+	s.DeletionPolicy = p
+}
+
+// GetDeletionPolicy of the managed resource.
+func (s *ResourceSpec) GetDeletionPolicy() DeletionPolicy {
+	return s.DeletionPolicy
+}
+
+// SetManagementPolicies of the managed resource.
+func (s *ResourceSpec) SetManagementPolicies(p ManagementPolicies) {
+	s.ManagementPolicies = p
+}
+
+// GetManagementPolicies of the managed resource.
+func (s *ResourceSpec) GetManagementPolicies() ManagementPolicies {
+	return s.ManagementPolicies
+}
+
+// SetProviderConfigReference of the managed resource.
+func (s *ResourceSpec) SetProviderConfigReference(r *Reference) {
+	s.ProviderConfigReference = r
+}
+
+// GetProviderConfigReference of the managed resource.
+func (s *ResourceSpec) GetProviderConfigReference() *Reference {
+	return s.ProviderConfigReference
+}
+
+// SetWriteConnectionSecretToReference of the managed resource.
+func (s *ResourceSpec) SetWriteConnectionSecretToReference(r *Reference) {
+	s.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of the managed resource.
+func (s *ResourceSpec) GetWriteConnectionSecretToReference() *Reference {
+	return s.WriteConnectionSecretToReference
+}
+
+// SetReconcilePolicy of the managed resource.
+func (s *ResourceSpec) SetReconcilePolicy(p *ReconcilePolicy) {
+	s.ReconcilePolicy = p
+}
+
+// GetReconcilePolicy of the managed resource.
+func (s *ResourceSpec) GetReconcilePolicy() *ReconcilePolicy {
+	return s.ReconcilePolicy
+}
+
+// A ResourceStatus defines the observed state of a managed resource. It is
+// intended to be embedded in the status of managed resource kinds.
+type ResourceStatus struct {
+	ConditionedStatus `json:",inline"`
+	SyncStatus        `json:",inline"`
+
+	// ObservedGeneration is the latest generation of the managed resource
+	// that was reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ExternalID identifies the external resource.
+	// +optional
+	ExternalID *ExternalID `json:"externalID,omitempty"`
+
+	// Failures is the number of consecutive times the managed resource
+	// failed to reconcile. It is reset by a successful reconcile.
+	// +optional
+	Failures int64 `json:"failures,omitempty"`
+}
+
+// SetObservedGeneration of the managed resource.
+func (s *ResourceStatus) SetObservedGeneration(g int64) {
+	s.ObservedGeneration = g
+}
+
+// GetObservedGeneration of the managed resource.
+func (s *ResourceStatus) GetObservedGeneration() int64 {
+	return s.ObservedGeneration
+}
+
+// SetFailures of the managed resource.
+func (s *ResourceStatus) SetFailures(i int64) {
+	s.Failures = i
+}
+
+// GetFailures of the managed resource.
+func (s *ResourceStatus) GetFailures() int64 {
+	return s.Failures
+}
+
+// SetExternalID of the managed resource.
+func (s *ResourceStatus) SetExternalID(id *ExternalID) {
+	s.ExternalID = id
+}
+
+// GetExternalID of the managed resource.
+func (s *ResourceStatus) GetExternalID() *ExternalID {
+	return s.ExternalID
+}
@@ -0,0 +1,80 @@
+package v1
+
+// A ManagementAction represents an action that a provider may take on an
+// external resource.
+// +kubebuilder:validation:Enum=Observe;Create;Update;Delete;LateInitialize
+type ManagementAction string
+
+// Management actions.
+const (
+	// ManagementActionObserve means the provider may observe the external
+	// resource.
+	ManagementActionObserve ManagementAction = "Observe"
+
+	// ManagementActionCreate means the provider may create the external
+	// resource.
+	ManagementActionCreate ManagementAction = "Create"
+
+	// ManagementActionUpdate means the provider may update the external
+	// resource.
+	ManagementActionUpdate ManagementAction = "Update"
+
+	// ManagementActionDelete means the provider may delete the external
+	// resource when the managed resource is deleted.
+	ManagementActionDelete ManagementAction = "Delete"
+
+	// ManagementActionLateInitialize means the provider may late initialize
+	// the managed resource's spec from the external resource.
+	ManagementActionLateInitialize ManagementAction = "LateInitialize"
+)
+
+// ManagementPolicies determine which actions a provider may take on an
+// external resource. Empty management policies allow every action, which is
+// the default.
+// +kubebuilder:validation:MaxItems=5
+// +listType=set
+type ManagementPolicies []ManagementAction
+
+// Has returns true if the management policies allow the supplied action.
+func (p ManagementPolicies) Has(a ManagementAction) bool {
+	if len(p) == 0 {
+		return true
+	}
+	for _, action := range p {
+		if action == a {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldObserve returns true if the external resource may be observed.
+func (p ManagementPolicies) ShouldObserve() bool {
+	return p.Has(ManagementActionObserve)
+}
+
+// ShouldCreate returns true if the external resource may be created.
+func (p ManagementPolicies) ShouldCreate() bool {
+	return p.Has(ManagementActionCreate)
+}
+
+// ShouldUpdate returns true if the external resource may be updated.
+func (p ManagementPolicies) ShouldUpdate() bool {
+	return p.Has(ManagementActionUpdate)
+}
+
+// ShouldDelete returns true if the external resource may be deleted.
+func (p ManagementPolicies) ShouldDelete() bool {
+	return p.Has(ManagementActionDelete)
+}
+
+// ShouldLateInitialize returns true if the managed resource's spec may be
+// late initialized from the external resource.
+func (p ManagementPolicies) ShouldLateInitialize() bool {
+	return p.Has(ManagementActionLateInitialize)
+}
+
+// IsObserveOnly returns true if the external resource may only be observed.
+func (p ManagementPolicies) IsObserveOnly() bool {
+	return len(p) == 1 && p[0] == ManagementActionObserve
+}
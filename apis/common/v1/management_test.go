@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestManagementPolicies(t *testing.T) {
+	type want struct {
+		observe        bool
+		create         bool
+		update         bool
+		delete         bool
+		lateInitialize bool
+		observeOnly    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      ManagementPolicies
+		want   want
+	}{
+		"Default": {
+			reason: "Empty management policies should allow every action.",
+			p:      nil,
+			want:   want{observe: true, create: true, update: true, delete: true, lateInitialize: true},
+		},
+		"ObserveOnly": {
+			reason: "Observe only management policies should allow only observing.",
+			p:      ManagementPolicies{ManagementActionObserve},
+			want:   want{observe: true, observeOnly: true},
+		},
+		"NoLateInitialize": {
+			reason: "Management policies should allow only the listed actions.",
+			p:      ManagementPolicies{ManagementActionObserve, ManagementActionCreate, ManagementActionUpdate, ManagementActionDelete},
+			want:   want{observe: true, create: true, update: true, delete: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{
+				observe:        tc.p.ShouldObserve(),
+				create:         tc.p.ShouldCreate(),
+				update:         tc.p.ShouldUpdate(),
+				delete:         tc.p.ShouldDelete(),
+				lateInitialize: tc.p.ShouldLateInitialize(),
+				observeOnly:    tc.p.IsObserveOnly(),
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nManagementPolicies: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package v1
+
+// A ProviderConfigSpec defines the desired state of a provider config. It is
+// intended to be embedded in the spec of provider config kinds.
+type ProviderConfigSpec struct {
+	// Credentials required to authenticate to the provider's API.
+	Credentials CredentialSelectors `json:"credentials"`
+}
+
+// A ProviderConfigStatus defines the observed status of a provider config. It
+// is intended to be embedded in the status of provider config kinds.
+type ProviderConfigStatus struct {
+	ConditionedStatus `json:",inline"`
+
+	// Users of this provider configuration.
+	// +optional
+	Users int64 `json:"users,omitempty"`
+}
+
+// A ProviderConfigUsage is a record that a particular managed resource is using
+// a particular provider configuration.
+type ProviderConfigUsage struct {
+	// ProviderConfigReference to the provider config being used.
+	ProviderConfigReference Reference `json:"providerConfigRef"`
+
+	// ResourceReference to the managed resource using the provider config.
+	ResourceReference TypedReference `json:"resourceRef"`
+}
@@ -1,5 +1,12 @@
 package v1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
 // A Reference to a named object.
 type Reference struct {
 	// Name of the referenced object.
@@ -9,6 +16,249 @@ type Reference struct {
 	Namespace string `json:"namespace"`
 }
 
+// A ResolvableReference to a named object that is resolved by a reference
+// resolver, typically to populate a field of the referencing object.
+type ResolvableReference struct {
+	Reference `json:",inline"`
+
+	// Policies for referencing.
+	// +optional
+	Policy *Policy `json:"policy,omitempty"`
+}
+
+// A ResolvableKeySelector is a reference to a key of a ConfigMap or Secret in
+// an arbitrary namespace, whose value is resolved by a reference resolver.
+type ResolvableKeySelector struct {
+	ResolvableReference `json:",inline"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
+// A LocalReference to a named object in an implied namespace, typically the
+// namespace of the referencing object.
+type LocalReference struct {
+	// Name of the referenced object.
+	Name string `json:"name"`
+}
+
+// A TypedReference refers to an object by Name, Kind, and APIVersion. It is
+// commonly used to reference cluster-scoped objects or objects where the
+// namespace is already known.
+type TypedReference struct {
+	// APIVersion of the referenced object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the referenced object.
+	Kind string `json:"kind"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+
+	// UID of the referenced object.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// TypedReferenceTo returns a typed reference to the supplied object, which is
+// of the supplied kind.
+func TypedReferenceTo(o metav1.Object, of schema.GroupVersionKind) TypedReference {
+	return TypedReference{
+		APIVersion: of.GroupVersion().String(),
+		Kind:       of.Kind,
+		Name:       o.GetName(),
+		UID:        o.GetUID(),
+	}
+}
+
+// TypedReferenceFromObjectReference returns a typed reference equivalent to
+// the supplied object reference. The namespace, resource version, and field
+// path of the object reference are discarded.
+func TypedReferenceFromObjectReference(r corev1.ObjectReference) TypedReference {
+	return TypedReference{
+		APIVersion: r.APIVersion,
+		Kind:       r.Kind,
+		Name:       r.Name,
+		UID:        r.UID,
+	}
+}
+
+// GroupVersionKind returns the kind of the referenced object.
+func (r TypedReference) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(r.APIVersion, r.Kind)
+}
+
+// ObjectReference returns an object reference equivalent to the typed
+// reference.
+func (r TypedReference) ObjectReference() corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: r.APIVersion,
+		Kind:       r.Kind,
+		Name:       r.Name,
+		UID:        r.UID,
+	}
+}
+
+// A ResolvePolicy determines when a reference should be resolved.
+// +kubebuilder:validation:Enum=Always;IfNotPresent
+type ResolvePolicy string
+
+// Resolve policies.
+const (
+	// ResolvePolicyIfNotPresent resolves the reference only when the field it
+	// populates is not already set. This is the default.
+	ResolvePolicyIfNotPresent ResolvePolicy = "IfNotPresent"
+
+	// ResolvePolicyAlways resolves the reference on every reconcile, even if
+	// the field it populates is already set.
+	ResolvePolicyAlways ResolvePolicy = "Always"
+)
+
+// A ResolutionPolicy determines whether a reference must resolve.
+// +kubebuilder:validation:Enum=Required;Optional
+type ResolutionPolicy string
+
+// Resolution policies.
+const (
+	// ResolutionPolicyRequired causes resolution to fail if the reference
+	// cannot be resolved. This is the default.
+	ResolutionPolicyRequired ResolutionPolicy = "Required"
+
+	// ResolutionPolicyOptional allows resolution to succeed if the reference
+	// cannot be resolved.
+	ResolutionPolicyOptional ResolutionPolicy = "Optional"
+)
+
+// A CrossNamespacePolicy determines whether a reference may refer to an
+// object in a namespace other than that of the referencing object.
+// +kubebuilder:validation:Enum=Allow;Deny
+type CrossNamespacePolicy string
+
+// Cross namespace policies.
+const (
+	// CrossNamespacePolicyAllow allows the reference to refer to an object in
+	// another namespace.
+	CrossNamespacePolicyAllow CrossNamespacePolicy = "Allow"
+
+	// CrossNamespacePolicyDeny causes resolution to fail if the reference
+	// refers to an object in another namespace.
+	CrossNamespacePolicyDeny CrossNamespacePolicy = "Deny"
+)
+
+// A Policy determines how a reference or selector is resolved.
+type Policy struct {
+	// Resolve specifies when this reference should be resolved. The default
+	// is 'IfNotPresent', which will attempt to resolve the reference only when
+	// the corresponding field is not present. Use 'Always' to resolve the
+	// reference on every reconcile.
+	// +optional
+	// +kubebuilder:default=IfNotPresent
+	// +kubebuilder:validation:Enum=Always;IfNotPresent
+	Resolve *ResolvePolicy `json:"resolve,omitempty"`
+
+	// Resolution specifies whether resolution of this reference is required.
+	// The default is 'Required', which means the reconcile will fail if the
+	// reference cannot be resolved. 'Optional' means this reference will be
+	// a no-op if it cannot be resolved.
+	// +optional
+	// +kubebuilder:default=Required
+	// +kubebuilder:validation:Enum=Required;Optional
+	Resolution *ResolutionPolicy `json:"resolution,omitempty"`
+
+	// CrossNamespace specifies whether this reference may refer to an object
+	// in a namespace other than that of the referencing object. The default
+	// is 'Allow', unless cross namespace references are denied by the
+	// provider. 'Deny' causes resolution to fail if the reference crosses
+	// namespaces.
+	// +optional
+	// +kubebuilder:validation:Enum=Allow;Deny
+	CrossNamespace *CrossNamespacePolicy `json:"crossNamespace,omitempty"`
+}
+
+// NewPolicy returns a policy with the supplied resolve and resolution
+// policies.
+func NewPolicy(resolve ResolvePolicy, resolution ResolutionPolicy) *Policy {
+	return &Policy{Resolve: &resolve, Resolution: &resolution}
+}
+
+// Default sets any unset resolve and resolution policies to their defaults,
+// which are IfNotPresent and Required respectively. The cross namespace policy
+// is left unset because its default depends on the provider.
+func (p *Policy) Default() {
+	if p == nil {
+		return
+	}
+	if p.Resolve == nil {
+		r := ResolvePolicyIfNotPresent
+		p.Resolve = &r
+	}
+	if p.Resolution == nil {
+		r := ResolutionPolicyRequired
+		p.Resolution = &r
+	}
+}
+
+// GetResolvePolicy returns the resolve policy of the policy, or the default
+// IfNotPresent policy if it is unset.
+func (p *Policy) GetResolvePolicy() ResolvePolicy {
+	if p == nil || p.Resolve == nil {
+		return ResolvePolicyIfNotPresent
+	}
+	return *p.Resolve
+}
+
+// GetResolutionPolicy returns the resolution policy of the policy, or the
+// default Required policy if it is unset.
+func (p *Policy) GetResolutionPolicy() ResolutionPolicy {
+	if p == nil || p.Resolution == nil {
+		return ResolutionPolicyRequired
+	}
+	return *p.Resolution
+}
+
+// IsResolutionPolicyOptional checks whether the resolution policy of the
+// policy is optional.
+func (p *Policy) IsResolutionPolicyOptional() bool {
+	if p == nil || p.Resolution == nil {
+		return false
+	}
+	return *p.Resolution == ResolutionPolicyOptional
+}
+
+// IsResolvePolicyAlways checks whether the resolve policy of the policy is
+// always.
+func (p *Policy) IsResolvePolicyAlways() bool {
+	if p == nil || p.Resolve == nil {
+		return false
+	}
+	return *p.Resolve == ResolvePolicyAlways
+}
+
+// IsCrossNamespacePolicyDeny checks whether the cross namespace policy of the
+// policy is deny.
+func (p *Policy) IsCrossNamespacePolicyDeny() bool {
+	if p == nil || p.CrossNamespace == nil {
+		return false
+	}
+	return *p.CrossNamespace == CrossNamespacePolicyDeny
+}
+
+// A Selector selects an object.
+type Selector struct {
+	// MatchLabels ensures an object with matching labels is selected.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchControllerRef ensures an object with the same controller reference
+	// as the selecting object is selected.
+	// +optional
+	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
+
+	// Policies for selection.
+	// +optional
+	Policy *Policy `json:"policy,omitempty"`
+}
+
 // CredentialSelectors provides selectors for extracting credentials.
 type CredentialSelectors struct {
 	// Env is a reference to an environment variable that contains credentials
@@ -20,6 +270,17 @@ type CredentialSelectors struct {
 	// that must be used to connect to the provider.
 	// +optional
 	SecretRef *SecretKeySelector `json:"secretRef,omitempty"`
+
+	// Fs is a reference to a filesystem location that contains credentials
+	// that must be used to connect to the provider.
+	// +optional
+	Fs *FsSelector `json:"fs,omitempty"`
+
+	// ServiceAccountToken requests a bound token for a service account that
+	// must be used to connect to the provider, for example using OIDC
+	// federation.
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenSelector `json:"serviceAccountToken,omitempty"`
 }
 
 // EnvSelector selects an environment variable.
@@ -28,12 +289,62 @@ type EnvSelector struct {
 	Name string `json:"name"`
 }
 
+// FsSelector selects a filesystem location.
+type FsSelector struct {
+	// Path is a filesystem path.
+	Path string `json:"path"`
+}
+
+// A ServiceAccountTokenSelector selects a service account for which a bound
+// token should be requested.
+type ServiceAccountTokenSelector struct {
+	Reference `json:",inline"`
+
+	// Audiences are the intended audiences of the token. The token issuer's
+	// audiences are used if none are specified.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ExpirationSeconds is the requested duration of validity of the token.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
 // A SecretKeySelector is a reference to a secret key in an arbitrary namespace.
 type SecretKeySelector struct {
 	Reference `json:",inline"`
 
 	// The key to select.
 	Key string `json:"key"`
+
+	// Optional specifies whether the secret and its key may be missing. A
+	// missing optional secret key is treated as an empty value.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// A LocalSecretKeySelector is a reference to a secret key in an implied
+// namespace, typically the namespace of the referencing object.
+type LocalSecretKeySelector struct {
+	LocalReference `json:",inline"`
+
+	// The key to select.
+	Key string `json:"key"`
+
+	// Optional specifies whether the secret and its key may be missing. A
+	// missing optional secret key is treated as an empty value.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// ToSecretKeySelector returns a SecretKeySelector that selects the same key
+// of the same secret in the supplied namespace.
+func (s *LocalSecretKeySelector) ToSecretKeySelector(namespace string) *SecretKeySelector {
+	return &SecretKeySelector{
+		Reference: Reference{Name: s.Name, Namespace: namespace},
+		Key:       s.Key,
+		Optional:  s.Optional,
+	}
 }
 
 // A ConfigMapKeySelector is a reference to a configmap key in an arbitrary namespace.
@@ -42,4 +353,9 @@ type ConfigMapKeySelector struct {
 
 	// The key to select.
 	Key string `json:"key"`
+
+	// Optional specifies whether the configmap and its key may be missing. A
+	// missing optional configmap key is treated as an empty value.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
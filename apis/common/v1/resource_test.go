@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestPolicyDefault(t *testing.T) {
+	always := ResolvePolicyAlways
+	optional := ResolutionPolicyOptional
+
+	cases := map[string]struct {
+		reason string
+		p      *Policy
+		want   *Policy
+	}{
+		"Nil": {
+			reason: "Defaulting a nil policy should be a no-op.",
+			p:      nil,
+			want:   nil,
+		},
+		"Unset": {
+			reason: "Unset resolve and resolution policies should be defaulted.",
+			p:      &Policy{},
+			want:   NewPolicy(ResolvePolicyIfNotPresent, ResolutionPolicyRequired),
+		},
+		"Set": {
+			reason: "Set resolve and resolution policies should not be changed.",
+			p:      &Policy{Resolve: &always, Resolution: &optional},
+			want:   NewPolicy(ResolvePolicyAlways, ResolutionPolicyOptional),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.p.Default()
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("\n%s\nDefault(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTypedReference(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Cool"}
+	o := &metav1.ObjectMeta{Name: "cool", UID: types.UID("cool-uid")}
+
+	want := TypedReference{APIVersion: "example.org/v1", Kind: "Cool", Name: "cool", UID: "cool-uid"}
+	got := TypedReferenceTo(o, gvk)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("TypedReferenceTo(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(gvk, got.GroupVersionKind()); diff != "" {
+		t.Errorf("GroupVersionKind(): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(want, TypedReferenceFromObjectReference(got.ObjectReference())); diff != "" {
+		t.Errorf("TypedReferenceFromObjectReference(ObjectReference()): -want, +got:\n%s", diff)
+	}
+}
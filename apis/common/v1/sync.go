@@ -0,0 +1,44 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A SyncStatus records when a managed resource was last synced with its
+// external resource. It may be inlined into the status of managed resources
+// so that tooling can tell how stale they are.
+type SyncStatus struct {
+	// LastSyncAttemptTime is the last time the managed resource was
+	// reconciled, whether or not the reconcile succeeded.
+	// +optional
+	LastSyncAttemptTime *metav1.Time `json:"lastSyncAttemptTime,omitempty"`
+
+	// LastSuccessfulSyncTime is the last time the managed resource was
+	// successfully reconciled.
+	// +optional
+	LastSuccessfulSyncTime *metav1.Time `json:"lastSuccessfulSyncTime,omitempty"`
+}
+
+// SetLastSyncAttemptTime sets the last time the managed resource was
+// reconciled.
+func (s *SyncStatus) SetLastSyncAttemptTime(t metav1.Time) {
+	s.LastSyncAttemptTime = &t
+}
+
+// GetLastSyncAttemptTime returns the last time the managed resource was
+// reconciled, if any.
+func (s *SyncStatus) GetLastSyncAttemptTime() *metav1.Time {
+	return s.LastSyncAttemptTime
+}
+
+// SetLastSuccessfulSyncTime sets the last time the managed resource was
+// successfully reconciled.
+func (s *SyncStatus) SetLastSuccessfulSyncTime(t metav1.Time) {
+	s.LastSuccessfulSyncTime = &t
+}
+
+// GetLastSuccessfulSyncTime returns the last time the managed resource was
+// successfully reconciled, if any.
+func (s *SyncStatus) GetLastSuccessfulSyncTime() *metav1.Time {
+	return s.LastSuccessfulSyncTime
+}
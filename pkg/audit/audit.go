@@ -0,0 +1,119 @@
+// Package audit records the operations providers perform on external
+// resources.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/redact"
+)
+
+// An Operation performed on an external system.
+type Operation string
+
+// Operations.
+const (
+	OperationConnect Operation = "Connect"
+	OperationObserve Operation = "Observe"
+	OperationCreate  Operation = "Create"
+	OperationUpdate  Operation = "Update"
+	OperationDelete  Operation = "Delete"
+)
+
+// An Outcome of an operation.
+type Outcome string
+
+// Outcomes.
+const (
+	OutcomeSuccess Outcome = "Success"
+	OutcomeFailure Outcome = "Failure"
+)
+
+// A Record of an operation performed on an external system.
+type Record struct {
+	// Time at which the operation started.
+	Time time.Time `json:"time"`
+
+	// ReconcileID identifies the reconcile that performed the operation.
+	ReconcileID string `json:"reconcileID,omitempty"`
+
+	// The group, version, kind, namespace, and name of the managed resource
+	// the operation was performed for.
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// ExternalName of the managed resource.
+	ExternalName string `json:"externalName,omitempty"`
+
+	Operation Operation `json:"operation"`
+	Outcome   Outcome   `json:"outcome"`
+
+	// Error returned by a failed operation, with any secrets it contained
+	// redacted.
+	Error string `json:"error,omitempty"`
+
+	// DurationMillis is how long the operation took, in milliseconds.
+	DurationMillis int64 `json:"durationMs"`
+}
+
+// NewRecord returns a record of an operation that started at the supplied
+// time and returned the supplied error, which may be nil. Its duration is the
+// time elapsed since it started. Secrets in the error are redacted, because
+// records are often persisted. Callers are expected to fill in the resource
+// it relates to.
+func NewRecord(op Operation, start time.Time, err error) Record {
+	r := Record{
+		Time:           start,
+		Operation:      op,
+		Outcome:        OutcomeSuccess,
+		DurationMillis: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		r.Outcome = OutcomeFailure
+		r.Error = redact.Redact(err.Error())
+	}
+	return r
+}
+
+// A Logger logs records of operations performed on external systems. Audit
+// loggers are distinct from the human oriented logging.Logger; they're
+// intended to produce a complete, machine readable record.
+type Logger interface {
+	Log(r Record)
+}
+
+// A LoggerFn is a function that satisfies Logger.
+type LoggerFn func(r Record)
+
+// Log the supplied record.
+func (fn LoggerFn) Log(r Record) {
+	fn(r)
+}
+
+// NewNopLogger returns a Logger that does nothing.
+func NewNopLogger() Logger { return LoggerFn(func(_ Record) {}) }
+
+// A JSONLogger writes records as JSON lines, one record per line.
+type JSONLogger struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLogger returns a JSONLogger that writes records to the supplied
+// writer, for example a file dedicated to audit records.
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{enc: json.NewEncoder(w)}
+}
+
+// Log writes the supplied record. Records that can't be written are dropped.
+func (l *JSONLogger) Log(r Record) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(r)
+}
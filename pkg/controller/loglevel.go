@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+)
+
+// LogLevelConfigMapKey is the key of the ConfigMap data read by a
+// LogLevelWatcher.
+const LogLevelConfigMapKey = "log-level"
+
+const defaultLogLevelPollInterval = 30 * time.Second
+
+// A LogLevelWatcher sets an AtomicLevel from the log-level key of a
+// ConfigMap, so that operators may change how verbosely a provider logs
+// without restarting it. The level is left unchanged while the ConfigMap or
+// its log-level key doesn't exist, or its value is invalid.
+type LogLevelWatcher struct {
+	client   client.Reader
+	cm       types.NamespacedName
+	level    *logging.AtomicLevel
+	interval time.Duration
+	log      logging.Logger
+}
+
+// A LogLevelWatcherOption configures a LogLevelWatcher.
+type LogLevelWatcherOption func(*LogLevelWatcher)
+
+// WithLogLevelPollInterval configures how often the ConfigMap is read. The
+// default is every 30 seconds.
+func WithLogLevelPollInterval(d time.Duration) LogLevelWatcherOption {
+	return func(w *LogLevelWatcher) {
+		w.interval = d
+	}
+}
+
+// WithLogLevelLogger configures the logger used to report changes to the
+// level, and ConfigMaps that can't be read.
+func WithLogLevelLogger(l logging.Logger) LogLevelWatcherOption {
+	return func(w *LogLevelWatcher) {
+		w.log = l
+	}
+}
+
+// NewLogLevelWatcher returns a LogLevelWatcher that sets the supplied level
+// from the named ConfigMap. The ConfigMap is read using the supplied client,
+// which typically should be the manager's API reader, so that ConfigMaps are
+// not cached.
+func NewLogLevelWatcher(c client.Reader, cm types.NamespacedName, l *logging.AtomicLevel, o ...LogLevelWatcherOption) *LogLevelWatcher {
+	w := &LogLevelWatcher{
+		client:   c,
+		cm:       cm,
+		level:    l,
+		interval: defaultLogLevelPollInterval,
+		log:      logging.NewNopLogger(),
+	}
+	for _, wo := range o {
+		wo(w)
+	}
+	return w
+}
+
+// Start reading the ConfigMap until the supplied context is done. It
+// satisfies manager.Runnable, so a LogLevelWatcher may be added to a manager.
+func (w *LogLevelWatcher) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, w.sync, w.interval)
+	return nil
+}
+
+func (w *LogLevelWatcher) sync(ctx context.Context) {
+	cm := &corev1.ConfigMap{}
+	if err := w.client.Get(ctx, w.cm, cm); err != nil {
+		if !kerrors.IsNotFound(err) {
+			w.log.Debug("Cannot get log level ConfigMap", "configmap", w.cm, "error", err)
+		}
+		return
+	}
+
+	v, ok := cm.Data[LogLevelConfigMapKey]
+	if !ok || v == w.level.String() {
+		return
+	}
+	if err := w.level.Set(v); err != nil {
+		w.log.Info("Ignoring invalid log level", "configmap", w.cm, "error", err)
+		return
+	}
+	w.log.Info("Changed log level", "configmap", w.cm, "level", v)
+}
+
+var _ manager.Runnable = &LogLevelWatcher{}
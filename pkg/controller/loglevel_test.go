@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestLogLevelWatcherSync(t *testing.T) {
+	withData := func(data map[string]string) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.ConfigMap).Data = data
+			return nil
+		})
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		want   int
+	}{
+		"Changed": {
+			reason: "The level should be set from the log-level key of the ConfigMap.",
+			c:      &test.MockClient{MockGet: withData(map[string]string{LogLevelConfigMapKey: "3"})},
+			want:   3,
+		},
+		"NotFound": {
+			reason: "The level should be unchanged while the ConfigMap doesn't exist.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cm"))},
+			want:   1,
+		},
+		"GetError": {
+			reason: "The level should be unchanged if the ConfigMap can't be read.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			want:   1,
+		},
+		"NoKey": {
+			reason: "The level should be unchanged if the ConfigMap has no log-level key.",
+			c:      &test.MockClient{MockGet: withData(map[string]string{"other": "3"})},
+			want:   1,
+		},
+		"Invalid": {
+			reason: "The level should be unchanged if the log-level key is invalid.",
+			c:      &test.MockClient{MockGet: withData(map[string]string{LogLevelConfigMapKey: "verbose"})},
+			want:   1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := logging.NewAtomicLevel(1)
+			w := NewLogLevelWatcher(tc.c, types.NamespacedName{Namespace: "ns", Name: "cm"}, l)
+			w.sync(context.Background())
+			if diff := cmp.Diff(tc.want, l.Level()); diff != "" {
+				t.Errorf("\n%s\nw.sync(...): -want level, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
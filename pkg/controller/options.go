@@ -1,16 +1,43 @@
 package controller
 
 import (
+	"context"
 	"time"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
 	"github.com/krateoplatformops/provider-runtime/pkg/logging"
 	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
 )
 
+// Error strings.
+const (
+	errNewEventsClient    = "cannot create events client"
+	errAddEventsRunnable  = "cannot add events broadcaster to manager"
+	errNoEventBroadcaster = "an event broadcaster is required to record events to the events.k8s.io/v1 API"
+	errFmtEventsAPI       = "unknown events API %q"
+)
+
+// An EventsAPI to which controllers record events.
+type EventsAPI string
+
+// Events APIs.
+const (
+	// EventsAPICoreV1 is the legacy core/v1 events API.
+	EventsAPICoreV1 EventsAPI = "core/v1"
+
+	// EventsAPIEventsV1 is the events.k8s.io/v1 events API. The API server
+	// manages the series of similar events recorded to it.
+	EventsAPIEventsV1 EventsAPI = "events.k8s.io/v1"
+)
+
 // DefaultOptions returns a functional set of options with conservative
 // defaults.
 func DefaultOptions() Options {
@@ -37,12 +64,104 @@ type Options struct {
 
 	// MaxConcurrentReconciles for each controller.
 	MaxConcurrentReconciles int
+
+	// EventFilter determines which events controllers record. Every event is
+	// recorded by default.
+	EventFilter event.Filter
+
+	// EventsAPI to which controllers record events. Events are recorded to
+	// the legacy core/v1 API by default.
+	EventsAPI EventsAPI
+
+	// EventBroadcaster with which controllers record events to the
+	// events.k8s.io/v1 API, typically built by NewEventBroadcaster. It is
+	// required if the EventsAPI is EventsAPIEventsV1, and is shared by every
+	// controller built using these options.
+	EventBroadcaster events.EventBroadcaster
+
+	// RateLimiter determines how long controllers wait before retrying a
+	// failed request, for example the exponential timed failure rate limiter
+	// of this module's workqueue package. The same rate limiter
+	// is used by every controller built using these options, so requests of
+	// different kinds with the same name share their backoff unless each
+	// controller is given its own. Each controller is given an exponential
+	// backoff from 1s to 60s by default.
+	RateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+}
+
+// NewEventRecorder returns an event recorder for the named controller. It
+// records events to the configured EventsAPI, filtered according to the
+// EventFilter.
+func (o Options) NewEventRecorder(mgr manager.Manager, name string) (event.Recorder, error) {
+	switch o.EventsAPI {
+	case "", EventsAPICoreV1:
+		return o.ForEventRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name), event.WithScheme(mgr.GetScheme()))), nil
+	case EventsAPIEventsV1:
+		if o.EventBroadcaster == nil {
+			return nil, errors.New(errNoEventBroadcaster)
+		}
+		return o.ForEventRecorder(event.NewEventsAPIRecorder(o.EventBroadcaster.NewRecorder(mgr.GetScheme(), name), event.WithScheme(mgr.GetScheme()))), nil
+	default:
+		return nil, errors.Errorf(errFmtEventsAPI, o.EventsAPI)
+	}
+}
+
+// NewEventBroadcaster returns an event broadcaster that records events to the
+// events.k8s.io/v1 API of the supplied manager's API server. The broadcaster
+// runs, and is shut down, with the manager. It should be built once per
+// manager and supplied to its controllers as the EventBroadcaster option.
+func NewEventBroadcaster(mgr manager.Manager) (events.EventBroadcaster, error) {
+	cs, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, errNewEventsClient)
+	}
+	b := events.NewBroadcaster(&events.EventSinkImpl{Interface: cs.EventsV1()})
+	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		if err := b.StartRecordingToSinkWithContext(ctx); err != nil {
+			return err
+		}
+		<-ctx.Done()
+		b.Shutdown()
+		return nil
+	}))
+	if err != nil {
+		return nil, errors.Wrap(err, errAddEventsRunnable)
+	}
+	return b, nil
+}
+
+// ForEventRecorder returns the supplied event recorder, filtered according
+// to the EventFilter.
+func (o Options) ForEventRecorder(r event.Recorder) event.Recorder {
+	return event.NewFilteringRecorder(r, o.EventFilter)
+}
+
+// A RateLimitedReconciler wraps the rate limiter of the controller that runs
+// it, for example so that it honors the rate limiting policies of the
+// resources it reconciles.
+type RateLimitedReconciler interface {
+	reconcile.Reconciler
+
+	RateLimiter(l workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimiter[reconcile.Request]
+}
+
+// ForReconciler extracts options for a controller-runtime controller that runs
+// the supplied reconciler, such as a managed resource reconciler. Its rate
+// limiter is wrapped by the reconciler.
+func (o Options) ForReconciler(r RateLimitedReconciler) controller.Options {
+	co := o.ForControllerRuntime()
+	co.RateLimiter = r.RateLimiter(co.RateLimiter)
+	return co
 }
 
 // ForControllerRuntime extracts options for controller-runtime.
 func (o Options) ForControllerRuntime() controller.Options {
+	rl := o.RateLimiter
+	if rl == nil {
+		rl = workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](1*time.Second, 60*time.Second)
+	}
 	return controller.TypedOptions[reconcile.Request]{
 		MaxConcurrentReconciles: o.MaxConcurrentReconciles,
-		RateLimiter:             workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](1*time.Second, 60*time.Second),
+		RateLimiter:             rl,
 	}
 }
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	rfake "github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestNewEventRecorder(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      Options
+		want   error
+	}{
+		"EventsAPIWithBroadcaster": {
+			reason: "A recorder should be built from the supplied event broadcaster.",
+			o: Options{
+				EventsAPI:        EventsAPIEventsV1,
+				EventBroadcaster: events.NewBroadcaster(&events.EventSinkImpl{Interface: fake.NewSimpleClientset().EventsV1()}),
+			},
+		},
+		"EventsAPIWithoutBroadcaster": {
+			reason: "An error should be returned if no event broadcaster was supplied.",
+			o:      Options{EventsAPI: EventsAPIEventsV1},
+			want:   errors.New(errNoEventBroadcaster),
+		},
+		"UnknownEventsAPI": {
+			reason: "An error should be returned if the events API is unknown.",
+			o:      Options{EventsAPI: "cool/v1"},
+			want:   errors.Errorf(errFmtEventsAPI, "cool/v1"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.o.NewEventRecorder(&rfake.Manager{Scheme: rfake.SchemeWith()}, "cool")
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\no.NewEventRecorder(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
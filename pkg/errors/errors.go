@@ -50,6 +50,12 @@ func As(err error, target any) bool { return errors.As(err, target) }
 // contains an Unwrap method returning error. Otherwise, Unwrap returns nil.
 func Unwrap(err error) error { return errors.Unwrap(err) }
 
+// Join returns an error that wraps the given errors. Any nil error values are
+// discarded. Join returns nil if every value in errs is nil. The error formats
+// as the concatenation of the strings obtained by calling the Error method of
+// each element of errs, with a newline between each string.
+func Join(errs ...error) error { return errors.Join(errs...) }
+
 // Errorf formats according to a format specifier and returns the string as a
 // value that satisfies error.
 //
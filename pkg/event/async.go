@@ -0,0 +1,190 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+)
+
+const defaultAsyncQueueSize = 1024
+
+// An AsyncRecorder decorates a Recorder, queueing events and recording them
+// asynchronously so that recording an event never blocks a reconcile, for
+// example when the API server is slow to respond. Queued events are recorded
+// one at a time, in the order they were queued, by a goroutine that runs
+// until the AsyncRecorder is closed. Events are dropped when the queue is
+// full. Call Close on shutdown to record the events still queued and stop the
+// goroutine.
+type AsyncRecorder struct {
+	wrapped Recorder
+
+	// queue is shared with the recorders returned by WithAnnotations and
+	// WithLabels, which each record their events using their own wrapped
+	// recorder.
+	queue *asyncQueue
+	log   logging.Logger
+}
+
+type asyncQueue struct {
+	items chan asyncItem
+
+	// closing is closed when Close is called, and stopped once the queued
+	// events have been recorded and the goroutine has exited.
+	closing chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+type asyncItem struct {
+	r   Recorder
+	obj runtime.Object
+	e   Event
+
+	// flushed is closed once the queued events before this item have been
+	// recorded. Items with a flushed channel carry no event.
+	flushed chan struct{}
+}
+
+type asyncConfig struct {
+	queueSize int
+	log       logging.Logger
+}
+
+// An AsyncRecorderOption configures an AsyncRecorder.
+type AsyncRecorderOption func(*asyncConfig)
+
+// WithAsyncQueueSize configures how many events may be queued before events
+// are dropped. The default is 1024.
+func WithAsyncQueueSize(n int) AsyncRecorderOption {
+	return func(c *asyncConfig) {
+		c.queueSize = n
+	}
+}
+
+// WithAsyncLogger configures the logger used to report events that are
+// dropped because the queue is full. Such events are not logged by default.
+func WithAsyncLogger(l logging.Logger) AsyncRecorderOption {
+	return func(c *asyncConfig) {
+		c.log = l
+	}
+}
+
+// NewAsyncRecorder returns an AsyncRecorder that queues events and records
+// them asynchronously using the supplied Recorder.
+func NewAsyncRecorder(r Recorder, o ...AsyncRecorderOption) *AsyncRecorder {
+	c := &asyncConfig{
+		queueSize: defaultAsyncQueueSize,
+		log:       logging.NewNopLogger(),
+	}
+	for _, ao := range o {
+		ao(c)
+	}
+	if c.queueSize < 1 {
+		c.queueSize = 1
+	}
+
+	q := &asyncQueue{
+		items:   make(chan asyncItem, c.queueSize),
+		closing: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go q.run()
+	return &AsyncRecorder{wrapped: r, queue: q, log: c.log}
+}
+
+// Event queues the supplied event to be recorded. The event is dropped if
+// the queue is full, or the AsyncRecorder is closed.
+func (r *AsyncRecorder) Event(obj runtime.Object, e Event) {
+	select {
+	case <-r.queue.closing:
+		r.log.Debug("Cannot queue event after the recorder was closed, dropping it", "reason", e.Reason)
+		return
+	default:
+	}
+	select {
+	case r.queue.items <- asyncItem{r: r.wrapped, obj: obj, e: e}:
+	default:
+		r.log.Debug("Cannot queue event, dropping it", "reason", e.Reason)
+	}
+}
+
+// WithAnnotations returns a new *AsyncRecorder that includes the supplied
+// annotations with all recorded events. It shares its queue with the
+// original recorder.
+func (r *AsyncRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	return &AsyncRecorder{wrapped: r.wrapped.WithAnnotations(keysAndValues...), queue: r.queue, log: r.log}
+}
+
+// WithLabels returns a new *AsyncRecorder that includes the supplied labels
+// with all recorded events. It shares its queue with the original recorder.
+func (r *AsyncRecorder) WithLabels(keysAndValues ...string) Recorder {
+	return &AsyncRecorder{wrapped: r.wrapped.WithLabels(keysAndValues...), queue: r.queue, log: r.log}
+}
+
+// Flush blocks until all events queued before it was called have been
+// recorded, or the supplied context is done. It returns immediately once the
+// AsyncRecorder is closed.
+func (r *AsyncRecorder) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	select {
+	case r.queue.items <- asyncItem{flushed: flushed}:
+	case <-r.queue.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+		return nil
+	case <-r.queue.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the AsyncRecorder, and any recorders returned by its
+// WithAnnotations and WithLabels methods, from queueing events. It blocks
+// until the events already queued have been recorded and the goroutine that
+// records them has exited, or the supplied context is done. Events recorded
+// after Close is called are dropped. Close may be called more than once.
+func (r *AsyncRecorder) Close(ctx context.Context) error {
+	r.queue.once.Do(func() { close(r.queue.closing) })
+	select {
+	case <-r.queue.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.stopped)
+	for {
+		select {
+		case i := <-q.items:
+			i.record()
+		case <-q.closing:
+			// Record whatever was queued before we were closed.
+			for {
+				select {
+				case i := <-q.items:
+					i.record()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (i asyncItem) record() {
+	if i.flushed != nil {
+		close(i.flushed)
+		return
+	}
+	i.r.Event(i.obj, i.e)
+}
@@ -0,0 +1,157 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type blockingRecorder struct {
+	Recorder
+	release chan struct{}
+}
+
+func (r blockingRecorder) Event(obj runtime.Object, e Event) {
+	<-r.release
+	r.Recorder.Event(obj, e)
+}
+
+func TestAsyncRecorder(t *testing.T) {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+
+	reasons := func(m *MemoryRecorder) []Reason {
+		out := []Reason{}
+		for _, e := range m.Events() {
+			out = append(out, e.Reason)
+		}
+		return out
+	}
+
+	cases := map[string]struct {
+		reason  string
+		opts    []AsyncRecorderOption
+		block   bool
+		records []Reason
+		want    []Reason
+	}{
+		"Flush": {
+			reason:  "Flush should return once all queued events have been recorded, in order.",
+			records: []Reason{"A", "B", "C"},
+			want:    []Reason{"A", "B", "C"},
+		},
+		"QueueFull": {
+			reason:  "Events should be dropped rather than block when the queue is full.",
+			opts:    []AsyncRecorderOption{WithAsyncQueueSize(1)},
+			block:   true,
+			records: []Reason{"A", "B", "C"},
+			want:    []Reason{"A", "B"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := NewMemoryRecorder(10)
+			release := make(chan struct{})
+			var wrapped Recorder = m
+			if tc.block {
+				wrapped = blockingRecorder{Recorder: m, release: release}
+			} else {
+				close(release)
+			}
+
+			r := NewAsyncRecorder(wrapped, tc.opts...)
+			for i, reason := range tc.records {
+				r.Event(obj, Normal(reason, string(reason)))
+				if tc.block && i == 0 {
+					// Wait for the first event to be dequeued, so that
+					// it blocks the recorder with an empty queue.
+					for len(r.queue.items) > 0 {
+						time.Sleep(time.Millisecond)
+					}
+				}
+			}
+			if tc.block {
+				close(release)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := r.Flush(ctx); err != nil {
+				t.Fatalf("\n%s\nFlush(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, reasons(m)); diff != "" {
+				t.Errorf("\n%s\nEvent(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAsyncRecorderClose(t *testing.T) {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+	m := NewMemoryRecorder(10)
+	release := make(chan struct{})
+	r := NewAsyncRecorder(blockingRecorder{Recorder: m, release: release})
+	annotated := r.WithAnnotations("phase", "create")
+
+	r.Event(obj, Normal("A", "A"))
+	annotated.Event(obj, Normal("B", "B"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	closed := make(chan error)
+	go func() { closed <- r.Close(ctx) }()
+	close(release)
+	if err := <-closed; err != nil {
+		t.Fatalf("Close(...): %v", err)
+	}
+
+	select {
+	case <-r.queue.stopped:
+	default:
+		t.Errorf("Close(...): want the recording goroutine stopped")
+	}
+
+	// Events recorded after Close are dropped, and Flush returns at once.
+	annotated.Event(obj, Normal("C", "C"))
+	if err := r.Flush(ctx); err != nil {
+		t.Errorf("Flush(...): %v", err)
+	}
+	if err := r.Close(ctx); err != nil {
+		t.Errorf("Close(...): calling Close again: %v", err)
+	}
+
+	got := []Reason{}
+	for _, e := range m.Events() {
+		got = append(got, e.Reason)
+	}
+	if diff := cmp.Diff([]Reason{"A", "B"}, got); diff != "" {
+		t.Errorf("Close(...): want the queued events recorded, -want, +got:\n%s", diff)
+	}
+}
+
+func TestAsyncRecorderWithAnnotations(t *testing.T) {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+	m := NewMemoryRecorder(1)
+	r := NewAsyncRecorder(m)
+	r.WithAnnotations("phase", "create").WithLabels("provider", "cool").Event(obj, Normal("Created", "created"))
+
+	if err := r.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush(...): %v", err)
+	}
+	want := Event{
+		Type:        TypeNormal,
+		Reason:      "Created",
+		Message:     "created",
+		Annotations: map[string]string{"phase": "create"},
+		Labels:      map[string]string{"provider": "cool"},
+	}
+	if diff := cmp.Diff(want, m.Events()[0].Event); diff != "" {
+		t.Errorf("Event(...): -want, +got:\n%s", diff)
+	}
+}
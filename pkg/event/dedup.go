@@ -0,0 +1,176 @@
+package event
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// A DedupRecorder decorates a Recorder, aggregating identical events. The
+// first of a series of events with the same object, type, reason, and message
+// is recorded immediately. Identical events recorded within the supplied
+// window of it are suppressed and counted, and the count is recorded with the
+// first identical event after the window ends. This keeps events that are
+// emitted on every reconcile, for example while an external API is down,
+// from flooding the API server.
+type DedupRecorder struct {
+	wrapped Recorder
+	window  time.Duration
+	now     func() time.Time
+
+	// seen is shared with the recorders returned by WithAnnotations, which
+	// is called for each reconcile.
+	seen *dedupCache
+}
+
+type dedupKey struct {
+	object  string
+	typ     Type
+	reason  Reason
+	message string
+}
+
+type dedupEntry struct {
+	since      time.Time
+	suppressed int
+
+	// The Recorder, object, and event of the most recently suppressed event,
+	// used to record the summary.
+	recorder Recorder
+	obj      runtime.Object
+	event    Event
+}
+
+type dedupCache struct {
+	mu      sync.Mutex
+	entries map[dedupKey]*dedupEntry
+	pruned  time.Time
+}
+
+// NewDedupRecorder returns a DedupRecorder that aggregates identical events
+// recorded within the supplied window.
+func NewDedupRecorder(r Recorder, window time.Duration) *DedupRecorder {
+	return &DedupRecorder{
+		wrapped: r,
+		window:  window,
+		now:     time.Now,
+		seen:    &dedupCache{entries: map[dedupKey]*dedupEntry{}},
+	}
+}
+
+// Event records the supplied event, unless an identical event was recorded
+// within the window.
+func (r *DedupRecorder) Event(obj runtime.Object, e Event) {
+	m, err := kmeta.Accessor(obj)
+	if err != nil {
+		// We can't tell which object this is, so we can't deduplicate.
+		r.wrapped.Event(obj, e)
+		return
+	}
+	k := dedupKey{
+		object:  m.GetNamespace() + "/" + m.GetName() + "/" + string(m.GetUID()),
+		typ:     e.Type,
+		reason:  e.Reason,
+		message: e.Message,
+	}
+
+	now := r.now()
+	r.seen.mu.Lock()
+	en, ok := r.seen.entries[k]
+	if ok && now.Sub(en.since) < r.window {
+		en.suppressed++
+		en.recorder, en.obj, en.event = r.wrapped, obj, e
+		r.seen.mu.Unlock()
+		return
+	}
+	suppressed := 0
+	if ok {
+		suppressed = en.suppressed
+	}
+	r.seen.entries[k] = &dedupEntry{since: now}
+	summaries := r.seen.prune(now, r.window)
+	r.seen.mu.Unlock()
+
+	// Record outside the lock, so that a slow Recorder doesn't block others.
+	for _, s := range summaries {
+		s.summarize(now)
+	}
+	if suppressed > 0 {
+		e.Message = fmt.Sprintf("%s (repeated %d times in the last %s)", e.Message, suppressed+1, now.Sub(en.since).Round(time.Second))
+	}
+	r.wrapped.Event(obj, e)
+}
+
+// Flush records a summary of any events that were suppressed, and resets
+// their windows.
+func (r *DedupRecorder) Flush() {
+	now := r.now()
+	r.seen.mu.Lock()
+	var summaries []dedupEntry
+	for _, en := range r.seen.entries {
+		if en.suppressed > 0 {
+			summaries = append(summaries, *en)
+		}
+	}
+	clear(r.seen.entries)
+	r.seen.mu.Unlock()
+
+	for _, s := range summaries {
+		s.summarize(now)
+	}
+}
+
+// WithAnnotations returns a new *DedupRecorder that includes the supplied
+// annotations with all recorded events. Annotations are not considered when
+// deduplicating events.
+func (r *DedupRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	return &DedupRecorder{
+		wrapped: r.wrapped.WithAnnotations(keysAndValues...),
+		window:  r.window,
+		now:     r.now,
+		seen:    r.seen,
+	}
+}
+
+// WithLabels returns a new *DedupRecorder that includes the supplied labels
+// with all recorded events. Labels are not considered when deduplicating
+// events.
+func (r *DedupRecorder) WithLabels(keysAndValues ...string) Recorder {
+	return &DedupRecorder{
+		wrapped: r.wrapped.WithLabels(keysAndValues...),
+		window:  r.window,
+		now:     r.now,
+		seen:    r.seen,
+	}
+}
+
+// prune forgets series of events whose window has ended, so that the cache
+// doesn't grow without bound, and returns those that suppressed events so
+// that their count isn't lost. It prunes at most once per window. The cache
+// must be locked.
+func (c *dedupCache) prune(now time.Time, window time.Duration) []dedupEntry {
+	if now.Sub(c.pruned) < window {
+		return nil
+	}
+	var summaries []dedupEntry
+	for k, en := range c.entries {
+		if now.Sub(en.since) < window {
+			continue
+		}
+		if en.suppressed > 0 {
+			summaries = append(summaries, *en)
+		}
+		delete(c.entries, k)
+	}
+	c.pruned = now
+	return summaries
+}
+
+func (en dedupEntry) summarize(now time.Time) {
+	e := en.event
+	e.Message = fmt.Sprintf("%s (repeated %d times in the last %s)", e.Message, en.suppressed, now.Sub(en.since).Round(time.Second))
+	en.recorder.Event(en.obj, e)
+}
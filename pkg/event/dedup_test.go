@@ -0,0 +1,133 @@
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type recorded struct {
+	Object string
+	Event  Event
+}
+
+type capturingRecorder struct {
+	events *[]recorded
+}
+
+func (r capturingRecorder) Event(obj runtime.Object, e Event) {
+	*r.events = append(*r.events, recorded{Object: obj.(metav1.Object).GetName(), Event: e})
+}
+
+func (r capturingRecorder) WithAnnotations(_ ...string) Recorder { return r }
+func (r capturingRecorder) WithLabels(_ ...string) Recorder      { return r }
+
+func TestDedupRecorder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	b := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+	boom := Warning("CannotObserve", errors.New("boom"))
+	bang := Warning("CannotObserve", errors.New("bang"))
+
+	type record struct {
+		after time.Duration
+		obj   runtime.Object
+		e     Event
+	}
+
+	cases := map[string]struct {
+		reason  string
+		records []record
+		want    []recorded
+	}{
+		"Identical": {
+			reason: "Identical events within the window should be recorded once.",
+			records: []record{
+				{after: 0, obj: a, e: boom},
+				{after: time.Second, obj: a, e: boom},
+				{after: 2 * time.Second, obj: a, e: boom},
+			},
+			want: []recorded{{Object: "a", Event: boom}},
+		},
+		"Different": {
+			reason: "Events with different objects or messages should not be deduplicated.",
+			records: []record{
+				{after: 0, obj: a, e: boom},
+				{after: time.Second, obj: b, e: boom},
+				{after: 2 * time.Second, obj: a, e: bang},
+			},
+			want: []recorded{{Object: "a", Event: boom}, {Object: "b", Event: boom}, {Object: "a", Event: bang}},
+		},
+		"WindowEnded": {
+			reason: "The first identical event after the window should be recorded with the count of suppressed events.",
+			records: []record{
+				{after: 0, obj: a, e: boom},
+				{after: time.Second, obj: a, e: boom},
+				{after: time.Minute, obj: a, e: boom},
+				{after: 2 * time.Minute, obj: a, e: boom},
+			},
+			want: []recorded{
+				{Object: "a", Event: boom},
+				{Object: "a", Event: Event{Type: TypeWarning, Reason: "CannotObserve", Message: "boom (repeated 2 times in the last 1m0s)", Annotations: map[string]string{}}},
+				{Object: "a", Event: boom},
+			},
+		},
+		"Pruned": {
+			reason: "The count of suppressed events should be recorded when their series is pruned, even if the event doesn't recur.",
+			records: []record{
+				{after: 0, obj: a, e: boom},
+				{after: time.Second, obj: a, e: boom},
+				{after: 2 * time.Second, obj: a, e: boom},
+				{after: 2 * time.Minute, obj: b, e: boom},
+			},
+			want: []recorded{
+				{Object: "a", Event: boom},
+				{Object: "a", Event: Event{Type: TypeWarning, Reason: "CannotObserve", Message: "boom (repeated 2 times in the last 2m0s)", Annotations: map[string]string{}}},
+				{Object: "b", Event: boom},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := []recorded{}
+			r := NewDedupRecorder(capturingRecorder{events: &got}, time.Minute)
+			for _, rec := range tc.records {
+				r.now = func() time.Time { return start.Add(rec.after) }
+				r.WithAnnotations("external-name", "cool").Event(rec.obj, rec.e)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEvent(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDedupRecorderFlush(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	boom := Warning("CannotObserve", errors.New("boom"))
+
+	got := []recorded{}
+	r := NewDedupRecorder(capturingRecorder{events: &got}, time.Minute)
+	r.now = func() time.Time { return start }
+	r.Event(a, boom)
+	r.now = func() time.Time { return start.Add(10 * time.Second) }
+	r.Event(a, boom)
+	r.Flush()
+	r.Event(a, boom)
+
+	want := []recorded{
+		{Object: "a", Event: boom},
+		{Object: "a", Event: Event{Type: TypeWarning, Reason: "CannotObserve", Message: "boom (repeated 1 times in the last 10s)", Annotations: map[string]string{}}},
+		{Object: "a", Event: boom},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nFlush should record suppressed events and reset their windows.\nEvent(...): -want, +got:\n%s", diff)
+	}
+}
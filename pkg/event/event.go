@@ -2,7 +2,12 @@
 package event
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/tools/record"
 )
 
@@ -19,12 +24,29 @@ var (
 // Reason an event occurred.
 type Reason string
 
+// Annotations that correlate events with the logs and traces of the
+// reconcile that recorded them.
+const (
+	// AnnotationKeyReconcileID identifies the reconcile that recorded an
+	// event. Reconcilers log it as reconcile-id.
+	AnnotationKeyReconcileID = "reconcile-id"
+
+	// AnnotationKeyExternalName is the external name of the managed resource
+	// an event relates to.
+	AnnotationKeyExternalName = "external-name"
+
+	// AnnotationKeyProviderVersion is the version of the provider that
+	// recorded an event.
+	AnnotationKeyProviderVersion = "provider-version"
+)
+
 // An Event relating to a custom resource.
 type Event struct {
 	Type        Type
 	Reason      Reason
 	Message     string
 	Annotations map[string]string
+	Labels      map[string]string
 }
 
 // Normal returns a normal, informational event.
@@ -51,38 +73,152 @@ func Warning(r Reason, err error, keysAndValues ...string) Event {
 	return e
 }
 
-// A Recorder records Kubernetes events.
+// WithAnnotations returns a copy of the event that includes the supplied
+// annotations.
+func (e Event) WithAnnotations(keysAndValues ...string) Event {
+	e.Annotations = withPairs(e.Annotations, keysAndValues)
+	return e
+}
+
+// WithLabels returns a copy of the event that includes the supplied labels.
+func (e Event) WithLabels(keysAndValues ...string) Event {
+	e.Labels = withPairs(e.Labels, keysAndValues)
+	return e
+}
+
+// eventsAPIAction is the action recorded with events.k8s.io/v1 events. Every
+// event is recorded while reconciling the object it relates to.
+const eventsAPIAction = "Reconcile"
+
+// eventsAPINoteMaxLength is the maximum length of the note of an
+// events.k8s.io/v1 event.
+const eventsAPINoteMaxLength = 1024
+
+// A Recorder records Kubernetes events. The annotations and labels supplied to
+// WithAnnotations and WithLabels are merged with those the recorder already
+// includes, so that nested components can each add context to the events it
+// records. Annotations and labels of an event take precedence over those of
+// the recorder.
 type Recorder interface {
 	Event(obj runtime.Object, e Event)
 	WithAnnotations(keysAndValues ...string) Recorder
+	WithLabels(keysAndValues ...string) Recorder
 }
 
-// An APIRecorder records Kubernetes events to an API server.
+// An APIRecorder records Kubernetes events to an API server. It counts the
+// events it records by kind, type, and reason in the krateo_provider_events_total
+// metric. Kubernetes events can't be labelled, so labels are recorded as
+// annotations.
 type APIRecorder struct {
 	kube        record.EventRecorder
+	events      events.EventRecorder
+	scheme      *runtime.Scheme
 	annotations map[string]string
+	labels      map[string]string
 }
 
+// An APIRecorderOption configures an APIRecorder.
+type APIRecorderOption func(*APIRecorder)
+
 // NewAPIRecorder returns an APIRecorder that records Kubernetes events to an
 // APIServer using the supplied EventRecorder.
-func NewAPIRecorder(r record.EventRecorder) *APIRecorder {
-	return &APIRecorder{kube: r, annotations: map[string]string{}}
+func NewAPIRecorder(r record.EventRecorder, o ...APIRecorderOption) *APIRecorder {
+	ar := &APIRecorder{kube: r, annotations: map[string]string{}}
+	for _, ao := range o {
+		ao(ar)
+	}
+	return ar
+}
+
+// NewEventsAPIRecorder returns an APIRecorder that records Kubernetes events
+// to an API server using the supplied events.k8s.io/v1 EventRecorder. The API
+// server manages the series of similar events, deduplicating them. The
+// events.k8s.io/v1 API doesn't support annotating events, so annotations and
+// labels are appended to the note of each event, for example "boom
+// (reconcile-id=abc)", unless the note would then be too long.
+func NewEventsAPIRecorder(r events.EventRecorder, o ...APIRecorderOption) *APIRecorder {
+	ar := &APIRecorder{events: r, annotations: map[string]string{}}
+	for _, ao := range o {
+		ao(ar)
+	}
+	return ar
 }
 
 // Event records the supplied event.
 func (r *APIRecorder) Event(obj runtime.Object, e Event) {
-	r.kube.AnnotatedEventf(obj, r.annotations, string(e.Type), string(e.Reason), e.Message)
+	a := merge(r.labels, e.Labels, r.annotations, e.Annotations)
+	if r.events != nil {
+		r.events.Eventf(obj, nil, string(e.Type), string(e.Reason), eventsAPIAction, "%s", eventsAPINote(e.Message, a))
+	} else {
+		r.kube.AnnotatedEventf(obj, a, string(e.Type), string(e.Reason), e.Message)
+	}
+	r.countEvent(obj, e)
 }
 
 // WithAnnotations returns a new *APIRecorder that includes the supplied
 // annotations with all recorded events.
 func (r *APIRecorder) WithAnnotations(keysAndValues ...string) Recorder {
-	ar := NewAPIRecorder(r.kube)
-	for k, v := range r.annotations {
-		ar.annotations[k] = v
+	ar := *r
+	ar.annotations = withPairs(r.annotations, keysAndValues)
+	return &ar
+}
+
+// WithLabels returns a new *APIRecorder that includes the supplied labels with
+// all recorded events.
+func (r *APIRecorder) WithLabels(keysAndValues ...string) Recorder {
+	ar := *r
+	ar.labels = withPairs(r.labels, keysAndValues)
+	return &ar
+}
+
+// eventsAPINote returns the note of an events.k8s.io/v1 event with the
+// supplied message and annotations. The annotations are appended to the
+// message, ordered by key, unless the note would then be too long.
+func eventsAPINote(message string, annotations map[string]string) string {
+	if len(annotations) == 0 {
+		return message
 	}
-	sliceMap(keysAndValues, ar.annotations)
-	return ar
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + annotations[k]
+	}
+	note := fmt.Sprintf("%s (%s)", message, strings.Join(pairs, ", "))
+	if len(note) > eventsAPINoteMaxLength {
+		return message
+	}
+	return note
+}
+
+// withPairs returns a copy of the supplied map that includes the supplied key
+// value pairs.
+func withPairs(m map[string]string, keysAndValues []string) map[string]string {
+	out := make(map[string]string, len(m)+len(keysAndValues)/2)
+	for k, v := range m {
+		out[k] = v
+	}
+	sliceMap(keysAndValues, out)
+	return out
+}
+
+// merge returns a new map that includes the entries of all of the supplied
+// maps. Entries of later maps take precedence.
+func merge(maps ...map[string]string) map[string]string {
+	n := 0
+	for _, m := range maps {
+		n += len(m)
+	}
+	out := make(map[string]string, n)
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
 }
 
 func sliceMap(from []string, to map[string]string) {
@@ -105,3 +241,6 @@ func (r *NopRecorder) Event(_ runtime.Object, _ Event) {}
 
 // WithAnnotations does nothing.
 func (r *NopRecorder) WithAnnotations(_ ...string) Recorder { return r }
+
+// WithLabels does nothing.
+func (r *NopRecorder) WithLabels(_ ...string) Recorder { return r }
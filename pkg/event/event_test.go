@@ -2,9 +2,15 @@
 package event
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/tools/record"
 )
 
 func TestSliceMap(t *testing.T) {
@@ -72,3 +78,72 @@ func TestSliceMap(t *testing.T) {
 	}
 
 }
+
+func TestEventWithAnnotations(t *testing.T) {
+	e := Normal("Created", "created", "key", "value")
+	got := e.WithAnnotations(AnnotationKeyReconcileID, "cool-id")
+
+	want := map[string]string{"key": "value", AnnotationKeyReconcileID: "cool-id"}
+	if diff := cmp.Diff(want, got.Annotations); diff != "" {
+		t.Errorf("WithAnnotations(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"key": "value"}, e.Annotations); diff != "" {
+		t.Errorf("WithAnnotations(...): the original event should not be modified: -want, +got:\n%s", diff)
+	}
+}
+
+func TestEventsAPIRecorder(t *testing.T) {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+	fr := events.NewFakeRecorder(3)
+	r := NewEventsAPIRecorder(fr).WithAnnotations(AnnotationKeyReconcileID, "cool-id")
+
+	r.Event(obj, Normal("Created", "created 100%"))
+	r.WithLabels("provider", "cool-provider").Event(obj, Warning("CannotCreate", errors.New("boom")).WithAnnotations(AnnotationKeyExternalName, "cool-external"))
+	r.Event(obj, Normal("Created", strings.Repeat("x", eventsAPINoteMaxLength)))
+	close(fr.Events)
+
+	got := []string{}
+	for e := range fr.Events {
+		got = append(got, e)
+	}
+	want := []string{
+		"Normal Created created 100% (reconcile-id=cool-id)",
+		"Warning CannotCreate boom (external-name=cool-external, provider=cool-provider, reconcile-id=cool-id)",
+		"Normal Created " + strings.Repeat("x", eventsAPINoteMaxLength),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Event(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRecorderEnrichment(t *testing.T) {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+	e := Normal("Created", "created", "phase", "create")
+
+	fr := record.NewFakeRecorder(1)
+	NewAPIRecorder(fr).
+		WithAnnotations("controller", "cool", "phase", "observe").
+		WithLabels("provider", "cool-provider").
+		Event(obj, e)
+	want := "Normal Created created map[controller:cool phase:create provider:cool-provider]"
+	if diff := cmp.Diff(want, <-fr.Events); diff != "" {
+		t.Errorf("APIRecorder.Event(...): -want, +got:\n%s", diff)
+	}
+
+	m := NewMemoryRecorder(1)
+	m.WithAnnotations("controller", "cool").
+		WithLabels("provider", "cool-provider").
+		WithAnnotations("phase", "observe").
+		Event(obj, e.WithLabels("stage", "beta"))
+	got := m.Events()[0].Event
+	wantEvent := Event{
+		Type:        TypeNormal,
+		Reason:      "Created",
+		Message:     "created",
+		Annotations: map[string]string{"controller": "cool", "phase": "create"},
+		Labels:      map[string]string{"provider": "cool-provider", "stage": "beta"},
+	}
+	if diff := cmp.Diff(wantEvent, got); diff != "" {
+		t.Errorf("MemoryRecorder.Event(...): -want, +got:\n%s", diff)
+	}
+}
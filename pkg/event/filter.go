@@ -0,0 +1,66 @@
+package event
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// A Filter determines which events are recorded. The zero value allows every
+// event.
+type Filter struct {
+	// Types of event to record, for example only warnings. Events of every
+	// type are recorded if none are specified.
+	Types []Type
+
+	// AllowReasons are the only reasons for which events are recorded, if
+	// any are specified.
+	AllowReasons []Reason
+
+	// DenyReasons are reasons for which events are never recorded.
+	DenyReasons []Reason
+}
+
+// Allows returns true if the supplied event should be recorded.
+func (f Filter) Allows(e Event) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	if len(f.AllowReasons) > 0 && !slices.Contains(f.AllowReasons, e.Reason) {
+		return false
+	}
+	return !slices.Contains(f.DenyReasons, e.Reason)
+}
+
+// A FilteringRecorder decorates a Recorder, dropping events its Filter
+// doesn't allow.
+type FilteringRecorder struct {
+	wrapped Recorder
+	filter  Filter
+}
+
+// NewFilteringRecorder returns a FilteringRecorder that records the events
+// allowed by the supplied Filter using the supplied Recorder.
+func NewFilteringRecorder(r Recorder, f Filter) *FilteringRecorder {
+	return &FilteringRecorder{wrapped: r, filter: f}
+}
+
+// Event records the supplied event if it is allowed by the filter.
+func (r *FilteringRecorder) Event(obj runtime.Object, e Event) {
+	if !r.filter.Allows(e) {
+		return
+	}
+	r.wrapped.Event(obj, e)
+}
+
+// WithAnnotations returns a new *FilteringRecorder that includes the supplied
+// annotations with all recorded events.
+func (r *FilteringRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	return NewFilteringRecorder(r.wrapped.WithAnnotations(keysAndValues...), r.filter)
+}
+
+// WithLabels returns a new *FilteringRecorder that includes the supplied
+// labels with all recorded events.
+func (r *FilteringRecorder) WithLabels(keysAndValues ...string) Recorder {
+	return NewFilteringRecorder(r.wrapped.WithLabels(keysAndValues...), r.filter)
+}
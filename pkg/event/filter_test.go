@@ -0,0 +1,59 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilterAllows(t *testing.T) {
+	created := Normal("CreatedExternalResource", "created")
+	cannotObserve := Warning("CannotObserveExternalResource", errors.New("boom"))
+
+	cases := map[string]struct {
+		reason string
+		f      Filter
+		e      Event
+		want   bool
+	}{
+		"ZeroValue": {
+			reason: "The zero value should allow every event.",
+			e:      created,
+			want:   true,
+		},
+		"TypeAllowed": {
+			reason: "Events of the specified types should be allowed.",
+			f:      Filter{Types: []Type{TypeWarning}},
+			e:      cannotObserve,
+			want:   true,
+		},
+		"TypeDenied": {
+			reason: "Events of other types should not be allowed.",
+			f:      Filter{Types: []Type{TypeWarning}},
+			e:      created,
+			want:   false,
+		},
+		"ReasonNotAllowed": {
+			reason: "Events with reasons that are not in the allowlist should not be allowed.",
+			f:      Filter{AllowReasons: []Reason{"CannotObserveExternalResource"}},
+			e:      created,
+			want:   false,
+		},
+		"ReasonDenied": {
+			reason: "Events with reasons that are in the denylist should not be allowed.",
+			f:      Filter{DenyReasons: []Reason{"CreatedExternalResource"}},
+			e:      created,
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.f.Allows(tc.e)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nAllows(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
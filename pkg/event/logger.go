@@ -0,0 +1,101 @@
+package event
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+)
+
+// ReasonLoggedWarning is the reason of the events recorded by an
+// EventingLogger.
+var ReasonLoggedWarning = RegisterReason("LoggedWarning", "The provider logged a warning about the object.")
+
+// An EventingLogger decorates a Logger, mirroring the warnings it logs as
+// Warning events on the object they relate to. It makes critical failures
+// visible to users who can describe an object but can't read the provider's
+// logs. Errors logged by a logr.Logger returned by logging.ToLogr are logged as
+// warnings, and thus are mirrored too.
+type EventingLogger struct {
+	log    logging.Logger
+	rec    Recorder
+	values []any
+}
+
+// NewEventingLogger returns an EventingLogger that logs using the supplied
+// Logger, and records events using the supplied Recorder. A warning relates
+// to the first runtime.Object, for example a managed resource or an
+// *corev1.ObjectReference, in its structured data or in the structured data
+// supplied to WithValues. Warnings that don't relate to an object are only
+// logged.
+func NewEventingLogger(l logging.Logger, r Recorder) *EventingLogger {
+	return &EventingLogger{log: l, rec: r}
+}
+
+// Info logs a message with optional structured data.
+func (l *EventingLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...)
+}
+
+// Debug logs a message with optional structured data.
+func (l *EventingLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.Debug(msg, keysAndValues...)
+}
+
+// Warn logs a message with optional structured data, and records it as a
+// Warning event on the object it relates to, if any. If the structured data
+// includes an error the event's message includes it.
+func (l *EventingLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warn(msg, keysAndValues...)
+
+	obj := findObject(keysAndValues)
+	if obj == nil {
+		obj = findObject(l.values)
+	}
+	if obj == nil {
+		return
+	}
+	if err := findError(keysAndValues); err != nil {
+		msg += ": " + err.Error()
+	}
+	l.rec.Event(obj, Event{Type: TypeWarning, Reason: ReasonLoggedWarning, Message: msg})
+}
+
+// WithValues returns a Logger that will include the supplied structured data
+// with any subsequent messages it logs.
+func (l *EventingLogger) WithValues(keysAndValues ...any) logging.Logger {
+	return &EventingLogger{
+		log:    l.log.WithValues(keysAndValues...),
+		rec:    l.rec,
+		values: append(l.values[:len(l.values):len(l.values)], keysAndValues...),
+	}
+}
+
+// WithGroup returns a Logger that will nest any structured data that is
+// subsequently supplied under the supplied group name.
+func (l *EventingLogger) WithGroup(name string) logging.Logger {
+	return &EventingLogger{log: l.log.WithGroup(name), rec: l.rec, values: l.values}
+}
+
+// Enabled returns true if messages logged at the supplied level would be
+// logged.
+func (l *EventingLogger) Enabled(level logging.Level) bool {
+	return l.log.Enabled(level)
+}
+
+func findObject(keysAndValues []any) runtime.Object {
+	for i := 1; i < len(keysAndValues); i += 2 {
+		if o, ok := keysAndValues[i].(runtime.Object); ok && o != nil {
+			return o
+		}
+	}
+	return nil
+}
+
+func findError(keysAndValues []any) error {
+	for i := 1; i < len(keysAndValues); i += 2 {
+		if err, ok := keysAndValues[i].(error); ok && err != nil {
+			return err
+		}
+	}
+	return nil
+}
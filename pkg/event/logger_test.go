@@ -0,0 +1,92 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+)
+
+func TestEventingLogger(t *testing.T) {
+	a := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	b := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+
+	cases := map[string]struct {
+		reason string
+		log    func(l logging.Logger)
+		want   []recorded
+	}{
+		"WarningWithObject": {
+			reason: "A warning that includes an object should be recorded as an event on the object.",
+			log: func(l logging.Logger) {
+				l.Warn("Cannot observe external resource", "resource", a, "error", errors.New("boom"))
+			},
+			want: []recorded{{Object: "a", Event: Event{Type: TypeWarning, Reason: ReasonLoggedWarning, Message: "Cannot observe external resource: boom"}}},
+		},
+		"WarningWithValuesObject": {
+			reason: "A warning logged by a Logger whose values include an object should be recorded as an event on the object.",
+			log: func(l logging.Logger) {
+				l.WithValues("resource", a).WithGroup("client").Warn("Cannot connect")
+			},
+			want: []recorded{{Object: "a", Event: Event{Type: TypeWarning, Reason: ReasonLoggedWarning, Message: "Cannot connect"}}},
+		},
+		"WarningObjectTakesPrecedence": {
+			reason: "An object included with a warning should take precedence over one included with WithValues.",
+			log: func(l logging.Logger) {
+				l.WithValues("resource", a).Warn("Cannot connect", "resource", b)
+			},
+			want: []recorded{{Object: "b", Event: Event{Type: TypeWarning, Reason: ReasonLoggedWarning, Message: "Cannot connect"}}},
+		},
+		"WarningWithoutObject": {
+			reason: "A warning that doesn't relate to an object should not be recorded as an event.",
+			log: func(l logging.Logger) {
+				l.Warn("Cannot connect", "error", errors.New("boom"))
+			},
+		},
+		"WarningThroughDecorators": {
+			reason: "A warning logged through decorating Loggers should be recorded as an event.",
+			log: func(l logging.Logger) {
+				l = logging.NewRedactingLogger(l.WithValues("resource", a))
+				l = logging.NewSamplingLogger(l, 0, 1, 0)
+				l = logging.WithLevel(l, 1)
+				l = logging.WithAtomicLevel(l, logging.NewAtomicLevel(0))
+				l.Warn("Cannot connect")
+			},
+			want: []recorded{{Object: "a", Event: Event{Type: TypeWarning, Reason: ReasonLoggedWarning, Message: "Cannot connect"}}},
+		},
+		"LogrError": {
+			reason: "An error logged by a logr.Logger should be recorded as an event.",
+			log: func(l logging.Logger) {
+				logging.ToLogr(l).Error(errors.New("boom"), "Cannot observe external resource", "resource", a)
+			},
+			want: []recorded{{Object: "a", Event: Event{Type: TypeWarning, Reason: ReasonLoggedWarning, Message: "Cannot observe external resource: boom"}}},
+		},
+		"Info": {
+			reason: "Info and debug messages should not be recorded as events.",
+			log: func(l logging.Logger) {
+				l.Info("Reconciling", "resource", a)
+				l.Debug("Reconciling", "resource", a)
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []recorded
+			tc.log(NewEventingLogger(logging.NewNopLogger(), capturingRecorder{events: &got}))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEventingLogger: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReasonLoggedWarningRegistered(t *testing.T) {
+	if _, ok := DefaultReasons.Description(ReasonLoggedWarning); !ok {
+		t.Errorf("DefaultReasons.Description(%q): reason is not registered", ReasonLoggedWarning)
+	}
+}
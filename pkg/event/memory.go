@@ -0,0 +1,117 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// A RecordedEvent is an event recorded by a MemoryRecorder.
+type RecordedEvent struct {
+	Event
+
+	// Time at which the event was recorded.
+	Time time.Time
+
+	// Object the event relates to.
+	Object SinkObject
+}
+
+// A MemoryRecorder records events in memory. It keeps the most recent events
+// in a fixed size ring buffer, discarding the oldest event when it is full.
+// It may be used as a test double, or to keep recent events at hand.
+type MemoryRecorder struct {
+	// buf is shared with the recorders returned by WithAnnotations.
+	buf         *ringBuffer
+	annotations map[string]string
+	labels      map[string]string
+}
+
+type ringBuffer struct {
+	mu     sync.RWMutex
+	events []RecordedEvent
+	next   int
+	full   bool
+}
+
+// NewMemoryRecorder returns a MemoryRecorder that keeps the supplied number
+// of most recent events. It keeps at least one event.
+func NewMemoryRecorder(size int) *MemoryRecorder {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryRecorder{
+		buf:         &ringBuffer{events: make([]RecordedEvent, size)},
+		annotations: map[string]string{},
+	}
+}
+
+// Event records the supplied event, discarding the oldest recorded event if
+// the buffer is full.
+func (r *MemoryRecorder) Event(obj runtime.Object, e Event) {
+	e.Annotations = merge(r.annotations, e.Annotations)
+	if l := merge(r.labels, e.Labels); len(l) > 0 {
+		e.Labels = l
+	}
+
+	r.buf.mu.Lock()
+	defer r.buf.mu.Unlock()
+	r.buf.events[r.buf.next] = RecordedEvent{Event: e, Time: time.Now(), Object: sinkObject(obj)}
+	r.buf.next = (r.buf.next + 1) % len(r.buf.events)
+	if r.buf.next == 0 {
+		r.buf.full = true
+	}
+}
+
+// WithAnnotations returns a new *MemoryRecorder that includes the supplied
+// annotations with all recorded events. It shares its buffer with the
+// original recorder.
+func (r *MemoryRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	mr := *r
+	mr.annotations = withPairs(r.annotations, keysAndValues)
+	return &mr
+}
+
+// WithLabels returns a new *MemoryRecorder that includes the supplied labels
+// with all recorded events. It shares its buffer with the original recorder.
+func (r *MemoryRecorder) WithLabels(keysAndValues ...string) Recorder {
+	mr := *r
+	mr.labels = withPairs(r.labels, keysAndValues)
+	return &mr
+}
+
+// Events returns the recorded events, oldest first.
+func (r *MemoryRecorder) Events() []RecordedEvent {
+	r.buf.mu.RLock()
+	defer r.buf.mu.RUnlock()
+	if !r.buf.full {
+		return append([]RecordedEvent{}, r.buf.events[:r.buf.next]...)
+	}
+	return append(append([]RecordedEvent{}, r.buf.events[r.buf.next:]...), r.buf.events[:r.buf.next]...)
+}
+
+// Last returns the most recent n recorded events, oldest first. It returns
+// all recorded events if fewer than n were recorded.
+func (r *MemoryRecorder) Last(n int) []RecordedEvent {
+	all := r.Events()
+	if n < 0 {
+		n = 0
+	}
+	if n > len(all) {
+		n = len(all)
+	}
+	return all[len(all)-n:]
+}
+
+// ByReason returns the recorded events with the supplied reason, oldest
+// first.
+func (r *MemoryRecorder) ByReason(reason Reason) []RecordedEvent {
+	out := []RecordedEvent{}
+	for _, e := range r.Events() {
+		if e.Reason == reason {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMemoryRecorder(t *testing.T) {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+	r := NewMemoryRecorder(3)
+	ar := r.WithAnnotations("external-name", "cool")
+	for _, reason := range []Reason{"A", "B", "A", "C"} {
+		ar.Event(obj, Normal(reason, string(reason)))
+	}
+
+	recorded := func(reason Reason) RecordedEvent {
+		return RecordedEvent{
+			Event: Event{
+				Type:        TypeNormal,
+				Reason:      reason,
+				Message:     string(reason),
+				Annotations: map[string]string{"external-name": "cool"},
+			},
+			Object: SinkObject{Namespace: "coolns", Name: "cool"},
+		}
+	}
+	ignoreTime := cmpopts.IgnoreFields(RecordedEvent{}, "Time")
+
+	cases := map[string]struct {
+		reason string
+		got    []RecordedEvent
+		want   []RecordedEvent
+	}{
+		"Events": {
+			reason: "The oldest event should be discarded when the buffer is full.",
+			got:    r.Events(),
+			want:   []RecordedEvent{recorded("B"), recorded("A"), recorded("C")},
+		},
+		"Last": {
+			reason: "The most recent events should be returned, oldest first.",
+			got:    r.Last(2),
+			want:   []RecordedEvent{recorded("A"), recorded("C")},
+		},
+		"LastMoreThanRecorded": {
+			reason: "All events should be returned if fewer than requested were recorded.",
+			got:    r.Last(10),
+			want:   []RecordedEvent{recorded("B"), recorded("A"), recorded("C")},
+		},
+		"ByReason": {
+			reason: "Only events with the requested reason should be returned.",
+			got:    r.ByReason("A"),
+			want:   []RecordedEvent{recorded("A")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.got, ignoreTime); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// eventsTotal counts the events recorded by APIRecorders. It is registered
+// with the controller-runtime metrics registry, which is served by the
+// controller manager.
+var eventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "krateo",
+	Subsystem: "provider",
+	Name:      "events_total",
+	Help:      "Number of Kubernetes events recorded, by the group and kind of the object they relate to, and by event type and reason.",
+}, []string{"group", "kind", "type", "reason"})
+
+func init() {
+	metrics.Registry.MustRegister(eventsTotal)
+}
+
+// WithScheme configures an APIRecorder to use the supplied scheme to
+// determine the kind of objects when counting their events. Otherwise their
+// kind is read from the object, or derived from its Go type.
+func WithScheme(s *runtime.Scheme) APIRecorderOption {
+	return func(r *APIRecorder) {
+		r.scheme = s
+	}
+}
+
+func (r *APIRecorder) countEvent(obj runtime.Object, e Event) {
+	gvk := r.groupVersionKind(obj)
+	eventsTotal.WithLabelValues(gvk.Group, gvk.Kind, string(e.Type), string(e.Reason)).Inc()
+}
+
+func (r *APIRecorder) groupVersionKind(obj runtime.Object) schema.GroupVersionKind {
+	if r.scheme != nil {
+		if gvk, err := apiutil.GVKForObject(obj, r.scheme); err == nil {
+			return gvk
+		}
+	}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Kind != "" {
+		return gvk
+	}
+	// Typed objects usually have the same name as their kind.
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return schema.GroupVersionKind{Kind: t.Name()}
+}
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestAPIRecorderMetrics(t *testing.T) {
+	type want struct {
+		group string
+		kind  string
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      []APIRecorderOption
+		obj    runtime.Object
+		want   want
+	}{
+		"Scheme": {
+			reason: "The kind of the object should be determined using the scheme.",
+			o:      []APIRecorderOption{WithScheme(scheme.Scheme)},
+			obj:    &corev1.Secret{},
+			want:   want{group: "", kind: "Secret"},
+		},
+		"TypeMeta": {
+			reason: "The kind of the object should be read from the object if there is no scheme.",
+			obj:    &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: "example.org/v1", Kind: "Cool"}},
+			want:   want{group: "example.org", kind: "Cool"},
+		},
+		"GoType": {
+			reason: "The kind of the object should be derived from its Go type if it's not otherwise known.",
+			obj:    &corev1.ConfigMap{},
+			want:   want{group: "", kind: "ConfigMap"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reason := Reason("Test" + name)
+			c := eventsTotal.WithLabelValues(tc.want.group, tc.want.kind, string(TypeWarning), string(reason))
+			before := testutil.ToFloat64(c)
+
+			r := NewAPIRecorder(record.NewFakeRecorder(10), tc.o...)
+			r.WithAnnotations("external-name", "cool").Event(tc.obj, Warning(reason, errors.New("boom")))
+
+			got := testutil.ToFloat64(c) - before
+			if diff := cmp.Diff(1.0, got); diff != "" {
+				t.Errorf("\n%s\nEvent(...): -want count, +got count:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package event
+
+import (
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+// A MutingRecorder decorates a Recorder, dropping events for objects whose
+// events annotation disables them. It allows events to be silenced for
+// specific high churn resources without silencing a whole controller.
+type MutingRecorder struct {
+	wrapped Recorder
+}
+
+// NewMutingRecorder returns a MutingRecorder that records events for objects
+// that don't disable them using the supplied Recorder.
+func NewMutingRecorder(r Recorder) *MutingRecorder {
+	return &MutingRecorder{wrapped: r}
+}
+
+// Event records the supplied event, unless the object disables events.
+func (r *MutingRecorder) Event(obj runtime.Object, e Event) {
+	if m, err := kmeta.Accessor(obj); err == nil && meta.AreEventsDisabled(m) {
+		return
+	}
+	r.wrapped.Event(obj, e)
+}
+
+// WithAnnotations returns a new *MutingRecorder that includes the supplied
+// annotations with all recorded events.
+func (r *MutingRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	return NewMutingRecorder(r.wrapped.WithAnnotations(keysAndValues...))
+}
+
+// WithLabels returns a new *MutingRecorder that includes the supplied
+// labels with all recorded events.
+func (r *MutingRecorder) WithLabels(keysAndValues ...string) Recorder {
+	return NewMutingRecorder(r.wrapped.WithLabels(keysAndValues...))
+}
@@ -0,0 +1,30 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+func TestMutingRecorder(t *testing.T) {
+	got := []recorded{}
+	r := NewMutingRecorder(capturingRecorder{events: &got}).WithAnnotations("external-name", "cool")
+
+	muted := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "muted", Annotations: map[string]string{meta.AnnotationKeyEvents: meta.EventsDisabled}}}
+	enabled := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "enabled", Annotations: map[string]string{meta.AnnotationKeyEvents: meta.EventsEnabled}}}
+	unset := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unset"}}
+
+	e := Normal("Created", "created")
+	for _, obj := range []*corev1.Secret{muted, enabled, unset} {
+		r.Event(obj, e)
+	}
+
+	want := []recorded{{Object: "enabled", Event: e}, {Object: "unset", Event: e}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Event(...): -want, +got:\n%s", diff)
+	}
+}
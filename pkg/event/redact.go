@@ -0,0 +1,39 @@
+package event
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/redact"
+)
+
+// A RedactingRecorder decorates a Recorder, scrubbing secrets from the
+// messages of the events it records. Warning events are usually created
+// from errors returned by external APIs, which may echo credentials.
+type RedactingRecorder struct {
+	wrapped  Recorder
+	redactor *redact.Redactor
+}
+
+// NewRedactingRecorder returns a RedactingRecorder that scrubs event messages
+// using the supplied Redactor.
+func NewRedactingRecorder(r Recorder, rd *redact.Redactor) *RedactingRecorder {
+	return &RedactingRecorder{wrapped: r, redactor: rd}
+}
+
+// Event records the supplied event with secrets scrubbed from its message.
+func (r *RedactingRecorder) Event(obj runtime.Object, e Event) {
+	e.Message = r.redactor.Redact(e.Message)
+	r.wrapped.Event(obj, e)
+}
+
+// WithAnnotations returns a new *RedactingRecorder that includes the supplied
+// annotations with all recorded events.
+func (r *RedactingRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	return NewRedactingRecorder(r.wrapped.WithAnnotations(keysAndValues...), r.redactor)
+}
+
+// WithLabels returns a new *RedactingRecorder that includes the supplied
+// labels with all recorded events.
+func (r *RedactingRecorder) WithLabels(keysAndValues ...string) Recorder {
+	return NewRedactingRecorder(r.wrapped.WithLabels(keysAndValues...), r.redactor)
+}
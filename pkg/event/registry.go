@@ -0,0 +1,114 @@
+package event
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errFmtReasonRedefined    = "event reason %q is already registered with description %q"
+	errFmtReasonEmpty        = "event reason %q must have a description"
+	errFmtReasonUnregistered = "event reason %q is not registered"
+)
+
+// DefaultReasons is the registry in which packages declare the reasons for
+// the events they record.
+var DefaultReasons = NewReasonRegistry()
+
+// A ReasonRegistry records the event reasons a provider may use, along with a
+// description of each. It keeps the reason vocabulary consistent across
+// providers.
+type ReasonRegistry struct {
+	mu      sync.RWMutex
+	reasons map[Reason]string
+}
+
+// NewReasonRegistry returns an empty ReasonRegistry.
+func NewReasonRegistry() *ReasonRegistry {
+	return &ReasonRegistry{reasons: map[Reason]string{}}
+}
+
+// Register the supplied reason with the supplied description. Registering a
+// reason again with the same description is a no-op. It returns an error if
+// the description is empty, or if the reason is already registered with a
+// different description.
+func (rr *ReasonRegistry) Register(r Reason, description string) error {
+	if description == "" {
+		return errors.Errorf(errFmtReasonEmpty, r)
+	}
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if d, ok := rr.reasons[r]; ok && d != description {
+		return errors.Errorf(errFmtReasonRedefined, r, d)
+	}
+	rr.reasons[r] = description
+	return nil
+}
+
+// MustRegister registers the supplied reason and description, and returns the
+// reason. It panics if the reason cannot be registered.
+func (rr *ReasonRegistry) MustRegister(r Reason, description string) Reason {
+	if err := rr.Register(r, description); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Description returns the description of the supplied reason, and whether it
+// is registered.
+func (rr *ReasonRegistry) Description(r Reason) (string, bool) {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	d, ok := rr.reasons[r]
+	return d, ok
+}
+
+// Reasons returns all registered reasons, sorted alphabetically.
+func (rr *ReasonRegistry) Reasons() []Reason {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	out := make([]Reason, 0, len(rr.reasons))
+	for r := range rr.reasons {
+		out = append(out, r)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Validate returns an error for each of the supplied events whose reason is
+// not registered. It's intended to be used in tests to check the reasons a
+// provider emits.
+func (rr *ReasonRegistry) Validate(events ...Event) error {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	var errs []error
+	for _, e := range events {
+		if _, ok := rr.reasons[e.Reason]; !ok {
+			errs = append(errs, errors.Errorf(errFmtReasonUnregistered, e.Reason))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateRecorded returns an error for each of the supplied recorded events
+// whose reason is not registered, for example the events captured by a
+// MemoryRecorder.
+func (rr *ReasonRegistry) ValidateRecorded(events ...RecordedEvent) error {
+	e := make([]Event, len(events))
+	for i := range events {
+		e[i] = events[i].Event
+	}
+	return rr.Validate(e...)
+}
+
+// RegisterReason registers the supplied reason and description with the
+// DefaultReasons registry, and returns the reason. It panics if the reason
+// cannot be registered.
+func RegisterReason(r Reason, description string) Reason {
+	return DefaultReasons.MustRegister(r, description)
+}
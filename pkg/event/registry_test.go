@@ -0,0 +1,93 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestReasonRegistryRegister(t *testing.T) {
+	type args struct {
+		r           Reason
+		description string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"New": {
+			reason: "A new reason with a description should be registered.",
+			args:   args{r: "UpdatedExternalResource", description: "The external resource was updated."},
+		},
+		"SameDescription": {
+			reason: "Registering a reason again with the same description should be a no-op.",
+			args:   args{r: "CreatedExternalResource", description: "The external resource was created."},
+		},
+		"DifferentDescription": {
+			reason: "Registering a reason again with a different description should return an error.",
+			args:   args{r: "CreatedExternalResource", description: "Created."},
+			want:   fmt.Errorf(errFmtReasonRedefined, "CreatedExternalResource", "The external resource was created."),
+		},
+		"EmptyDescription": {
+			reason: "Registering a reason without a description should return an error.",
+			args:   args{r: "DeletedExternalResource"},
+			want:   fmt.Errorf(errFmtReasonEmpty, "DeletedExternalResource"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rr := NewReasonRegistry()
+			rr.MustRegister("CreatedExternalResource", "The external resource was created.")
+
+			err := rr.Register(tc.args.r, tc.args.description)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRegister(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReasonRegistryReasons(t *testing.T) {
+	rr := NewReasonRegistry()
+	rr.MustRegister("UpdatedExternalResource", "The external resource was updated.")
+	rr.MustRegister("CreatedExternalResource", "The external resource was created.")
+
+	want := []Reason{"CreatedExternalResource", "UpdatedExternalResource"}
+	if diff := cmp.Diff(want, rr.Reasons()); diff != "" {
+		t.Errorf("Reasons(): -want, +got:\n%s", diff)
+	}
+
+	d, ok := rr.Description("CreatedExternalResource")
+	if diff := cmp.Diff("The external resource was created.", d); diff != "" || !ok {
+		t.Errorf("Description(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestReasonRegistryValidate(t *testing.T) {
+	rr := NewReasonRegistry()
+	rr.MustRegister("CannotObserveExternalResource", "The external resource could not be observed.")
+
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}
+	m := NewMemoryRecorder(5)
+	m.Event(obj, Warning("CannotObserveExternalResource", errors.New("boom")))
+	m.Event(obj, Normal("Observed", "observed"))
+
+	want := errors.Join(fmt.Errorf(errFmtReasonUnregistered, "Observed"))
+	err := rr.ValidateRecorded(m.Events()...)
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("ValidateRecorded(...): -want error, +got error:\n%s", diff)
+	}
+
+	if err := rr.Validate(Warning("CannotObserveExternalResource", errors.New("boom"))); err != nil {
+		t.Errorf("Validate(...): unexpected error: %v", err)
+	}
+}
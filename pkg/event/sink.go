@@ -0,0 +1,231 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+)
+
+const defaultSinkTimeout = 5 * time.Second
+
+// Error strings.
+const (
+	errMarshalSinkEvent   = "cannot marshal event"
+	errNewWebhookRequest  = "cannot create webhook request"
+	errSendWebhookRequest = "cannot send webhook request"
+	errFmtWebhookStatus   = "webhook returned status %d"
+)
+
+// A SinkEvent is an event about an object, as sent to a Sink.
+type SinkEvent struct {
+	Time        time.Time         `json:"time"`
+	Object      SinkObject        `json:"object"`
+	Type        Type              `json:"type"`
+	Reason      Reason            `json:"reason"`
+	Message     string            `json:"message"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// A SinkObject identifies the object an event is about.
+type SinkObject struct {
+	APIVersion string    `json:"apiVersion,omitempty"`
+	Kind       string    `json:"kind,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	UID        types.UID `json:"uid,omitempty"`
+}
+
+// sinkObject returns a SinkObject identifying the supplied object.
+func sinkObject(obj runtime.Object) SinkObject {
+	o := SinkObject{}
+	o.APIVersion, o.Kind = obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	if m, err := kmeta.Accessor(obj); err == nil {
+		o.Namespace = m.GetNamespace()
+		o.Name = m.GetName()
+		o.UID = m.GetUID()
+	}
+	return o
+}
+
+// A Sink receives events, typically to forward them to a system outside
+// Kubernetes such as a webhook or a message bus.
+type Sink interface {
+	Send(ctx context.Context, e SinkEvent) error
+}
+
+// A SinkFn is a function that satisfies the Sink interface.
+type SinkFn func(ctx context.Context, e SinkEvent) error
+
+// Send the supplied event.
+func (fn SinkFn) Send(ctx context.Context, e SinkEvent) error {
+	return fn(ctx, e)
+}
+
+// A SinkRecorder records events by sending them to a Sink. Events are sent
+// synchronously, subject to a timeout. Events that can't be sent are logged
+// and dropped.
+type SinkRecorder struct {
+	sink        Sink
+	log         logging.Logger
+	timeout     time.Duration
+	annotations map[string]string
+	labels      map[string]string
+}
+
+// A SinkRecorderOption configures a SinkRecorder.
+type SinkRecorderOption func(*SinkRecorder)
+
+// WithSinkLogger configures the logger used to report events that can't be
+// sent. Such events are not logged by default.
+func WithSinkLogger(l logging.Logger) SinkRecorderOption {
+	return func(r *SinkRecorder) {
+		r.log = l
+	}
+}
+
+// WithSinkTimeout configures how long to wait for an event to be sent. The
+// default is five seconds.
+func WithSinkTimeout(d time.Duration) SinkRecorderOption {
+	return func(r *SinkRecorder) {
+		r.timeout = d
+	}
+}
+
+// NewSinkRecorder returns a SinkRecorder that sends events to the supplied
+// Sink.
+func NewSinkRecorder(s Sink, o ...SinkRecorderOption) *SinkRecorder {
+	r := &SinkRecorder{
+		sink:        s,
+		log:         logging.NewNopLogger(),
+		timeout:     defaultSinkTimeout,
+		annotations: map[string]string{},
+	}
+	for _, ro := range o {
+		ro(r)
+	}
+	return r
+}
+
+// Event sends the supplied event to the sink.
+func (r *SinkRecorder) Event(obj runtime.Object, e Event) {
+	se := SinkEvent{
+		Time:        time.Now(),
+		Object:      sinkObject(obj),
+		Type:        e.Type,
+		Reason:      e.Reason,
+		Message:     e.Message,
+		Annotations: merge(r.annotations, e.Annotations),
+	}
+	if l := merge(r.labels, e.Labels); len(l) > 0 {
+		se.Labels = l
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	if err := r.sink.Send(ctx, se); err != nil {
+		r.log.Debug("Cannot send event to sink", "error", err, "reason", e.Reason)
+	}
+}
+
+// WithAnnotations returns a new *SinkRecorder that includes the supplied
+// annotations with all recorded events.
+func (r *SinkRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	sr := *r
+	sr.annotations = withPairs(r.annotations, keysAndValues)
+	return &sr
+}
+
+// WithLabels returns a new *SinkRecorder that includes the supplied labels
+// with all recorded events.
+func (r *SinkRecorder) WithLabels(keysAndValues ...string) Recorder {
+	sr := *r
+	sr.labels = withPairs(r.labels, keysAndValues)
+	return &sr
+}
+
+// A MultiRecorder records events using several recorders, for example the
+// Kubernetes API recorder and a SinkRecorder.
+type MultiRecorder []Recorder
+
+// NewMultiRecorder returns a MultiRecorder that records events using all of
+// the supplied recorders, in order.
+func NewMultiRecorder(rs ...Recorder) MultiRecorder {
+	return MultiRecorder(rs)
+}
+
+// Event records the supplied event using all recorders.
+func (m MultiRecorder) Event(obj runtime.Object, e Event) {
+	for _, r := range m {
+		r.Event(obj, e)
+	}
+}
+
+// WithAnnotations returns a new MultiRecorder whose recorders include the
+// supplied annotations with all recorded events.
+func (m MultiRecorder) WithAnnotations(keysAndValues ...string) Recorder {
+	out := make(MultiRecorder, len(m))
+	for i, r := range m {
+		out[i] = r.WithAnnotations(keysAndValues...)
+	}
+	return out
+}
+
+// WithLabels returns a new MultiRecorder whose recorders include the supplied
+// labels with all recorded events.
+func (m MultiRecorder) WithLabels(keysAndValues ...string) Recorder {
+	out := make(MultiRecorder, len(m))
+	for i, r := range m {
+		out[i] = r.WithLabels(keysAndValues...)
+	}
+	return out
+}
+
+// A WebhookSink sends events to an HTTP webhook. Each event is sent as the
+// JSON encoded body of a POST request.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink that sends events to the supplied URL
+// using the supplied HTTP client, or http.DefaultClient if it is nil.
+func NewWebhookSink(url string, c *http.Client) *WebhookSink {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &WebhookSink{url: url, client: c}
+}
+
+// Send the supplied event to the webhook. Any response status other than 2xx
+// is considered an error.
+func (s *WebhookSink) Send(ctx context.Context, e SinkEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, errMarshalSinkEvent)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, errNewWebhookRequest)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, errSendWebhookRequest)
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Nothing useful to do with the error.
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return errors.Errorf(errFmtWebhookStatus, rsp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSinkRecorder(t *testing.T) {
+	got := []SinkEvent{}
+	s := SinkFn(func(_ context.Context, e SinkEvent) error {
+		got = append(got, e)
+		return errors.New("boom")
+	})
+
+	obj := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool", UID: "cool-uid"},
+	}
+	r := NewMultiRecorder(NewNopRecorder(), NewSinkRecorder(s))
+	r.WithAnnotations("external-name", "cool").Event(obj, Normal("Created", "created", "key", "value"))
+
+	want := []SinkEvent{{
+		Object:      SinkObject{APIVersion: "v1", Kind: "Secret", Namespace: "coolns", Name: "cool", UID: "cool-uid"},
+		Type:        TypeNormal,
+		Reason:      "Created",
+		Message:     "created",
+		Annotations: map[string]string{"external-name": "cool", "key": "value"},
+	}}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(SinkEvent{}, "Time")); diff != "" {
+		t.Errorf("Event(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	e := SinkEvent{Type: TypeWarning, Reason: "CannotObserve", Message: "boom"}
+
+	cases := map[string]struct {
+		reason  string
+		status  int
+		wantErr bool
+	}{
+		"Success": {
+			reason: "Events should be posted to the webhook as JSON.",
+			status: http.StatusAccepted,
+		},
+		"ErrorStatus": {
+			reason:  "An error should be returned if the webhook returns a non-2xx status.",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got SinkEvent
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("\n%s\nunexpected %s request with content type %q", tc.reason, r.Method, r.Header.Get("Content-Type"))
+				}
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			err := NewWebhookSink(srv.URL, nil).Send(context.Background(), e)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("\n%s\nSend(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(e, got, cmpopts.EquateApproxTime(0)); diff != "" {
+				t.Errorf("\n%s\nSend(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// SetGlobalLogger installs the supplied Logger as the controller-runtime
+// logger and the klog backend, so that messages logged by controller-runtime
+// and client-go, for example by caches, clients, and leader election, share
+// the format and destinations of the provider's own messages. It should be
+// called once, early in the provider's main function.
+func SetGlobalLogger(l Logger) {
+	lr := ToLogr(l)
+	ctrllog.SetLogger(lr)
+	klog.SetLogger(lr)
+}
+
+// ToLogr returns a logr.Logger that logs using the supplied Logger. Messages
+// logged at V(0) are logged as info messages, and messages logged at V(1)
+// and above as debug messages. Errors are logged as warnings. A V-level is
+// enabled if the supplied Logger is enabled at the corresponding level. Names
+// are logged as the value of the "logger" key. A Logger returned by
+// NewLogrLogger is unwrapped.
+func ToLogr(l Logger) logr.Logger {
+	if ll, ok := l.(logrLogger); ok {
+		return ll.log
+	}
+	return logr.New(logSink{log: l})
+}
+
+type logSink struct {
+	log  Logger
+	name string
+}
+
+func (s logSink) Init(_ logr.RuntimeInfo) {}
+
+func (s logSink) Enabled(level int) bool {
+	if level > 0 {
+		return s.log.Enabled(LevelDebug)
+	}
+	return s.log.Enabled(LevelInfo)
+}
+
+func (s logSink) Info(level int, msg string, keysAndValues ...any) {
+	keysAndValues = s.withName(keysAndValues)
+	if level > 0 {
+		s.log.Debug(msg, keysAndValues...)
+		return
+	}
+	s.log.Info(msg, keysAndValues...)
+}
+
+func (s logSink) Error(err error, msg string, keysAndValues ...any) {
+	// Appending to the caller's structured data could write to its backing
+	// array, so we append to a copy.
+	s.log.Warn(msg, s.withName(append(keysAndValues[:len(keysAndValues):len(keysAndValues)], "error", err))...)
+}
+
+func (s logSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return logSink{log: s.log.WithValues(keysAndValues...), name: s.name}
+}
+
+func (s logSink) WithName(name string) logr.LogSink {
+	if s.name != "" {
+		name = s.name + "." + name
+	}
+	return logSink{log: s.log, name: name}
+}
+
+func (s logSink) withName(keysAndValues []any) []any {
+	if s.name == "" {
+		return keysAndValues
+	}
+	return append(keysAndValues[:len(keysAndValues):len(keysAndValues)], "logger", s.name)
+}
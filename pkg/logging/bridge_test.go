@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestToLogr(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		debug  bool
+		log    func(l logr.Logger)
+		want   []line
+	}{
+		"Info": {
+			reason: "Messages logged at V(0) should be logged as info messages.",
+			log: func(l logr.Logger) {
+				l.Info("Starting", "controller", "c")
+			},
+			want: []line{{Level: "info", Msg: "Starting", KeysAndValues: []any{"controller", "c"}}},
+		},
+		"Debug": {
+			reason: "Messages logged at V(1) and above should be logged as debug messages.",
+			debug:  true,
+			log: func(l logr.Logger) {
+				l.V(1).Info("Observing")
+				l.V(4).Info("Sending request")
+			},
+			want: []line{
+				{Level: "debug", Msg: "Observing"},
+				{Level: "debug", Msg: "Sending request"},
+			},
+		},
+		"DebugDisabled": {
+			reason: "Messages logged at V(1) and above should not be logged if debug messages are disabled.",
+			log: func(l logr.Logger) {
+				l.V(1).Info("Observing")
+			},
+		},
+		"Error": {
+			reason: "Errors should be logged as warnings.",
+			log: func(l logr.Logger) {
+				l.Error(errBoom, "Cannot observe", "resource", "a")
+			},
+			want: []line{{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "a", "error", errBoom}}},
+		},
+		"WithName": {
+			reason: "Names should be joined and logged as the value of the logger key.",
+			log: func(l logr.Logger) {
+				l = l.WithName("controller").WithName("cache")
+				l.Info("Starting")
+				l.Error(errBoom, "Cannot list")
+			},
+			want: []line{
+				{Level: "info", Msg: "Starting", KeysAndValues: []any{"logger", "controller.cache"}},
+				{Level: "warn", Msg: "Cannot list", KeysAndValues: []any{"error", errBoom, "logger", "controller.cache"}},
+			},
+		},
+		"WithValues": {
+			reason: "Values should be logged with subsequent messages.",
+			log: func(l logr.Logger) {
+				l.WithValues("controller", "c").Info("Starting")
+			},
+			want: []line{{Level: "info", Msg: "Starting", KeysAndValues: []any{"controller", "c"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []line
+			tc.log(ToLogr(recordingLogger{lines: &got, debug: tc.debug}))
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty(), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nToLogr(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestToLogrDoesNotModifyCallerValues(t *testing.T) {
+	kv := make([]any, 2, 6)
+	kv[0], kv[1] = "resource", "a"
+
+	var got []line
+	l := ToLogr(recordingLogger{lines: &got}).WithName("controller")
+	l.Error(errors.New("boom"), "Cannot observe", kv...)
+	l.Info("Starting", kv...)
+
+	if diff := cmp.Diff(make([]any, 4), kv[2:6]); diff != "" {
+		t.Errorf("ToLogr(...): want the backing array of the caller's structured data unchanged: -want, +got:\n%s", diff)
+	}
+}
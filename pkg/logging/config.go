@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"flag"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Environment variables that set the defaults of the log flags bound by
+// Config.BindFlags.
+const (
+	EnvLogFormat = "LOG_FORMAT"
+	EnvLogLevel  = "LOG_LEVEL"
+)
+
+// Error strings.
+const (
+	errFmtUnknownFormat = "unknown log format %q: must be one of text, json, or pretty"
+	errFmtUnknownLevel  = "unknown log level %q: must be one of debug, info, warn, or error"
+)
+
+// A Format in which messages are logged.
+type Format string
+
+// Formats.
+const (
+	// FormatText logs messages as plain text, one message per line.
+	FormatText Format = "text"
+
+	// FormatJSON logs messages as JSON objects, one message per line.
+	FormatJSON Format = "json"
+
+	// FormatPretty logs messages as colored, human readable text, suitable
+	// for development.
+	FormatPretty Format = "pretty"
+)
+
+// A Config configures the output of a Logger returned by NewFromConfig.
+type Config struct {
+	// Format in which messages are logged. The default is FormatJSON.
+	Format Format
+
+	// Level is the minimum level of messages that are logged; one of
+	// debug, info, warn, or error. The default is info.
+	Level string
+}
+
+// ConfigFromEnv returns a Config read from the LOG_FORMAT and LOG_LEVEL
+// environment variables.
+func ConfigFromEnv() Config {
+	return Config{Format: Format(os.Getenv(EnvLogFormat)), Level: os.Getenv(EnvLogLevel)}
+}
+
+// BindFlags binds the log-format and log-level flags to the Config, so that
+// all providers expose the same logging configuration. The flags default to
+// the LOG_FORMAT and LOG_LEVEL environment variables, if set.
+func (c *Config) BindFlags(fs *flag.FlagSet) {
+	env := ConfigFromEnv()
+	format, level := string(FormatJSON), "info"
+	if env.Format != "" {
+		format = string(env.Format)
+	}
+	if env.Level != "" {
+		level = env.Level
+	}
+	fs.StringVar((*string)(&c.Format), "log-format", format, "Format of log messages; one of text, json, or pretty. Defaults to $"+EnvLogFormat+", or json.")
+	fs.StringVar(&c.Level, "log-level", level, "Minimum level of log messages; one of debug, info, warn, or error. Defaults to $"+EnvLogLevel+", or info.")
+}
+
+// NewFromConfig returns a ZapLogger that logs messages in the configured
+// format, at or above the configured level.
+func NewFromConfig(c Config) (*ZapLogger, error) {
+	level := zapcore.InfoLevel
+	if c.Level != "" {
+		l, err := zapcore.ParseLevel(c.Level)
+		if err != nil {
+			return nil, errors.Errorf(errFmtUnknownLevel, c.Level)
+		}
+		level = l
+	}
+
+	var zc zap.Config
+	switch c.Format {
+	case FormatJSON, "":
+		zc = ProductionZapConfig()
+	case FormatText:
+		zc = ProductionZapConfig()
+		zc.Encoding = "console"
+		zc.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	case FormatPretty:
+		zc = DevelopmentZapConfig()
+	default:
+		return nil, errors.Errorf(errFmtUnknownFormat, c.Format)
+	}
+
+	return NewZapLogger(WithZapConfig(zc), WithZapLevel(level))
+}
@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+const errFmtInvalidLevel = "invalid log level %q: must be a non-negative integer"
+
+// An AtomicLevel is a log level that may be changed at runtime, for example
+// when the provider receives a SIGHUP or when a ConfigMap changes. Debug
+// messages of verbosity up to the level are logged as info messages, as by
+// WithLevel. It satisfies flag.Value, so it may also be set by a command line
+// flag.
+type AtomicLevel struct {
+	level atomic.Int32
+}
+
+// NewAtomicLevel returns an AtomicLevel set to the supplied level.
+func NewAtomicLevel(level int) *AtomicLevel {
+	l := &AtomicLevel{}
+	l.SetLevel(level)
+	return l
+}
+
+// Level returns the current level.
+func (l *AtomicLevel) Level() int {
+	return int(l.level.Load())
+}
+
+// SetLevel sets the current level. Negative levels are treated as zero.
+func (l *AtomicLevel) SetLevel(level int) {
+	l.level.Store(int32(max(level, 0))) //nolint:gosec // Log levels are small.
+}
+
+// String returns the current level as a string.
+func (l *AtomicLevel) String() string {
+	return strconv.Itoa(l.Level())
+}
+
+// Set the current level from the supplied string.
+func (l *AtomicLevel) Set(s string) error {
+	level, err := strconv.Atoi(s)
+	if err != nil || level < 0 {
+		return errors.Errorf(errFmtInvalidLevel, s)
+	}
+	l.SetLevel(level)
+	return nil
+}
+
+// ToggleOnSignal toggles the supplied level between zero and debug, level 1,
+// each time the process receives one of the supplied signals, or SIGHUP if
+// none are supplied. Any level above zero is toggled to zero. It returns when
+// the supplied context is done.
+func ToggleOnSignal(ctx context.Context, l *AtomicLevel, sig ...os.Signal) {
+	if len(sig) == 0 {
+		sig = []os.Signal{syscall.SIGHUP}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+	defer signal.Stop(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			if l.Level() > 0 {
+				l.SetLevel(0)
+				continue
+			}
+			l.SetLevel(1)
+		}
+	}
+}
+
+// WithAtomicLevel returns a Logger that logs the messages of the supplied
+// Logger at the supplied level, which may change while the Logger is in use.
+func WithAtomicLevel(l Logger, level *AtomicLevel) Logger {
+	return atomicLeveledLogger{log: l, level: level, verbosity: 1}
+}
+
+type atomicLeveledLogger struct {
+	log       Logger
+	level     *AtomicLevel
+	verbosity int
+}
+
+func (l atomicLeveledLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...)
+}
+
+func (l atomicLeveledLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warn(msg, keysAndValues...)
+}
+
+func (l atomicLeveledLogger) Debug(msg string, keysAndValues ...any) {
+	if l.level.Level() >= l.verbosity {
+		l.log.Info(msg, keysAndValues...)
+		return
+	}
+	l.log.Debug(msg, keysAndValues...)
+}
+
+func (l atomicLeveledLogger) WithValues(keysAndValues ...any) Logger {
+	return atomicLeveledLogger{log: l.log.WithValues(keysAndValues...), level: l.level, verbosity: l.verbosity}
+}
+
+func (l atomicLeveledLogger) WithGroup(name string) Logger {
+	return atomicLeveledLogger{log: l.log.WithGroup(name), level: l.level, verbosity: l.verbosity}
+}
+
+func (l atomicLeveledLogger) Enabled(level Level) bool {
+	if l.level.Level() >= l.verbosity {
+		return l.log.Enabled(LevelInfo)
+	}
+	return l.log.Enabled(level)
+}
+
+func (l atomicLeveledLogger) v(verbosity int) Logger {
+	return atomicLeveledLogger{log: V(l.log, verbosity), level: l.level, verbosity: max(verbosity, 1)}
+}
@@ -0,0 +1,234 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestAtomicLevelSet(t *testing.T) {
+	type want struct {
+		level  int
+		string string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		s      string
+		want   want
+	}{
+		"Valid": {
+			reason: "A non-negative integer should set the level.",
+			s:      "3",
+			want:   want{level: 3, string: "3"},
+		},
+		"Negative": {
+			reason: "A negative integer should be rejected, leaving the level unchanged.",
+			s:      "-1",
+			want:   want{level: 1, string: "1", err: errors.Errorf(errFmtInvalidLevel, "-1")},
+		},
+		"NotAnInteger": {
+			reason: "A value that isn't an integer should be rejected, leaving the level unchanged.",
+			s:      "debug",
+			want:   want{level: 1, string: "1", err: errors.Errorf(errFmtInvalidLevel, "debug")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewAtomicLevel(1)
+			err := l.Set(tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nl.Set(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.level, l.Level()); diff != "" {
+				t.Errorf("\n%s\nl.Level(): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.string, l.String()); diff != "" {
+				t.Errorf("\n%s\nl.String(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAtomicLevelSetLevel(t *testing.T) {
+	l := NewAtomicLevel(-2)
+	if diff := cmp.Diff(0, l.Level()); diff != "" {
+		t.Errorf("NewAtomicLevel(-2).Level(): want negative levels treated as zero: -want, +got:\n%s", diff)
+	}
+}
+
+func TestVerbosity(t *testing.T) {
+	type want struct {
+		lines   []line
+		enabled bool
+	}
+
+	cases := map[string]struct {
+		reason    string
+		debug     bool
+		log       func(l Logger) Logger
+		verbosity int
+		want      want
+	}{
+		"LevelZero": {
+			reason:    "Debug messages should not be promoted at level 0.",
+			log:       func(l Logger) Logger { return WithLevel(l, 0) },
+			verbosity: 1,
+			want:      want{enabled: false},
+		},
+		"LevelOne": {
+			reason:    "Debug messages of verbosity 1 should be promoted to info at level 1.",
+			log:       func(l Logger) Logger { return WithLevel(l, 1) },
+			verbosity: 1,
+			want: want{
+				lines:   []line{{Level: "info", Msg: "Observing", KeysAndValues: []any{"log-level", 1}}},
+				enabled: true,
+			},
+		},
+		"VerbosityAboveLevel": {
+			reason:    "Debug messages of a verbosity above the level should not be promoted.",
+			log:       func(l Logger) Logger { return WithLevel(l, 2) },
+			verbosity: 3,
+			want:      want{enabled: false},
+		},
+		"VerbosityAboveLevelDebugEnabled": {
+			reason:    "Debug messages of a verbosity above the level should be logged as debug messages.",
+			debug:     true,
+			log:       func(l Logger) Logger { return WithLevel(l, 2) },
+			verbosity: 3,
+			want: want{
+				lines:   []line{{Level: "debug", Msg: "Observing", KeysAndValues: []any{"log-level", 2}}},
+				enabled: true,
+			},
+		},
+		"VerbosityAtLevel": {
+			reason:    "Debug messages of a verbosity up to the level should be promoted to info.",
+			log:       func(l Logger) Logger { return WithLevel(l, 4) },
+			verbosity: 3,
+			want: want{
+				lines:   []line{{Level: "info", Msg: "Observing", KeysAndValues: []any{"log-level", 4}}},
+				enabled: true,
+			},
+		},
+		"AtomicVerbosityAtLevel": {
+			reason:    "Debug messages of a verbosity up to the atomic level should be promoted to info.",
+			log:       func(l Logger) Logger { return WithAtomicLevel(l, NewAtomicLevel(2)) },
+			verbosity: 2,
+			want: want{
+				lines:   []line{{Level: "info", Msg: "Observing"}},
+				enabled: true,
+			},
+		},
+		"AtomicVerbosityAboveLevel": {
+			reason:    "Debug messages of a verbosity above the atomic level should not be promoted.",
+			log:       func(l Logger) Logger { return WithAtomicLevel(l, NewAtomicLevel(2)) },
+			verbosity: 3,
+			want:      want{enabled: false},
+		},
+		"Nested": {
+			reason:    "The verbosity should be forwarded to a wrapped leveled Logger.",
+			log:       func(l Logger) Logger { return WithAtomicLevel(WithLevel(l, 1), NewAtomicLevel(0)) },
+			verbosity: 2,
+			want:      want{enabled: false},
+		},
+		"ThroughSampling": {
+			reason:    "The verbosity should be forwarded through a sampling Logger.",
+			log:       func(l Logger) Logger { return NewSamplingLogger(WithLevel(l, 1), 0, 10, 0) },
+			verbosity: 2,
+			want:      want{enabled: false},
+		},
+		"ThroughRedacting": {
+			reason:    "The verbosity should be forwarded through a redacting Logger.",
+			log:       func(l Logger) Logger { return NewRedactingLogger(WithLevel(l, 1)) },
+			verbosity: 2,
+			want:      want{enabled: false},
+		},
+		"PromotedThroughDecorators": {
+			reason:    "Debug messages of a verbosity up to the level should be promoted through decorating Loggers.",
+			log:       func(l Logger) Logger { return NewRedactingLogger(NewSamplingLogger(WithLevel(l, 2), 0, 10, 0)) },
+			verbosity: 2,
+			want: want{
+				lines:   []line{{Level: "info", Msg: "Observing", KeysAndValues: []any{"log-level", 2}}},
+				enabled: true,
+			},
+		},
+		"NotLeveled": {
+			reason:    "Loggers that aren't leveled should log debug messages of any verbosity as debug messages.",
+			debug:     true,
+			log:       func(l Logger) Logger { return l },
+			verbosity: 4,
+			want: want{
+				lines:   []line{{Level: "debug", Msg: "Observing"}},
+				enabled: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []line
+			l := V(tc.log(recordingLogger{lines: &got, debug: tc.debug}), tc.verbosity)
+			l.Debug("Observing")
+
+			if diff := cmp.Diff(tc.want.lines, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nV(...).Debug(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.enabled, l.Enabled(LevelDebug)); diff != "" {
+				t.Errorf("\n%s\nV(...).Enabled(LevelDebug): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestToggleOnSignal(t *testing.T) {
+	// Notify our own channel too, so that a signal sent before ToggleOnSignal
+	// starts listening doesn't terminate the test.
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	defer signal.Stop(ch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l := NewAtomicLevel(0)
+	go ToggleOnSignal(ctx, l)
+
+	// Signals sent before ToggleOnSignal starts listening are lost, so we
+	// resend until the level toggles.
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("os.FindProcess(...): %v", err)
+	}
+	toggle := func(want int) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for time.Now().Before(deadline) {
+			if err := p.Signal(syscall.SIGHUP); err != nil {
+				t.Fatalf("p.Signal(...): %v", err)
+			}
+			for wait := time.Now().Add(200 * time.Millisecond); time.Now().Before(wait); time.Sleep(5 * time.Millisecond) {
+				if l.Level() == want {
+					return
+				}
+			}
+		}
+		t.Fatalf("ToggleOnSignal(...): want level %d, got %d", want, l.Level())
+	}
+
+	toggle(1)
+	toggle(0)
+
+	l.SetLevel(3)
+	toggle(0)
+}
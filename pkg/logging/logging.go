@@ -40,6 +40,18 @@ import (
 	"github.com/go-logr/logr"
 )
 
+// A Level at which messages are logged.
+type Level int
+
+// Levels.
+const (
+	// LevelInfo is the level of messages logged by Info.
+	LevelInfo Level = iota
+
+	// LevelDebug is the level of messages logged by Debug.
+	LevelDebug
+)
+
 // A Logger logs messages. Messages may be supplemented by structured data.
 type Logger interface {
 	// Info logs a message with optional structured data. Structured data must
@@ -48,6 +60,12 @@ type Logger interface {
 	// very likely to be concerned with when running.
 	Info(msg string, keysAndValues ...any)
 
+	// Warn logs a message with optional structured data. Structured data must
+	// be supplied as an array that alternates between string keys and values of
+	// an arbitrary type. Use Warn for messages about unexpected but
+	// recoverable situations.
+	Warn(msg string, keysAndValues ...any)
+
 	// Debug logs a message with optional structured data. Structured data must
 	// be supplied as an array that alternates between string keys and values of
 	// an arbitrary type. Use Debug for messages that operators or
@@ -59,6 +77,16 @@ type Logger interface {
 	// be supplied as an array that alternates between string keys and values of
 	// an arbitrary type.
 	WithValues(keysAndValues ...any) Logger
+
+	// WithGroup returns a Logger that will nest any structured data that is
+	// subsequently supplied under the supplied group name, so that keys
+	// supplied by different components don't collide.
+	WithGroup(name string) Logger
+
+	// Enabled returns true if messages logged at the supplied level would be
+	// logged. Use Enabled to avoid building expensive structured data, such
+	// as deep diffs or marshaled objects, that would not be logged.
+	Enabled(level Level) bool
 }
 
 // NewNopLogger returns a Logger that does nothing.
@@ -67,12 +95,16 @@ func NewNopLogger() Logger { return nopLogger{} }
 type nopLogger struct{}
 
 func (l nopLogger) Info(msg string, keysAndValues ...any)  {}
+func (l nopLogger) Warn(msg string, keysAndValues ...any)  {}
 func (l nopLogger) Debug(msg string, keysAndValues ...any) {}
 func (l nopLogger) WithValues(keysAndValues ...any) Logger { return nopLogger{} }
+func (l nopLogger) WithGroup(name string) Logger           { return nopLogger{} }
+func (l nopLogger) Enabled(level Level) bool               { return false }
 
 // NewLogrLogger returns a Logger that is satisfied by the supplied logr.Logger,
 // which may be satisfied in turn by various logging implementations (Zap, klog,
-// etc). Debug messages are logged at V(1).
+// etc). Warnings are logged as info messages, since logr has no warning level.
+// Debug messages are logged at V(1). Groups are logged as logr name segments.
 func NewLogrLogger(l logr.Logger) Logger {
 	return logrLogger{log: l}
 }
@@ -85,6 +117,10 @@ func (l logrLogger) Info(msg string, keysAndValues ...any) {
 	l.log.Info(msg, keysAndValues...) //nolint:logrlint // False positive - logrlint thinks there's an odd number of args.
 }
 
+func (l logrLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...) //nolint:logrlint // False positive - logrlint thinks there's an odd number of args.
+}
+
 func (l logrLogger) Debug(msg string, keysAndValues ...any) {
 	l.log.V(1).Info(msg, keysAndValues...) //nolint:logrlint // False positive - logrlint thinks there's an odd number of args.
 }
@@ -92,3 +128,80 @@ func (l logrLogger) Debug(msg string, keysAndValues ...any) {
 func (l logrLogger) WithValues(keysAndValues ...any) Logger {
 	return logrLogger{log: l.log.WithValues(keysAndValues...)} //nolint:logrlint // False positive - logrlint thinks there's an odd number of args.
 }
+
+func (l logrLogger) WithGroup(name string) Logger {
+	return logrLogger{log: l.log.WithName(name)}
+}
+
+func (l logrLogger) Enabled(level Level) bool {
+	if level >= LevelDebug {
+		return l.log.V(1).Enabled()
+	}
+	return l.log.Enabled()
+}
+
+// WithLevel returns a Logger that logs the messages of the supplied Logger at
+// the supplied level, typically read from a resource's log-level annotation.
+// Debug messages of verbosity up to the level are logged as info messages, so
+// that they are logged even when debug logging is disabled. Debug messages
+// have verbosity 1 unless they are logged by a Logger returned by V. At level
+// 0 the supplied Logger is returned unchanged.
+func WithLevel(l Logger, level int) Logger {
+	if level <= 0 {
+		return l
+	}
+	return leveledLogger{log: l.WithValues("log-level", level), level: level, verbosity: 1}
+}
+
+// V returns a Logger whose debug messages have the supplied verbosity, from 1,
+// the verbosity of all other debug messages, to 4 for the most detailed
+// messages. Loggers returned by WithLevel and WithAtomicLevel log them as info
+// messages only if their level is at least the verbosity. Otherwise, and by
+// any other Logger, they are logged as debug messages.
+func V(l Logger, verbosity int) Logger {
+	if vl, ok := l.(interface{ v(verbosity int) Logger }); ok {
+		return vl.v(verbosity)
+	}
+	return l
+}
+
+type leveledLogger struct {
+	log       Logger
+	level     int
+	verbosity int
+}
+
+func (l leveledLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...)
+}
+
+func (l leveledLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warn(msg, keysAndValues...)
+}
+
+func (l leveledLogger) Debug(msg string, keysAndValues ...any) {
+	if l.verbosity > l.level {
+		l.log.Debug(msg, keysAndValues...)
+		return
+	}
+	l.log.Info(msg, keysAndValues...)
+}
+
+func (l leveledLogger) WithValues(keysAndValues ...any) Logger {
+	return leveledLogger{log: l.log.WithValues(keysAndValues...), level: l.level, verbosity: l.verbosity}
+}
+
+func (l leveledLogger) WithGroup(name string) Logger {
+	return leveledLogger{log: l.log.WithGroup(name), level: l.level, verbosity: l.verbosity}
+}
+
+func (l leveledLogger) Enabled(level Level) bool {
+	if level >= LevelDebug && l.verbosity > l.level {
+		return l.log.Enabled(level)
+	}
+	return l.log.Enabled(LevelInfo)
+}
+
+func (l leveledLogger) v(verbosity int) Logger {
+	return leveledLogger{log: V(l.log, verbosity), level: l.level, verbosity: max(verbosity, 1)}
+}
@@ -0,0 +1,41 @@
+package logging
+
+// A line logged by a recordingLogger.
+type line struct {
+	Level         string
+	Msg           string
+	KeysAndValues []any
+}
+
+// A recordingLogger records the lines it logs. Structured data supplied to
+// WithValues precedes the structured data supplied with each message. It logs
+// debug messages only if debug is true.
+type recordingLogger struct {
+	lines  *[]line
+	values []any
+	debug  bool
+}
+
+func (l recordingLogger) log(level, msg string, keysAndValues []any) {
+	kv := append(l.values[:len(l.values):len(l.values)], keysAndValues...)
+	*l.lines = append(*l.lines, line{Level: level, Msg: msg, KeysAndValues: kv})
+}
+
+func (l recordingLogger) Info(msg string, keysAndValues ...any) { l.log("info", msg, keysAndValues) }
+func (l recordingLogger) Warn(msg string, keysAndValues ...any) { l.log("warn", msg, keysAndValues) }
+
+func (l recordingLogger) Debug(msg string, keysAndValues ...any) {
+	if l.debug {
+		l.log("debug", msg, keysAndValues)
+	}
+}
+
+func (l recordingLogger) WithValues(keysAndValues ...any) Logger {
+	return recordingLogger{lines: l.lines, values: append(l.values[:len(l.values):len(l.values)], keysAndValues...), debug: l.debug}
+}
+
+func (l recordingLogger) WithGroup(_ string) Logger { return l }
+
+func (l recordingLogger) Enabled(level Level) bool {
+	return level < LevelDebug || l.debug
+}
@@ -0,0 +1,210 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewRateLimitedLogger returns a RateLimitedLogger that suppresses repeated
+// warnings logged by the supplied Logger, so that a single broken resource
+// can't dominate log volume. Warnings are identical if they have the same
+// message and the same values for the supplied keys, whether the values were
+// supplied to WithValues or with the warning. If no keys are supplied
+// warnings are identical if they have the same message and were logged with
+// the same structured data, ignoring data supplied to WithValues.
+//
+// The first of a set of identical warnings is logged, and the rest are
+// suppressed until the window ends. A summary of how many times the warning
+// was repeated is logged once the window ends, the next time a warning is
+// logged or when Flush is called. Info and debug messages are never
+// suppressed. Errors logged by a logr.Logger returned by ToLogr are logged as
+// warnings, and thus are suppressed too.
+//
+// Loggers returned by WithValues and WithGroup share their state with the
+// original Logger, so warnings logged by different reconciles are suppressed
+// together.
+func NewRateLimitedLogger(l Logger, window time.Duration, keys ...string) *RateLimitedLogger {
+	return &RateLimitedLogger{log: l, limiter: &warnLimiter{
+		window:  window,
+		keys:    keys,
+		now:     time.Now,
+		entries: map[string]*warnEntry{},
+	}}
+}
+
+// A RateLimitedLogger is a Logger that suppresses repeated warnings.
+type RateLimitedLogger struct {
+	log     Logger
+	values  []any
+	limiter *warnLimiter
+}
+
+// Info logs a message with optional structured data.
+func (l *RateLimitedLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...)
+}
+
+// Debug logs a message with optional structured data.
+func (l *RateLimitedLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.Debug(msg, keysAndValues...)
+}
+
+// Warn logs a message with optional structured data, unless an identical
+// warning was already logged within the window.
+func (l *RateLimitedLogger) Warn(msg string, keysAndValues ...any) {
+	l.limiter.warn(l.log, l.key(msg, keysAndValues), msg, keysAndValues)
+}
+
+// WithValues returns a Logger that will include the supplied structured data
+// with any subsequent messages it logs.
+func (l *RateLimitedLogger) WithValues(keysAndValues ...any) Logger {
+	return &RateLimitedLogger{
+		log:     l.log.WithValues(keysAndValues...),
+		values:  append(l.values[:len(l.values):len(l.values)], keysAndValues...),
+		limiter: l.limiter,
+	}
+}
+
+// WithGroup returns a Logger that will nest any structured data that is
+// subsequently supplied under the supplied group name.
+func (l *RateLimitedLogger) WithGroup(name string) Logger {
+	return &RateLimitedLogger{log: l.log.WithGroup(name), values: l.values, limiter: l.limiter}
+}
+
+// Enabled returns true if messages logged at the supplied level would be
+// logged.
+func (l *RateLimitedLogger) Enabled(level Level) bool {
+	return l.log.Enabled(level)
+}
+
+// Flush logs a summary of any warnings that were suppressed, and resets
+// their windows.
+func (l *RateLimitedLogger) Flush() {
+	l.limiter.flush()
+}
+
+func (l *RateLimitedLogger) key(msg string, keysAndValues []any) string {
+	b := &strings.Builder{}
+	b.WriteString(msg)
+	if len(l.limiter.keys) == 0 {
+		for _, v := range keysAndValues {
+			fmt.Fprintf(b, "\x00%v", v)
+		}
+		return b.String()
+	}
+	for _, k := range l.limiter.keys {
+		// Structured data supplied with the warning takes precedence over
+		// structured data supplied to WithValues.
+		v, ok := lookup(keysAndValues, k)
+		if !ok {
+			v, _ = lookup(l.values, k)
+		}
+		fmt.Fprintf(b, "\x00%v", v)
+	}
+	return b.String()
+}
+
+func lookup(keysAndValues []any, key string) (any, bool) {
+	for i := len(keysAndValues) - 2; i >= 0; i -= 2 {
+		if k, ok := keysAndValues[i].(string); ok && k == key {
+			return keysAndValues[i+1], true
+		}
+	}
+	return nil, false
+}
+
+type warnEntry struct {
+	end        time.Time
+	suppressed int
+
+	// The Logger, message, and structured data of the most recently
+	// suppressed warning, used to log the summary.
+	log           Logger
+	msg           string
+	keysAndValues []any
+}
+
+type warnLimiter struct {
+	window time.Duration
+	keys   []string
+	now    func() time.Time
+
+	mu      sync.Mutex
+	sweep   time.Time
+	entries map[string]*warnEntry
+}
+
+func (wl *warnLimiter) warn(l Logger, key, msg string, keysAndValues []any) {
+	wl.mu.Lock()
+	now := wl.now()
+
+	// Expired entries are swept at most once per window, so that logging a
+	// warning doesn't usually require walking every entry.
+	var summaries []warnEntry
+	if !now.Before(wl.sweep) {
+		summaries = wl.expire(now)
+		wl.sweep = now.Add(wl.window)
+	}
+
+	e, ok := wl.entries[key]
+	if ok && !now.Before(e.end) {
+		if e.suppressed > 0 {
+			summaries = append(summaries, *e)
+		}
+		ok = false
+	}
+	if ok {
+		e.suppressed++
+		e.log, e.msg, e.keysAndValues = l, msg, keysAndValues
+	} else {
+		wl.entries[key] = &warnEntry{end: now.Add(wl.window)}
+	}
+	wl.mu.Unlock()
+
+	// Log outside the lock, so that a slow Logger doesn't block others.
+	for _, s := range summaries {
+		s.summarize()
+	}
+	if !ok {
+		l.Warn(msg, keysAndValues...)
+	}
+}
+
+func (wl *warnLimiter) flush() {
+	wl.mu.Lock()
+	var summaries []warnEntry
+	for _, e := range wl.entries {
+		if e.suppressed > 0 {
+			summaries = append(summaries, *e)
+		}
+	}
+	clear(wl.entries)
+	wl.mu.Unlock()
+
+	for _, s := range summaries {
+		s.summarize()
+	}
+}
+
+// expire removes entries whose window ended before the supplied time, and
+// returns those that suppressed warnings. It must be called with the lock
+// held.
+func (wl *warnLimiter) expire(now time.Time) []warnEntry {
+	var summaries []warnEntry
+	for k, e := range wl.entries {
+		if now.Before(e.end) {
+			continue
+		}
+		if e.suppressed > 0 {
+			summaries = append(summaries, *e)
+		}
+		delete(wl.entries, k)
+	}
+	return summaries
+}
+
+func (e warnEntry) summarize() {
+	e.log.Warn(fmt.Sprintf("%s (repeated %d times)", e.msg, e.suppressed), e.keysAndValues...)
+}
@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRateLimitedLogger(t *testing.T) {
+	window := 1 * time.Minute
+
+	cases := map[string]struct {
+		reason string
+		keys   []string
+		log    func(l *RateLimitedLogger, expire func())
+		want   []line
+	}{
+		"FirstWarning": {
+			reason: "The first of a set of identical warnings should be logged.",
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.Warn("Cannot observe", "error", "boom")
+			},
+			want: []line{{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"error", "boom"}}},
+		},
+		"RepeatsSuppressed": {
+			reason: "Identical warnings should be suppressed until the window ends, while other warnings are logged.",
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.Warn("Cannot observe")
+				l.Warn("Cannot observe")
+				l.Warn("Cannot update")
+				l.Warn("Cannot observe")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe"},
+				{Level: "warn", Msg: "Cannot update"},
+			},
+		},
+		"InfoAndDebugNotSuppressed": {
+			reason: "Info and debug messages should never be suppressed.",
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.Info("Reconciling")
+				l.Info("Reconciling")
+				l.Debug("Observing")
+				l.Debug("Observing")
+			},
+			want: []line{
+				{Level: "info", Msg: "Reconciling"},
+				{Level: "info", Msg: "Reconciling"},
+				{Level: "debug", Msg: "Observing"},
+				{Level: "debug", Msg: "Observing"},
+			},
+		},
+		"SummaryOnWindowExpiry": {
+			reason: "A summary of suppressed warnings should be logged the next time a warning is logged after the window ends.",
+			log: func(l *RateLimitedLogger, expire func()) {
+				l.Warn("Cannot observe", "error", "boom")
+				l.Warn("Cannot observe", "error", "boom")
+				l.Warn("Cannot observe", "error", "boom")
+				expire()
+				l.Warn("Cannot observe", "error", "boom")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"error", "boom"}},
+				{Level: "warn", Msg: "Cannot observe (repeated 2 times)", KeysAndValues: []any{"error", "boom"}},
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"error", "boom"}},
+			},
+		},
+		"SummaryOfOtherWarningOnWindowExpiry": {
+			reason: "Expired warnings should be swept and summarized when a different warning is logged.",
+			log: func(l *RateLimitedLogger, expire func()) {
+				l.Warn("Cannot observe")
+				l.Warn("Cannot observe")
+				expire()
+				l.Warn("Cannot update")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe"},
+				{Level: "warn", Msg: "Cannot observe (repeated 1 times)"},
+				{Level: "warn", Msg: "Cannot update"},
+			},
+		},
+		"SummaryOnFlush": {
+			reason: "A summary of suppressed warnings should be logged when the Logger is flushed.",
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.Warn("Cannot observe")
+				l.Warn("Cannot observe")
+				l.Warn("Cannot update")
+				l.Flush()
+				l.Warn("Cannot observe")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe"},
+				{Level: "warn", Msg: "Cannot update"},
+				{Level: "warn", Msg: "Cannot observe (repeated 1 times)"},
+				{Level: "warn", Msg: "Cannot observe"},
+			},
+		},
+		"NoKeysCallSiteValues": {
+			reason: "Without keys, warnings should be identical only if they were logged with the same structured data, ignoring data supplied to WithValues.",
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.Warn("Cannot observe", "resource", "a")
+				l.Warn("Cannot observe", "resource", "b")
+				l.WithValues("controller", "c").Warn("Cannot observe", "resource", "a")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "a"}},
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "b"}},
+			},
+		},
+		"KeysFromWithValues": {
+			reason: "With keys, warnings should be identical if they have the same values for the keys supplied to WithValues, regardless of other data.",
+			keys:   []string{"resource"},
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.WithValues("resource", "a").Warn("Cannot observe", "error", "boom")
+				l.WithValues("resource", "b").Warn("Cannot observe", "error", "boom")
+				l.WithValues("resource", "a").Warn("Cannot observe", "error", "bang")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "a", "error", "boom"}},
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "b", "error", "boom"}},
+			},
+		},
+		"KeysFromCallSite": {
+			reason: "With keys, values supplied with a warning should take precedence over values supplied to WithValues.",
+			keys:   []string{"resource"},
+			log: func(l *RateLimitedLogger, _ func()) {
+				l.WithValues("resource", "a").Warn("Cannot observe", "resource", "b")
+				l.Warn("Cannot observe", "resource", "b")
+				l.WithValues("resource", "a").Warn("Cannot observe")
+			},
+			want: []line{
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "a", "resource", "b"}},
+				{Level: "warn", Msg: "Cannot observe", KeysAndValues: []any{"resource", "a"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			var got []line
+			l := NewRateLimitedLogger(recordingLogger{lines: &got, debug: true}, window, tc.keys...)
+			l.limiter.now = func() time.Time { return now }
+
+			tc.log(l, func() { now = now.Add(window) })
+
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nRateLimitedLogger: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
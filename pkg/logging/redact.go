@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/redact"
+)
+
+// DefaultRedactedKeys are the keys whose values are redacted by a Logger
+// returned by NewRedactingLogger, unless other keys are supplied.
+var DefaultRedactedKeys = []string{"token", "password", "passwd", "secret", "authorization", "apikey", "api-key", "api_key", "credential"}
+
+// NewRedactingLogger returns a Logger that redacts sensitive values before
+// they're logged by the supplied Logger. The values of keys that contain any
+// of the supplied keys, ignoring case, are replaced entirely. For example the
+// key "token" redacts the value of "accessToken". The DefaultRedactedKeys are
+// used if no keys are supplied. Secrets recognized by the redact package's
+// default rules are scrubbed from messages and from string, error, and
+// fmt.Stringer values of any key.
+func NewRedactingLogger(l Logger, keys ...string) Logger {
+	if len(keys) == 0 {
+		keys = DefaultRedactedKeys
+	}
+	lk := make([]string, len(keys))
+	for i, k := range keys {
+		lk[i] = strings.ToLower(k)
+	}
+	return redactingLogger{log: l, keys: lk}
+}
+
+type redactingLogger struct {
+	log  Logger
+	keys []string
+}
+
+func (l redactingLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(redact.Redact(msg), l.redact(keysAndValues)...)
+}
+
+func (l redactingLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warn(redact.Redact(msg), l.redact(keysAndValues)...)
+}
+
+func (l redactingLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.Debug(redact.Redact(msg), l.redact(keysAndValues)...)
+}
+
+func (l redactingLogger) WithValues(keysAndValues ...any) Logger {
+	return redactingLogger{log: l.log.WithValues(l.redact(keysAndValues)...), keys: l.keys}
+}
+
+func (l redactingLogger) WithGroup(name string) Logger {
+	return redactingLogger{log: l.log.WithGroup(name), keys: l.keys}
+}
+
+func (l redactingLogger) Enabled(level Level) bool {
+	return l.log.Enabled(level)
+}
+
+func (l redactingLogger) v(verbosity int) Logger {
+	return redactingLogger{log: V(l.log, verbosity), keys: l.keys}
+}
+
+func (l redactingLogger) redact(keysAndValues []any) []any {
+	out := make([]any, len(keysAndValues))
+	copy(out, keysAndValues)
+	for i := 0; i+1 < len(out); i += 2 {
+		if k, ok := out[i].(string); ok && l.sensitive(k) {
+			out[i+1] = redact.Placeholder
+			continue
+		}
+		switch v := out[i+1].(type) {
+		case string:
+			out[i+1] = redact.Redact(v)
+		case error:
+			out[i+1] = redact.Redact(v.Error())
+		case fmt.Stringer:
+			out[i+1] = redact.Redact(v.String())
+		}
+	}
+	return out
+}
+
+func (l redactingLogger) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range l.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
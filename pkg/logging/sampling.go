@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// NewSamplingLogger returns a Logger that samples the debug messages of the
+// supplied Logger, so that debug logging of large numbers of resources
+// doesn't produce huge numbers of identical lines. Within each interval the
+// first debug messages with a particular message are logged, and thereafter
+// only one in every thereafter of them. If thereafter is zero no more of them
+// are logged until the interval ends. Counts are reset every interval, or
+// never if the interval is zero. Info messages and warnings are not sampled.
+//
+// Loggers returned by WithValues share their counts with the original Logger,
+// so messages logged by different reconciles are sampled together.
+func NewSamplingLogger(l Logger, interval time.Duration, first, thereafter int) Logger {
+	return samplingLogger{log: l, sampler: &sampler{
+		interval:   interval,
+		first:      max(first, 0),
+		thereafter: max(thereafter, 0),
+		now:        time.Now,
+		counts:     map[string]int{},
+	}}
+}
+
+type samplingLogger struct {
+	log     Logger
+	sampler *sampler
+}
+
+func (l samplingLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...)
+}
+
+func (l samplingLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warn(msg, keysAndValues...)
+}
+
+func (l samplingLogger) Debug(msg string, keysAndValues ...any) {
+	if !l.sampler.allow(msg) {
+		return
+	}
+	l.log.Debug(msg, keysAndValues...)
+}
+
+func (l samplingLogger) WithValues(keysAndValues ...any) Logger {
+	return samplingLogger{log: l.log.WithValues(keysAndValues...), sampler: l.sampler}
+}
+
+func (l samplingLogger) WithGroup(name string) Logger {
+	return samplingLogger{log: l.log.WithGroup(name), sampler: l.sampler}
+}
+
+func (l samplingLogger) Enabled(level Level) bool {
+	return l.log.Enabled(level)
+}
+
+func (l samplingLogger) v(verbosity int) Logger {
+	return samplingLogger{log: V(l.log, verbosity), sampler: l.sampler}
+}
+
+type sampler struct {
+	interval   time.Duration
+	first      int
+	thereafter int
+	now        func() time.Time
+
+	mu     sync.Mutex
+	reset  time.Time
+	counts map[string]int
+}
+
+func (s *sampler) allow(msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := s.now(); s.interval > 0 && !now.Before(s.reset) {
+		clear(s.counts)
+		s.reset = now.Add(s.interval)
+	}
+
+	s.counts[msg]++
+	n := s.counts[msg]
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
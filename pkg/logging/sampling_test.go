@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSamplerAllow(t *testing.T) {
+	interval := 1 * time.Minute
+
+	type args struct {
+		interval   time.Duration
+		first      int
+		thereafter int
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		// Each call to allow is made after advancing the clock by the
+		// corresponding duration.
+		advance []time.Duration
+		want    []bool
+	}{
+		"First": {
+			reason:  "The first messages should be allowed, and no more if thereafter is zero.",
+			args:    args{interval: interval, first: 2},
+			advance: []time.Duration{0, 0, 0, 0},
+			want:    []bool{true, true, false, false},
+		},
+		"Thereafter": {
+			reason:  "After the first messages only one in every thereafter should be allowed.",
+			args:    args{interval: interval, first: 1, thereafter: 2},
+			advance: []time.Duration{0, 0, 0, 0, 0},
+			want:    []bool{true, false, true, false, true},
+		},
+		"IntervalReset": {
+			reason:  "Counts should be reset when the interval ends.",
+			args:    args{interval: interval, first: 1},
+			advance: []time.Duration{0, 0, interval, 0},
+			want:    []bool{true, false, true, false},
+		},
+		"NoInterval": {
+			reason:  "Counts should never be reset if the interval is zero.",
+			args:    args{first: 1},
+			advance: []time.Duration{0, 0, interval, 0},
+			want:    []bool{true, false, false, false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			l := NewSamplingLogger(NewNopLogger(), tc.args.interval, tc.args.first, tc.args.thereafter).(samplingLogger)
+			l.sampler.now = func() time.Time { return now }
+
+			got := make([]bool, len(tc.advance))
+			for i, d := range tc.advance {
+				now = now.Add(d)
+				got[i] = l.sampler.allow("Observing")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ns.allow(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSamplingLogger(t *testing.T) {
+	var got []line
+	l := NewSamplingLogger(recordingLogger{lines: &got, debug: true}, 0, 1, 0)
+
+	l.Debug("Observing")
+	l.WithValues("resource", "a").Debug("Observing")
+	l.Debug("Connecting")
+	l.Info("Reconciling")
+	l.Info("Reconciling")
+	l.Warn("Cannot observe")
+	l.Warn("Cannot observe")
+
+	want := []line{
+		{Level: "debug", Msg: "Observing"},
+		{Level: "debug", Msg: "Connecting"},
+		{Level: "info", Msg: "Reconciling"},
+		{Level: "info", Msg: "Reconciling"},
+		{Level: "warn", Msg: "Cannot observe"},
+		{Level: "warn", Msg: "Cannot observe"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SamplingLogger: want debug messages sampled across WithValues, and other messages unsampled: -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger returns a Logger that is satisfied by the supplied
+// slog.Logger. Debug messages are logged at slog's debug level, and groups are
+// logged as slog groups.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{log: l}
+}
+
+type slogLogger struct {
+	log *slog.Logger
+}
+
+func (l slogLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Info(msg, keysAndValues...)
+}
+
+func (l slogLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.Debug(msg, keysAndValues...)
+}
+
+func (l slogLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warn(msg, keysAndValues...)
+}
+
+func (l slogLogger) WithValues(keysAndValues ...any) Logger {
+	return slogLogger{log: l.log.With(keysAndValues...)}
+}
+
+func (l slogLogger) WithGroup(name string) Logger {
+	return slogLogger{log: l.log.WithGroup(name)}
+}
+
+func (l slogLogger) Enabled(level Level) bool {
+	if level >= LevelDebug {
+		return l.log.Enabled(context.Background(), slog.LevelDebug)
+	}
+	return l.log.Enabled(context.Background(), slog.LevelInfo)
+}
@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ProductionZapConfig returns a zap configuration suitable for production. It
+// logs JSON at info level and above, with ISO8601 timestamps. Unlike zap's
+// own production configuration it doesn't sample messages; use
+// NewSamplingLogger to sample repetitive debug messages.
+func ProductionZapConfig() zap.Config {
+	c := zap.NewProductionConfig()
+	c.Sampling = nil
+	c.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return c
+}
+
+// DevelopmentZapConfig returns a zap configuration suitable for development.
+// It logs human readable, colored text at debug level and above.
+func DevelopmentZapConfig() zap.Config {
+	c := zap.NewDevelopmentConfig()
+	c.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	return c
+}
+
+type zapOptions struct {
+	config  zap.Config
+	level   *zapcore.Level
+	options []zap.Option
+}
+
+// A ZapOption configures the Logger returned by NewZapLogger.
+type ZapOption func(*zapOptions)
+
+// WithZapDevelopment configures the Logger using DevelopmentZapConfig rather
+// than ProductionZapConfig.
+func WithZapDevelopment() ZapOption {
+	return func(o *zapOptions) {
+		o.config = DevelopmentZapConfig()
+	}
+}
+
+// WithZapConfig configures the Logger using the supplied zap configuration
+// rather than ProductionZapConfig.
+func WithZapConfig(c zap.Config) ZapOption {
+	return func(o *zapOptions) {
+		o.config = c
+	}
+}
+
+// WithZapLevel configures the minimum level of messages the Logger logs.
+func WithZapLevel(l zapcore.Level) ZapOption {
+	return func(o *zapOptions) {
+		o.level = &l
+	}
+}
+
+// WithZapOptions configures options that are passed to zap when building the
+// Logger, for example to add hooks or a caller skip.
+func WithZapOptions(zo ...zap.Option) ZapOption {
+	return func(o *zapOptions) {
+		o.options = append(o.options, zo...)
+	}
+}
+
+// NewZapLogger returns a ZapLogger built using ProductionZapConfig, unless
+// configured otherwise by the supplied options.
+func NewZapLogger(o ...ZapOption) (*ZapLogger, error) {
+	zo := &zapOptions{config: ProductionZapConfig()}
+	for _, fn := range o {
+		fn(zo)
+	}
+	if zo.level != nil {
+		zo.config.Level = zap.NewAtomicLevelAt(*zo.level)
+	}
+	// Skip ZapLogger's own methods when reporting the caller.
+	zl, err := zo.config.Build(append([]zap.Option{zap.AddCallerSkip(1)}, zo.options...)...)
+	if err != nil {
+		return nil, err
+	}
+	return NewZapLoggerFrom(zl), nil
+}
+
+// NewZapLoggerFrom returns a ZapLogger that is satisfied by the supplied
+// zap.Logger. Callers should build the zap.Logger with zap.AddCallerSkip(1)
+// if it reports callers.
+func NewZapLoggerFrom(l *zap.Logger) *ZapLogger {
+	return &ZapLogger{log: l.Sugar()}
+}
+
+// A ZapLogger is a Logger that is satisfied by a zap.Logger. Debug messages
+// are logged at zap's debug level, and warnings at zap's warn level.
+type ZapLogger struct {
+	log *zap.SugaredLogger
+}
+
+// Info logs a message with optional structured data at info level.
+func (l *ZapLogger) Info(msg string, keysAndValues ...any) {
+	l.log.Infow(msg, keysAndValues...)
+}
+
+// Debug logs a message with optional structured data at debug level.
+func (l *ZapLogger) Debug(msg string, keysAndValues ...any) {
+	l.log.Debugw(msg, keysAndValues...)
+}
+
+// Warn logs a message with optional structured data at warn level.
+func (l *ZapLogger) Warn(msg string, keysAndValues ...any) {
+	l.log.Warnw(msg, keysAndValues...)
+}
+
+// WithValues returns a Logger that will include the supplied structured data
+// with any subsequent messages it logs.
+func (l *ZapLogger) WithValues(keysAndValues ...any) Logger {
+	return &ZapLogger{log: l.log.With(keysAndValues...)}
+}
+
+// WithGroup returns a Logger that will nest any structured data that is
+// subsequently supplied under the supplied name, using a zap namespace.
+func (l *ZapLogger) WithGroup(name string) Logger {
+	return &ZapLogger{log: l.log.With(zap.Namespace(name))}
+}
+
+// Enabled returns true if messages logged at the supplied level would be
+// logged.
+func (l *ZapLogger) Enabled(level Level) bool {
+	if level >= LevelDebug {
+		return l.log.Desugar().Core().Enabled(zapcore.DebugLevel)
+	}
+	return l.log.Desugar().Core().Enabled(zapcore.InfoLevel)
+}
+
+// Sync flushes any buffered messages. Callers should call it before exiting.
+func (l *ZapLogger) Sync() error {
+	return l.log.Sync()
+}
+
+// Zap returns the underlying zap.Logger.
+func (l *ZapLogger) Zap() *zap.Logger {
+	return l.log.Desugar()
+}
+
+// A WarnLogger is a Logger that can also log warnings.
+//
+// Deprecated: Every Logger can log warnings. Use Logger.
+type WarnLogger = Logger
+
+// Warn logs a warning using the supplied Logger.
+//
+// Deprecated: Use Logger.Warn.
+func Warn(l Logger, msg string, keysAndValues ...any) {
+	l.Warn(msg, keysAndValues...)
+}
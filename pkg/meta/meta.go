@@ -2,9 +2,31 @@
 package meta
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errParseDependsOn = "cannot parse depends-on annotation"
+	errParseTTL       = "cannot parse ttl annotation"
+	errFmtInvalidTTL  = "invalid ttl %q: must be positive"
+
+	errFmtInvalidLogLevel         = "invalid log level %q: must be an integer from %d to %d"
+	errFmtUnknownManagementPolicy = "unknown management policy %q: must be one of %s"
+
+	errParseTimeouts       = "cannot parse timeouts annotation"
+	errFmtUnknownOperation = "unknown operation %q in timeouts annotation"
+	errFmtParseTimeout     = "cannot parse %s timeout"
+	errFmtInvalidTimeout   = "invalid %s timeout %q: must be positive"
 )
 
 const (
@@ -13,6 +35,19 @@ const (
 	// systems.
 	AnnotationKeyExternalName = "krateo.io/external-name"
 
+	// AnnotationKeyExternalNameCreated is the key in the annotations map of
+	// a resource that records the external name with which its external
+	// resource was created. It is used to guard against changes to the
+	// external name after creation.
+	AnnotationKeyExternalNameCreated = "krateo.io/external-name-created"
+
+	// AnnotationKeyExternalNameTemplate is the key in the annotations map of
+	// a resource for a Go template that is rendered to produce its external
+	// name, if it does not already have one. The template is executed with the
+	// resource's JSON representation, for example
+	// "{{ .metadata.namespace }}-{{ .metadata.name }}".
+	AnnotationKeyExternalNameTemplate = "krateo.io/external-name-template"
+
 	// AnnotationKeyExternalCreatePending is the key in the annotations map
 	// of a resource that indicates the last time creation of the external
 	// resource was pending (i.e. about to happen). Its value must be an
@@ -40,10 +75,21 @@ const (
 	// will be queued for the resource.
 	AnnotationKeyReconciliationPaused = "krateo.io/paused"
 
+	// AnnotationKeyPausedReason is the key in the annotations map of a
+	// resource that explains why its reconciliation is paused. It has no
+	// effect unless reconciliation is paused using the
+	// AnnotationKeyReconciliationPaused annotation.
+	AnnotationKeyPausedReason = "krateo.io/paused-reason"
+
 	// AnnotationKeyConnectorVerbose is the key in the annotations map
 	// of a resource that indicates that the external client has verbose info enabled.
 	AnnotationKeyConnectorVerbose = "krateo.io/connector-verbose"
 
+	// AnnotationKeyLogLevel is the key in the annotations map of a resource
+	// that sets how verbosely it is logged, from LogLevelInfo to
+	// LogLevelTrace. It supersedes AnnotationKeyConnectorVerbose.
+	AnnotationKeyLogLevel = "krateo.io/log-level"
+
 	// AnnotationKeyManagementPolicy is the key in the annotations map
 	// of a resource to instruct the provider to manage resources in a fine-grained way.
 	// default: The provider can fully manage the resource.
@@ -60,6 +106,81 @@ const (
 	// of a resource that determines what should happen to the underlying external
 	// resource when a managed resource is deleted
 	AnnotationKeyDeletionPolicy = "krateo.io/deletion-policy"
+
+	// AnnotationKeyDependsOn is the key in the annotations map of a resource
+	// that lists the objects it depends on. Its value must be a JSON array of
+	// object references, each with an apiVersion, kind, name, and optional
+	// namespace. The external resource is not created until every object it
+	// depends on is ready.
+	AnnotationKeyDependsOn = "krateo.io/depends-on"
+
+	// AnnotationKeyTTL is the key in the annotations map of a resource that
+	// sets how long the resource should live, for example 24h. The resource
+	// is deleted, honoring its deletion policy, once this duration has passed
+	// since it was created.
+	AnnotationKeyTTL = "krateo.io/ttl"
+
+	// AnnotationKeySyncNow is the key in the annotations map of a resource
+	// that requests it be reconciled immediately, regardless of its poll
+	// interval or any rate limiting. Its value is conventionally the time at
+	// which the sync was requested, so that setting it again is a change. The
+	// annotation is removed once the reconcile starts.
+	AnnotationKeySyncNow = "krateo.io/sync-now"
+
+	// AnnotationKeyEvents is the key in the annotations map of a resource
+	// that determines whether events are recorded for it. Events are
+	// recorded unless it is set to EventsDisabled.
+	AnnotationKeyEvents = "krateo.io/events"
+
+	// AnnotationKeyManagedBy is the key in the annotations map of a managed
+	// resource that names the provider that manages it.
+	AnnotationKeyManagedBy = "krateo.io/managed-by"
+
+	// AnnotationKeyProviderVersion is the key in the annotations map of a
+	// managed resource that records the version of the provider that manages
+	// it.
+	AnnotationKeyProviderVersion = "krateo.io/provider-version"
+
+	// AnnotationKeyTimeouts is the key in the annotations map of a resource
+	// that sets how long each operation on its external resource may take.
+	// Its value must be a JSON object mapping operations (connect, observe,
+	// create, update, and delete) to durations, for example
+	// {"create":"5m","delete":"2m"}. Operations that aren't listed are only
+	// subject to the reconciler's overall timeout.
+	AnnotationKeyTimeouts = "krateo.io/timeouts"
+
+	// AnnotationKeyPriority is the key in the annotations map of a resource
+	// that sets the priority of its reconciles; one of PriorityHigh,
+	// PriorityNormal, or PriorityLow. Failed reconciles of high priority
+	// resources are retried sooner than those of low priority resources.
+	AnnotationKeyPriority = "krateo.io/priority"
+)
+
+// Log levels that may be set by the log-level annotation. Each level includes
+// the messages logged at lower levels.
+const (
+	// LogLevelInfo logs only messages that operators are likely to be
+	// concerned with. It is the default.
+	LogLevelInfo = 0
+
+	// LogLevelDebug additionally logs debug messages. It is equivalent to
+	// the connector-verbose annotation.
+	LogLevelDebug = 1
+
+	// LogLevelTrace is the most verbose level. Providers may use the levels
+	// between LogLevelDebug and LogLevelTrace to log increasingly detailed
+	// messages, such as requests to and responses from external systems, by
+	// logging them at the corresponding verbosity using logging.V.
+	LogLevelTrace = 4
+)
+
+// Operations on an external resource that may be subject to a timeout.
+const (
+	OperationConnect = "connect"
+	OperationObserve = "observe"
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
 )
 
 const (
@@ -82,6 +203,21 @@ const (
 	// managed resource is deleted.
 	DeletionPolicyDelete = "delete"
 
+	// EventsEnabled means events are recorded for the resource.
+	EventsEnabled = "enabled"
+
+	// EventsDisabled means events are not recorded for the resource.
+	EventsDisabled = "disabled"
+
+	// PriorityHigh means the resource's reconciles are retried sooner than
+	// those of other resources.
+	PriorityHigh = "high"
+	// PriorityNormal means the resource's reconciles are retried as usual.
+	PriorityNormal = "normal"
+	// PriorityLow means the resource's reconciles are retried later than
+	// those of other resources.
+	PriorityLow = "low"
+
 	// ActionCreate means to create an Object
 	ActionCreate = "create"
 	// ActionUpdate means to update an Object
@@ -186,6 +322,21 @@ func WasCreated(o metav1.Object) bool {
 	return !t.IsZero()
 }
 
+// HaveSameController returns true if both supplied objects are controlled by
+// the same object.
+func HaveSameController(a, b metav1.Object) bool {
+	ac := metav1.GetControllerOf(a)
+	bc := metav1.GetControllerOf(b)
+
+	// We do not consider two objects without any controller to have
+	// the same controller.
+	if ac == nil || bc == nil {
+		return false
+	}
+
+	return ac.UID == bc.UID
+}
+
 // GetExternalName returns the external name annotation value on the resource.
 func GetExternalName(o metav1.Object) string {
 	return o.GetAnnotations()[AnnotationKeyExternalName]
@@ -196,6 +347,84 @@ func SetExternalName(o metav1.Object, name string) {
 	AddAnnotations(o, map[string]string{AnnotationKeyExternalName: name})
 }
 
+// GetExternalNameCreated returns the external name with which the external
+// resource was created, if it was recorded.
+func GetExternalNameCreated(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKeyExternalNameCreated]
+}
+
+// SetExternalNameCreated records the external name with which the external
+// resource was created.
+func SetExternalNameCreated(o metav1.Object, name string) {
+	AddAnnotations(o, map[string]string{AnnotationKeyExternalNameCreated: name})
+}
+
+// ExternalNameChanged returns true if the external name differs from the
+// recorded external name with which the external resource was created.
+func ExternalNameChanged(o metav1.Object) bool {
+	c := GetExternalNameCreated(o)
+	return c != "" && c != GetExternalName(o)
+}
+
+// GetManagedBy returns the name of the provider that manages the resource.
+func GetManagedBy(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKeyManagedBy]
+}
+
+// SetManagedBy records the name of the provider that manages the resource.
+func SetManagedBy(o metav1.Object, provider string) {
+	AddAnnotations(o, map[string]string{AnnotationKeyManagedBy: provider})
+}
+
+// GetProviderVersion returns the version of the provider that manages the
+// resource.
+func GetProviderVersion(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKeyProviderVersion]
+}
+
+// SetProviderVersion records the version of the provider that manages the
+// resource.
+func SetProviderVersion(o metav1.Object, version string) {
+	AddAnnotations(o, map[string]string{AnnotationKeyProviderVersion: version})
+}
+
+// GetDependsOn returns the objects the resource depends on, as listed by its
+// depends-on annotation. Objects that don't specify a namespace are in the
+// namespace of the resource, if any.
+func GetDependsOn(o metav1.Object) ([]corev1.ObjectReference, error) {
+	a := o.GetAnnotations()[AnnotationKeyDependsOn]
+	if a == "" {
+		return nil, nil
+	}
+	refs := []corev1.ObjectReference{}
+	if err := json.Unmarshal([]byte(a), &refs); err != nil {
+		return nil, errors.Wrap(err, errParseDependsOn)
+	}
+	for i := range refs {
+		if refs[i].Namespace == "" {
+			refs[i].Namespace = o.GetNamespace()
+		}
+	}
+	return refs, nil
+}
+
+// GetExpiry returns the time at which the resource expires, as set by its ttl
+// annotation. It returns the zero time if the resource has no ttl annotation.
+func GetExpiry(o metav1.Object) (time.Time, error) {
+	a := o.GetAnnotations()[AnnotationKeyTTL]
+	if a == "" {
+		return time.Time{}, nil
+	}
+	ttl, err := time.ParseDuration(a)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, errParseTTL)
+	}
+	if ttl <= 0 {
+		return time.Time{}, errors.Errorf(errFmtInvalidTTL, a)
+	}
+	return o.GetCreationTimestamp().Add(ttl), nil
+}
+
 // GetExternalCreatePending returns the time at which the external resource
 // was most recently pending creation.
 func GetExternalCreatePending(o metav1.Object) time.Time {
@@ -280,25 +509,137 @@ func ExternalCreateSucceededDuring(o metav1.Object, d time.Duration) bool {
 	return time.Since(t) < d
 }
 
+// HasExternalCreateAnnotations returns true if the object has any of the
+// annotations that track creation of its external resource.
+func HasExternalCreateAnnotations(o metav1.Object) bool {
+	a := o.GetAnnotations()
+	for _, k := range []string{AnnotationKeyExternalCreatePending, AnnotationKeyExternalCreateSucceeded, AnnotationKeyExternalCreateFailed} {
+		if _, ok := a[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveExternalCreateAnnotations removes the annotations that track creation
+// of the object's external resource. They should only be removed once they
+// are no longer needed to determine whether creation succeeded.
+func RemoveExternalCreateAnnotations(o metav1.Object) {
+	RemoveAnnotations(o, AnnotationKeyExternalCreatePending, AnnotationKeyExternalCreateSucceeded, AnnotationKeyExternalCreateFailed)
+}
+
 // IsPaused returns true if the object has the AnnotationKeyReconciliationPaused
 // annotation set to `true`.
 func IsPaused(o metav1.Object) bool {
 	return o.GetAnnotations()[AnnotationKeyReconciliationPaused] == "true"
 }
 
+// AreEventsDisabled returns true if the object has the AnnotationKeyEvents
+// annotation set to EventsDisabled.
+func AreEventsDisabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyEvents] == EventsDisabled
+}
+
+// GetPriority returns the priority set by the object's AnnotationKeyPriority
+// annotation. It returns PriorityNormal if the annotation is not set, or is
+// set to an unknown priority.
+func GetPriority(o metav1.Object) string {
+	switch p := o.GetAnnotations()[AnnotationKeyPriority]; p {
+	case PriorityHigh, PriorityLow:
+		return p
+	default:
+		return PriorityNormal
+	}
+}
+
+// GetTimeouts returns the timeout of each operation listed by the resource's
+// timeouts annotation. It returns an error if the annotation lists an unknown
+// operation, or a duration that is invalid or not positive.
+func GetTimeouts(o metav1.Object) (map[string]time.Duration, error) {
+	a := o.GetAnnotations()[AnnotationKeyTimeouts]
+	if a == "" {
+		return nil, nil
+	}
+	raw := map[string]string{}
+	if err := json.Unmarshal([]byte(a), &raw); err != nil {
+		return nil, errors.Wrap(err, errParseTimeouts)
+	}
+	timeouts := make(map[string]time.Duration, len(raw))
+	for op, v := range raw {
+		switch op {
+		case OperationConnect, OperationObserve, OperationCreate, OperationUpdate, OperationDelete:
+		default:
+			return nil, errors.Errorf(errFmtUnknownOperation, op)
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseTimeout, op)
+		}
+		if d <= 0 {
+			return nil, errors.Errorf(errFmtInvalidTimeout, op, v)
+		}
+		timeouts[op] = d
+	}
+	return timeouts, nil
+}
+
+// GetTimeout returns the timeout of the supplied operation, as set by the
+// resource's timeouts annotation. It returns zero if the operation has no
+// timeout.
+func GetTimeout(o metav1.Object, operation string) (time.Duration, error) {
+	timeouts, err := GetTimeouts(o)
+	if err != nil {
+		return 0, err
+	}
+	return timeouts[operation], nil
+}
+
+// GetPausedReason returns the reason the object's reconciliation is paused,
+// as set by its paused-reason annotation.
+func GetPausedReason(o metav1.Object) string {
+	return o.GetAnnotations()[AnnotationKeyPausedReason]
+}
+
+// IsSyncRequested returns true if the object has a non-empty
+// AnnotationKeySyncNow annotation.
+func IsSyncRequested(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeySyncNow] != ""
+}
+
 // IsVerbose returns true if the object has the AnnotationKeyConnectorVerbose
 // annotation set to `true`.
+//
+// Deprecated: Use GetLogLevel, which also honors AnnotationKeyLogLevel.
 func IsVerbose(o metav1.Object) bool {
 	return o.GetAnnotations()[AnnotationKeyConnectorVerbose] == "true"
 }
 
-// IsActionAllowed determines if action is allowed to be performed on Object
-func IsActionAllowed(o metav1.Object, action string) bool {
-	p := o.GetAnnotations()[AnnotationKeyManagementPolicy]
-	if len(p) == 0 {
-		p = ManagementPolicyDefault
+// GetLogLevel returns how verbosely the object should be logged, as set by its
+// log-level annotation. If the object has no log-level annotation it returns
+// LogLevelDebug if its connector-verbose annotation is `true`, and
+// LogLevelInfo otherwise.
+func GetLogLevel(o metav1.Object) (int, error) {
+	a, ok := o.GetAnnotations()[AnnotationKeyLogLevel]
+	if !ok || a == "" {
+		if IsVerbose(o) {
+			return LogLevelDebug, nil
+		}
+		return LogLevelInfo, nil
 	}
+	l, err := strconv.Atoi(a)
+	if err != nil || l < LogLevelInfo || l > LogLevelTrace {
+		return LogLevelInfo, errors.Errorf(errFmtInvalidLogLevel, a, LogLevelInfo, LogLevelTrace)
+	}
+	return l, nil
+}
+
+// A ManagementPolicy determines which actions the provider may take on an
+// external resource.
+type ManagementPolicy string
 
+// IsActionAllowed returns true if the supplied action may be performed under
+// the management policy.
+func (p ManagementPolicy) IsActionAllowed(action string) bool {
 	if action == ActionCreate || action == ActionUpdate {
 		return p == ManagementPolicyDefault || p == ManagementPolicyObserveCreateUpdate
 	}
@@ -307,17 +648,112 @@ func IsActionAllowed(o metav1.Object, action string) bool {
 	return p == ManagementPolicyDefault || p == ManagementPolicyObserveDelete
 }
 
-// ShouldDelete determines if the external resource will orphaned
-func ShouldDelete(o metav1.Object) bool {
+// GetManagementPolicy returns the management policy of the supplied object,
+// as set by its management-policy annotation. It returns
+// ManagementPolicyDefault if the annotation is unset, and an error if it is
+// not a known management policy.
+func GetManagementPolicy(o metav1.Object) (ManagementPolicy, error) {
+	p := ManagementPolicy(o.GetAnnotations()[AnnotationKeyManagementPolicy])
+	switch p {
+	case "":
+		return ManagementPolicyDefault, nil
+	case ManagementPolicyDefault, ManagementPolicyObserveCreateUpdate, ManagementPolicyObserveDelete, ManagementPolicyObserve:
+		return p, nil
+	}
+	return "", errors.Errorf(errFmtUnknownManagementPolicy, p, strings.Join([]string{
+		ManagementPolicyDefault, ManagementPolicyObserveCreateUpdate, ManagementPolicyObserveDelete, ManagementPolicyObserve,
+	}, ", "))
+}
+
+// CheckManagementPolicy returns an error if the management policy annotation
+// of the supplied object is not a known management policy. The annotation is
+// not checked if the object specifies management policies in its spec, since
+// they take precedence over it.
+func CheckManagementPolicy(o metav1.Object) error {
+	if _, ok := specManagementPolicies(o); ok {
+		return nil
+	}
+	_, err := GetManagementPolicy(o)
+	return err
+}
+
+// IsActionAllowed determines if action is allowed to be performed on Object.
+// Management policies specified in its spec take precedence over its
+// management policy annotation. Unknown management policies allow no actions.
+//
+// Deprecated: Use ShouldCreate, ShouldUpdate, or ShouldDelete.
+func IsActionAllowed(o metav1.Object, action string) bool {
+	if mps, ok := specManagementPolicies(o); ok {
+		switch action {
+		case ActionCreate:
+			return mps.ShouldCreate()
+		case ActionUpdate:
+			return mps.ShouldUpdate()
+		}
+		return mps.ShouldDelete()
+	}
+	p, err := GetManagementPolicy(o)
+	if err != nil {
+		return false
+	}
+	return p.IsActionAllowed(action)
+}
+
+// A deletionPolicySpecifier specifies its deletion policy in its spec.
+type deletionPolicySpecifier interface {
+	GetDeletionPolicy() prv1.DeletionPolicy
+}
+
+// A managementPoliciesSpecifier specifies its management policies in its
+// spec.
+type managementPoliciesSpecifier interface {
+	GetManagementPolicies() prv1.ManagementPolicies
+}
+
+// specManagementPolicies returns the management policies the supplied object
+// specifies in its spec, if any. Management policies specified in the spec
+// take precedence over the management policy annotation.
+func specManagementPolicies(o metav1.Object) (prv1.ManagementPolicies, bool) {
+	s, ok := o.(managementPoliciesSpecifier)
+	if !ok || len(s.GetManagementPolicies()) == 0 {
+		return nil, false
+	}
+	return s.GetManagementPolicies(), true
+}
+
+// annotatedManagementPolicy returns the management policy annotation of the
+// supplied object, or ManagementPolicyDefault if it is unset.
+func annotatedManagementPolicy(o metav1.Object) string {
 	mp := o.GetAnnotations()[AnnotationKeyManagementPolicy]
 	if len(mp) == 0 {
 		mp = ManagementPolicyDefault
 	}
+	return mp
+}
 
+// deletionPolicy returns the deletion policy of the supplied object. A
+// deletion policy specified in its spec takes precedence over the deletion
+// policy annotation. It returns DeletionPolicyDelete if neither is set.
+func deletionPolicy(o metav1.Object) string {
+	if s, ok := o.(deletionPolicySpecifier); ok && s.GetDeletionPolicy() != "" {
+		return string(s.GetDeletionPolicy())
+	}
 	dp := o.GetAnnotations()[AnnotationKeyDeletionPolicy]
 	if len(dp) == 0 {
 		dp = DeletionPolicyDelete
 	}
+	return dp
+}
+
+// ShouldDelete determines if the external resource will orphaned
+func ShouldDelete(o metav1.Object) bool {
+	dp := deletionPolicy(o)
+
+	if mps, ok := specManagementPolicies(o); ok {
+		return mps.ShouldDelete() && dp == DeletionPolicyDelete
+	}
+
+	mp := annotatedManagementPolicy(o)
 
 	if dp == DeletionPolicyDelete && mp == ManagementPolicyDefault {
 		return true
@@ -333,30 +769,35 @@ func ShouldDelete(o metav1.Object) bool {
 // ShouldOnlyObserve returns true if the Observe action is allowed and all
 // other actions are not allowed.
 func ShouldOnlyObserve(o metav1.Object) bool {
-	mp := o.GetAnnotations()[AnnotationKeyManagementPolicy]
-	if len(mp) == 0 {
-		mp = ManagementPolicyDefault
+	if mps, ok := specManagementPolicies(o); ok {
+		return mps.IsObserveOnly()
 	}
-
-	return mp == ManagementPolicyObserve
+	return annotatedManagementPolicy(o) == ManagementPolicyObserve
 }
 
 // ShouldCreate returns true if the Create action is allowed.
 func ShouldCreate(o metav1.Object) bool {
-	mp := o.GetAnnotations()[AnnotationKeyManagementPolicy]
-	if len(mp) == 0 {
-		mp = ManagementPolicyDefault
+	if mps, ok := specManagementPolicies(o); ok {
+		return mps.ShouldCreate()
 	}
-
+	mp := annotatedManagementPolicy(o)
 	return mp == ManagementPolicyDefault || mp == ManagementPolicyObserveCreateUpdate
 }
 
 // ShouldUpdate returns true if the Update action is allowed.
 func ShouldUpdate(o metav1.Object) bool {
-	mp := o.GetAnnotations()[AnnotationKeyManagementPolicy]
-	if len(mp) == 0 {
-		mp = ManagementPolicyDefault
+	if mps, ok := specManagementPolicies(o); ok {
+		return mps.ShouldUpdate()
 	}
-
+	mp := annotatedManagementPolicy(o)
 	return mp == ManagementPolicyDefault || mp == ManagementPolicyObserveCreateUpdate
 }
+
+// ShouldLateInitialize returns true if the LateInitialize action is allowed.
+// The management policy annotation always allows it.
+func ShouldLateInitialize(o metav1.Object) bool {
+	if mps, ok := specManagementPolicies(o); ok {
+		return mps.ShouldLateInitialize()
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
@@ -9,6 +10,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
 )
 
 const (
@@ -271,6 +276,44 @@ func TestWasCreated(t *testing.T) {
 	}
 }
 
+func TestHaveSameController(t *testing.T) {
+	controller := func(uid types.UID) metav1.Object {
+		c := true
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{UID: uid, Controller: &c}}}}
+	}
+
+	cases := map[string]struct {
+		a    metav1.Object
+		b    metav1.Object
+		want bool
+	}{
+		"SameController": {
+			a:    controller(uid),
+			b:    controller(uid),
+			want: true,
+		},
+		"DifferentController": {
+			a:    controller(uid),
+			b:    controller("some-other-uuid"),
+			want: false,
+		},
+		"NoControllers": {
+			a:    &corev1.Pod{},
+			b:    &corev1.Pod{},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HaveSameController(tc.a, tc.b)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("HaveSameController(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetExternalName(t *testing.T) {
 	cases := map[string]struct {
 		o    metav1.Object
@@ -319,6 +362,132 @@ func TestSetExternalName(t *testing.T) {
 	}
 }
 
+func TestExternalNameChanged(t *testing.T) {
+	cases := map[string]struct {
+		o    metav1.Object
+		want bool
+	}{
+		"NotRecorded": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyExternalName: "new"}}},
+			want: false,
+		},
+		"Unchanged": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				AnnotationKeyExternalName:        "cool",
+				AnnotationKeyExternalNameCreated: "cool",
+			}}},
+			want: false,
+		},
+		"Changed": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				AnnotationKeyExternalName:        "new",
+				AnnotationKeyExternalNameCreated: "cool",
+			}}},
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ExternalNameChanged(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ExternalNameChanged(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetDependsOn(t *testing.T) {
+	type want struct {
+		refs []corev1.ObjectReference
+		err  error
+	}
+
+	cases := map[string]struct {
+		o    metav1.Object
+		want want
+	}{
+		"NoDependencies": {
+			o:    &corev1.Pod{},
+			want: want{},
+		},
+		"Dependencies": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Annotations: map[string]string{
+					AnnotationKeyDependsOn: `[{"apiVersion":"v1","kind":"ConfigMap","name":"a"},{"apiVersion":"v1","kind":"Secret","name":"b","namespace":"other"}]`,
+				},
+			}},
+			want: want{refs: []corev1.ObjectReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "a", Namespace: namespace},
+				{APIVersion: "v1", Kind: "Secret", Name: "b", Namespace: "other"},
+			}},
+		},
+		"InvalidAnnotation": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyDependsOn: "nope"}}},
+			want: want{err: errors.Wrap(json.Unmarshal([]byte("nope"), &[]corev1.ObjectReference{}), errParseDependsOn)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetDependsOn(tc.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GetDependsOn(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.refs, got); diff != "" {
+				t.Errorf("GetDependsOn(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetExpiry(t *testing.T) {
+	created := time.Now().Round(time.Second)
+	_, errParse := time.ParseDuration("nope")
+
+	type want struct {
+		t   time.Time
+		err error
+	}
+
+	cases := map[string]struct {
+		o    metav1.Object
+		want want
+	}{
+		"NoTTL": {
+			o:    &corev1.Pod{},
+			want: want{},
+		},
+		"TTL": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.NewTime(created),
+				Annotations:       map[string]string{AnnotationKeyTTL: "24h"},
+			}},
+			want: want{t: created.Add(24 * time.Hour)},
+		},
+		"InvalidTTL": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyTTL: "nope"}}},
+			want: want{err: errors.Wrap(errParse, errParseTTL)},
+		},
+		"NegativeTTL": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyTTL: "-1h"}}},
+			want: want{err: errors.Errorf(errFmtInvalidTTL, "-1h")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetExpiry(tc.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GetExpiry(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.t, got); diff != "" {
+				t.Errorf("GetExpiry(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGetExternalCreatePending(t *testing.T) {
 	now := time.Now().Round(time.Second)
 
@@ -597,6 +766,42 @@ func TestExternalCreateIncomplete(t *testing.T) {
 	}
 }
 
+func TestExternalCreateAnnotations(t *testing.T) {
+	now := time.Now().Format(time.RFC3339)
+
+	cases := map[string]struct {
+		o    metav1.Object
+		want bool
+	}{
+		"NoAnnotations": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyExternalName: "cool"}}},
+			want: false,
+		},
+		"Annotations": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				AnnotationKeyExternalName:            "cool",
+				AnnotationKeyExternalCreatePending:   now,
+				AnnotationKeyExternalCreateSucceeded: now,
+			}}},
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, HasExternalCreateAnnotations(tc.o)); diff != "" {
+				t.Errorf("HasExternalCreateAnnotations(...): -want, +got:\n%s", diff)
+			}
+			RemoveExternalCreateAnnotations(tc.o)
+			if diff := cmp.Diff(false, HasExternalCreateAnnotations(tc.o)); diff != "" {
+				t.Errorf("HasExternalCreateAnnotations(...) after RemoveExternalCreateAnnotations(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff("cool", GetExternalName(tc.o)); diff != "" {
+				t.Errorf("RemoveExternalCreateAnnotations(...): -want external name, +got external name:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsPaused(t *testing.T) {
 	cases := map[string]struct {
 		o    metav1.Object
@@ -637,6 +842,246 @@ func TestIsPaused(t *testing.T) {
 	}
 }
 
+func TestGetTimeout(t *testing.T) {
+	_, errParse := time.ParseDuration("nope")
+
+	type args struct {
+		o         metav1.Object
+		operation string
+	}
+	type want struct {
+		d   time.Duration
+		err error
+	}
+
+	withTimeouts := func(a string) metav1.Object {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyTimeouts: a}}}
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoTimeouts": {
+			args: args{o: &corev1.Pod{}, operation: OperationCreate},
+			want: want{},
+		},
+		"Timeout": {
+			args: args{o: withTimeouts(`{"create":"5m","delete":"2m"}`), operation: OperationCreate},
+			want: want{d: 5 * time.Minute},
+		},
+		"NoTimeoutForOperation": {
+			args: args{o: withTimeouts(`{"create":"5m"}`), operation: OperationObserve},
+			want: want{},
+		},
+		"InvalidAnnotation": {
+			args: args{o: withTimeouts("nope"), operation: OperationCreate},
+			want: want{err: errors.Wrap(json.Unmarshal([]byte("nope"), &map[string]string{}), errParseTimeouts)},
+		},
+		"UnknownOperation": {
+			args: args{o: withTimeouts(`{"explode":"5m"}`), operation: OperationCreate},
+			want: want{err: errors.Errorf(errFmtUnknownOperation, "explode")},
+		},
+		"InvalidDuration": {
+			args: args{o: withTimeouts(`{"create":"nope"}`), operation: OperationCreate},
+			want: want{err: errors.Wrapf(errParse, errFmtParseTimeout, OperationCreate)},
+		},
+		"NegativeDuration": {
+			args: args{o: withTimeouts(`{"create":"-5m"}`), operation: OperationCreate},
+			want: want{err: errors.Errorf(errFmtInvalidTimeout, OperationCreate, "-5m")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetTimeout(tc.args.o, tc.args.operation)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GetTimeout(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.d, got); diff != "" {
+				t.Errorf("GetTimeout(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetLogLevel(t *testing.T) {
+	type want struct {
+		level int
+		err   error
+	}
+
+	cases := map[string]struct {
+		o    metav1.Object
+		want want
+	}{
+		"NoAnnotations": {
+			o:    &corev1.Pod{},
+			want: want{level: LogLevelInfo},
+		},
+		"ConnectorVerbose": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyConnectorVerbose: "true"}}},
+			want: want{level: LogLevelDebug},
+		},
+		"LogLevel": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				AnnotationKeyConnectorVerbose: "true",
+				AnnotationKeyLogLevel:         "3",
+			}}},
+			want: want{level: 3},
+		},
+		"OutOfRange": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyLogLevel: "5"}}},
+			want: want{level: LogLevelInfo, err: errors.Errorf(errFmtInvalidLogLevel, "5", LogLevelInfo, LogLevelTrace)},
+		},
+		"NotAnInteger": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyLogLevel: "debug"}}},
+			want: want{level: LogLevelInfo, err: errors.Errorf(errFmtInvalidLogLevel, "debug", LogLevelInfo, LogLevelTrace)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetLogLevel(tc.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GetLogLevel(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.level, got); diff != "" {
+				t.Errorf("GetLogLevel(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsSyncRequested(t *testing.T) {
+	cases := map[string]struct {
+		o    metav1.Object
+		want bool
+	}{
+		"HasSyncNowAnnotation": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeySyncNow: "2024-01-01T00:00:00Z"}}},
+			want: true,
+		},
+		"NoSyncNowAnnotation": {
+			o:    &corev1.Pod{},
+			want: false,
+		},
+		"HasEmptySyncNowAnnotation": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeySyncNow: ""}}},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSyncRequested(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsSyncRequested(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetPriority(t *testing.T) {
+	cases := map[string]struct {
+		o    metav1.Object
+		want string
+	}{
+		"High": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyPriority: PriorityHigh}}},
+			want: PriorityHigh,
+		},
+		"Low": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyPriority: PriorityLow}}},
+			want: PriorityLow,
+		},
+		"NoPriorityAnnotation": {
+			o:    &corev1.Pod{},
+			want: PriorityNormal,
+		},
+		"UnknownPriority": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyPriority: "urgent"}}},
+			want: PriorityNormal,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetPriority(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetPriority(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetManagementPolicy(t *testing.T) {
+	type want struct {
+		p   ManagementPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		o    metav1.Object
+		want want
+	}{
+		"Unset": {
+			o:    &corev1.Pod{},
+			want: want{p: ManagementPolicyDefault},
+		},
+		"Known": {
+			o:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserveDelete}}},
+			want: want{p: ManagementPolicyObserveDelete},
+		},
+		"Unknown": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyManagementPolicy: "observe-crate-update"}}},
+			want: want{err: errors.Errorf(errFmtUnknownManagementPolicy, "observe-crate-update",
+				"default, observe-create-update, observe-delete, observe")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetManagementPolicy(tc.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GetManagementPolicy(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.p, got); diff != "" {
+				t.Errorf("GetManagementPolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckManagementPolicy(t *testing.T) {
+	unknown := map[string]string{AnnotationKeyManagementPolicy: "observe-crate-update"}
+
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		want   error
+	}{
+		"Unset": {
+			reason: "An unset management policy annotation should be valid.",
+			o:      &corev1.Pod{},
+		},
+		"Unknown": {
+			reason: "An unknown management policy annotation should be invalid.",
+			o:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: unknown}},
+			want: errors.Errorf(errFmtUnknownManagementPolicy, "observe-crate-update",
+				"default, observe-create-update, observe-delete, observe"),
+		},
+		"UnknownWithSpecManagementPolicies": {
+			reason: "The management policy annotation should be ignored if the spec specifies management policies.",
+			o:      withSpec(unknown, "", prv1.ManagementActionObserve),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckManagementPolicy(tc.o)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCheckManagementPolicy(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestIsAllowed(t *testing.T) {
 	cases := map[string]struct {
 		action string
@@ -681,6 +1126,21 @@ func TestIsAllowed(t *testing.T) {
 			}(),
 			want: false,
 		},
+		"CreateWithSpecManagementPolicies": {
+			action: ActionCreate,
+			o:      withSpec(map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserve}, "", prv1.ManagementActionObserve, prv1.ManagementActionCreate),
+			want:   true,
+		},
+		"DeleteWithSpecManagementPolicies": {
+			action: ActionDelete,
+			o:      withSpec(nil, "", prv1.ManagementActionObserve, prv1.ManagementActionCreate),
+			want:   false,
+		},
+		"UpdateWithSpecManagementPoliciesAndUnknownAnnotation": {
+			action: ActionUpdate,
+			o:      withSpec(map[string]string{AnnotationKeyManagementPolicy: "observe-crate-update"}, "", prv1.ManagementActionUpdate),
+			want:   true,
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -708,6 +1168,153 @@ func EquateErrors() cmp.Option {
 	})
 }
 
+// A specified object specifies its deletion and management policies in its
+// spec.
+type specified struct {
+	metav1.ObjectMeta
+	prv1.ResourceSpec
+}
+
+func withSpec(annotations map[string]string, dp prv1.DeletionPolicy, mps ...prv1.ManagementAction) metav1.Object {
+	return &specified{
+		ObjectMeta:   metav1.ObjectMeta{Annotations: annotations},
+		ResourceSpec: prv1.ResourceSpec{DeletionPolicy: dp, ManagementPolicies: mps},
+	}
+}
+
+func TestShouldDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		want   bool
+	}{
+		"Default": {
+			reason: "External resources should be deleted by default.",
+			o:      &corev1.Pod{},
+			want:   true,
+		},
+		"OrphanAnnotation": {
+			reason: "External resources should be orphaned if the deletion policy annotation says so.",
+			o:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyDeletionPolicy: DeletionPolicyOrphan}}},
+			want:   false,
+		},
+		"ObserveCreateUpdateAnnotation": {
+			reason: "External resources should be orphaned if the management policy annotation doesn't allow deletion.",
+			o:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserveCreateUpdate}}},
+			want:   false,
+		},
+		"OrphanSpec": {
+			reason: "External resources should be orphaned if the spec deletion policy says so.",
+			o:      withSpec(nil, prv1.DeletionOrphan),
+			want:   false,
+		},
+		"SpecDeletionPolicyWins": {
+			reason: "The spec deletion policy should take precedence over the deletion policy annotation.",
+			o:      withSpec(map[string]string{AnnotationKeyDeletionPolicy: DeletionPolicyOrphan}, prv1.DeletionDelete),
+			want:   true,
+		},
+		"SpecManagementPoliciesWithoutDelete": {
+			reason: "External resources should be orphaned if the spec management policies don't allow deletion.",
+			o:      withSpec(nil, "", prv1.ManagementActionObserve, prv1.ManagementActionCreate),
+			want:   false,
+		},
+		"SpecManagementPoliciesWin": {
+			reason: "The spec management policies should take precedence over the management policy annotation.",
+			o:      withSpec(map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserve}, "", prv1.ManagementActionObserve, prv1.ManagementActionDelete),
+			want:   true,
+		},
+		"SpecManagementPoliciesWithOrphan": {
+			reason: "External resources should be orphaned if the deletion policy says so, even if the management policies allow deletion.",
+			o:      withSpec(nil, prv1.DeletionOrphan, prv1.ManagementActionObserve, prv1.ManagementActionDelete),
+			want:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ShouldDelete(tc.o); got != tc.want {
+				t.Errorf("\n%s\nShouldDelete(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestShouldCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		want   bool
+	}{
+		"Default": {
+			reason: "External resources should be created by default.",
+			o:      &corev1.Pod{},
+			want:   true,
+		},
+		"ObserveAnnotation": {
+			reason: "External resources should not be created if the management policy annotation doesn't allow it.",
+			o:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserve}}},
+			want:   false,
+		},
+		"EmptySpec": {
+			reason: "The management policy annotation should apply if the spec doesn't specify management policies.",
+			o:      withSpec(map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserve}, ""),
+			want:   false,
+		},
+		"SpecManagementPoliciesWithoutCreate": {
+			reason: "External resources should not be created if the spec management policies don't allow it.",
+			o:      withSpec(nil, "", prv1.ManagementActionObserve),
+			want:   false,
+		},
+		"SpecManagementPoliciesWin": {
+			reason: "The spec management policies should take precedence over the management policy annotation.",
+			o:      withSpec(map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserve}, "", prv1.ManagementActionObserve, prv1.ManagementActionCreate),
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ShouldCreate(tc.o); got != tc.want {
+				t.Errorf("\n%s\nShouldCreate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestShouldLateInitialize(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		want   bool
+	}{
+		"Default": {
+			reason: "Managed resources should be late initialized by default.",
+			o:      &corev1.Pod{},
+			want:   true,
+		},
+		"ObserveAnnotation": {
+			reason: "The management policy annotation should not prevent late initialization.",
+			o:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationKeyManagementPolicy: ManagementPolicyObserve}}},
+			want:   true,
+		},
+		"SpecManagementPoliciesWithoutLateInitialize": {
+			reason: "Managed resources should not be late initialized if the spec management policies don't allow it.",
+			o:      withSpec(nil, "", prv1.ManagementActionObserve, prv1.ManagementActionCreate, prv1.ManagementActionUpdate, prv1.ManagementActionDelete),
+			want:   false,
+		},
+		"SpecManagementPoliciesWithLateInitialize": {
+			reason: "Managed resources should be late initialized if the spec management policies allow it.",
+			o:      withSpec(nil, "", prv1.ManagementActionObserve, prv1.ManagementActionLateInitialize),
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ShouldLateInitialize(tc.o); got != tc.want {
+				t.Errorf("\n%s\nShouldLateInitialize(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
 func TestAddFinalizer(t *testing.T) {
 	finalizer := "fin"
 	funalizer := "fun"
@@ -0,0 +1,73 @@
+package meta
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultPropagationPrefix is the prefix of the labels and annotations of a
+// managed resource that are conventionally propagated to its external
+// resource, for example as tags.
+const DefaultPropagationPrefix = "downstream.krateo.io/"
+
+// GetPropagated returns the labels and annotations of the supplied object
+// whose keys have the supplied prefix, with the prefix removed from their
+// keys. They are intended to be copied to the external resource, for example
+// as tags. An annotation takes precedence over a label with the same key. It
+// returns nil if no labels or annotations have the prefix.
+func GetPropagated(o metav1.Object, prefix string) map[string]string {
+	var p map[string]string
+	for _, m := range []map[string]string{o.GetLabels(), o.GetAnnotations()} {
+		for k, v := range m {
+			t, ok := strings.CutPrefix(k, prefix)
+			if !ok || t == "" {
+				continue
+			}
+			if p == nil {
+				p = map[string]string{}
+			}
+			p[t] = v
+		}
+	}
+	return p
+}
+
+// A TagDiff describes how to change observed tags to match desired tags.
+type TagDiff struct {
+	// Add contains the tags that are missing or have a different value.
+	Add map[string]string
+
+	// Remove contains the keys of the tags that are not desired, sorted.
+	Remove []string
+}
+
+// Empty returns true if the observed tags match the desired tags.
+func (d TagDiff) Empty() bool {
+	return len(d.Add) == 0 && len(d.Remove) == 0
+}
+
+// DiffTags returns how to change the supplied observed tags to match the
+// supplied desired tags. Desired tags are typically those returned by
+// GetPropagated. Observed tags should include only those tags that are
+// managed by propagation, since any other observed tag will be removed.
+func DiffTags(desired, observed map[string]string) TagDiff {
+	d := TagDiff{}
+	for k, v := range desired {
+		if ov, ok := observed[k]; ok && ov == v {
+			continue
+		}
+		if d.Add == nil {
+			d.Add = map[string]string{}
+		}
+		d.Add[k] = v
+	}
+	for k := range observed {
+		if _, ok := desired[k]; !ok {
+			d.Remove = append(d.Remove, k)
+		}
+	}
+	sort.Strings(d.Remove)
+	return d
+}
@@ -0,0 +1,97 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPropagated(t *testing.T) {
+	cases := map[string]struct {
+		o    metav1.Object
+		want map[string]string
+	}{
+		"NoneWithPrefix": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "platform"},
+				Annotations: map[string]string{AnnotationKeyExternalName: "cool"},
+			}},
+			want: nil,
+		},
+		"LabelsAndAnnotations": {
+			o: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"team":                             "ignored",
+					DefaultPropagationPrefix + "team":  "platform",
+					DefaultPropagationPrefix + "stage": "dev",
+				},
+				Annotations: map[string]string{
+					DefaultPropagationPrefix + "stage": "prod",
+					DefaultPropagationPrefix:           "empty",
+				},
+			}},
+			want: map[string]string{"team": "platform", "stage": "prod"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetPropagated(tc.o, DefaultPropagationPrefix)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetPropagated(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	type args struct {
+		desired  map[string]string
+		observed map[string]string
+	}
+	type want struct {
+		d     TagDiff
+		empty bool
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				desired:  map[string]string{"team": "platform"},
+				observed: map[string]string{"team": "platform"},
+			},
+			want: want{empty: true},
+		},
+		"Drifted": {
+			args: args{
+				desired:  map[string]string{"team": "platform", "stage": "prod", "owner": "ops"},
+				observed: map[string]string{"team": "platform", "stage": "dev", "old": "x", "older": "y"},
+			},
+			want: want{d: TagDiff{
+				Add:    map[string]string{"stage": "prod", "owner": "ops"},
+				Remove: []string{"old", "older"},
+			}},
+		},
+		"NoneDesired": {
+			args: args{observed: map[string]string{"old": "x"}},
+			want: want{d: TagDiff{Remove: []string{"old"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffTags(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want.d, got); diff != "" {
+				t.Errorf("DiffTags(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.empty, got.Empty()); diff != "" {
+				t.Errorf("DiffTags(...).Empty(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
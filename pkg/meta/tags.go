@@ -0,0 +1,43 @@
+package meta
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the tags that providers should set on external resources in order
+// to trace their ownership back to the managed resources that manage them.
+// These keys avoid characters, such as slashes, that some external systems
+// don't allow in tag keys.
+const (
+	ExternalTagKind            = "krateo-kind"
+	ExternalTagName            = "krateo-name"
+	ExternalTagNamespace       = "krateo-namespace"
+	ExternalTagUID             = "krateo-uid"
+	ExternalTagProvider        = "krateo-provider"
+	ExternalTagProviderVersion = "krateo-provider-version"
+)
+
+// GetExternalTags returns the tags that providers should set on the external
+// resource of the supplied managed resource. Its kind tag is its lowercase
+// kind and group, for example bucket.s3.aws.krateo.io. The provider tags are
+// read from the managed-by and provider-version annotations. Tags whose value
+// would be empty, such as the namespace of a cluster scoped managed resource,
+// are omitted.
+func GetExternalTags(mg client.Object) map[string]string {
+	tags := map[string]string{
+		ExternalTagKind:            strings.ToLower(mg.GetObjectKind().GroupVersionKind().GroupKind().String()),
+		ExternalTagName:            mg.GetName(),
+		ExternalTagNamespace:       mg.GetNamespace(),
+		ExternalTagUID:             string(mg.GetUID()),
+		ExternalTagProvider:        mg.GetAnnotations()[AnnotationKeyManagedBy],
+		ExternalTagProviderVersion: mg.GetAnnotations()[AnnotationKeyProviderVersion],
+	}
+	for k, v := range tags {
+		if v == "" {
+			delete(tags, k)
+		}
+	}
+	return tags
+}
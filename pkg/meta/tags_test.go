@@ -0,0 +1,59 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetExternalTags(t *testing.T) {
+	cases := map[string]struct {
+		o    client.Object
+		want map[string]string
+	}{
+		"Namespaced": {
+			o: &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{APIVersion: "s3.aws.krateo.io/v1", Kind: "Bucket"},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      name,
+					UID:       uid,
+					Annotations: map[string]string{
+						AnnotationKeyManagedBy:       "provider-aws",
+						AnnotationKeyProviderVersion: "v1.2.3",
+					},
+				},
+			},
+			want: map[string]string{
+				ExternalTagKind:            "bucket.s3.aws.krateo.io",
+				ExternalTagName:            name,
+				ExternalTagNamespace:       namespace,
+				ExternalTagUID:             string(uid),
+				ExternalTagProvider:        "provider-aws",
+				ExternalTagProviderVersion: "v1.2.3",
+			},
+		},
+		"ClusterScopedWithoutProvider": {
+			o: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "s3.aws.krateo.io/v1", Kind: "Bucket"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, UID: uid},
+			},
+			want: map[string]string{
+				ExternalTagKind: "bucket.s3.aws.krateo.io",
+				ExternalTagName: name,
+				ExternalTagUID:  string(uid),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetExternalTags(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetExternalTags(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package meta
+
+import (
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errFmtInvalidAnnotation = "invalid %s annotation"
+	errFmtInvalidTimestamp  = "%q is not an RFC3339 timestamp"
+	errFmtInvalidBool       = "%q is not true or false"
+	errFmtInvalidValue      = "%q is not one of %s"
+)
+
+// ValidateAnnotations returns an error describing every krateo.io annotation
+// of the supplied object that has an invalid value, or nil if they are all
+// valid. Annotations with empty values are treated as unset. Without
+// validation an invalid annotation, such as a misspelt management policy, is
+// silently treated as though it were unset.
+func ValidateAnnotations(o metav1.Object) error {
+	a := o.GetAnnotations()
+	errs := []error{}
+	invalid := func(key string, err error) {
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, errFmtInvalidAnnotation, key))
+		}
+	}
+
+	for _, k := range []string{AnnotationKeyExternalCreatePending, AnnotationKeyExternalCreateSucceeded, AnnotationKeyExternalCreateFailed} {
+		if v := a[k]; v != "" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				invalid(k, errors.Errorf(errFmtInvalidTimestamp, v))
+			}
+		}
+	}
+
+	for _, k := range []string{AnnotationKeyReconciliationPaused, AnnotationKeyConnectorVerbose} {
+		if v := a[k]; v != "" && v != "true" && v != "false" {
+			invalid(k, errors.Errorf(errFmtInvalidBool, v))
+		}
+	}
+
+	_, err := GetManagementPolicy(o)
+	invalid(AnnotationKeyManagementPolicy, err)
+
+	if v := a[AnnotationKeyDeletionPolicy]; v != "" {
+		invalid(AnnotationKeyDeletionPolicy, oneOf(v, []string{DeletionPolicyDelete, DeletionPolicyOrphan}))
+	}
+
+	if v := a[AnnotationKeyEvents]; v != "" {
+		invalid(AnnotationKeyEvents, oneOf(v, []string{EventsEnabled, EventsDisabled}))
+	}
+
+	if v := a[AnnotationKeyPriority]; v != "" {
+		invalid(AnnotationKeyPriority, oneOf(v, []string{PriorityHigh, PriorityNormal, PriorityLow}))
+	}
+
+	_, err = GetLogLevel(o)
+	invalid(AnnotationKeyLogLevel, err)
+
+	_, err = GetExpiry(o)
+	invalid(AnnotationKeyTTL, err)
+
+	_, err = GetTimeouts(o)
+	invalid(AnnotationKeyTimeouts, err)
+
+	_, err = GetDependsOn(o)
+	invalid(AnnotationKeyDependsOn, err)
+
+	return errors.Join(errs...)
+}
+
+func oneOf(v string, valid []string) error {
+	for _, s := range valid {
+		if v == s {
+			return nil
+		}
+	}
+	return errors.Errorf(errFmtInvalidValue, v, strings.Join(valid, ", "))
+}
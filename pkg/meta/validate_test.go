@@ -0,0 +1,72 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestValidateAnnotations(t *testing.T) {
+	withAnnotations := func(a map[string]string) metav1.Object {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: a}}
+	}
+
+	cases := map[string]struct {
+		o    metav1.Object
+		want error
+	}{
+		"NoAnnotations": {
+			o:    &corev1.Pod{},
+			want: nil,
+		},
+		"Valid": {
+			o: withAnnotations(map[string]string{
+				AnnotationKeyExternalCreatePending: time.Now().Format(time.RFC3339),
+				AnnotationKeyReconciliationPaused:  "false",
+				AnnotationKeyManagementPolicy:      ManagementPolicyObserveCreateUpdate,
+				AnnotationKeyDeletionPolicy:        DeletionPolicyOrphan,
+				AnnotationKeyTTL:                   "24h",
+				AnnotationKeyTimeouts:              `{"create":"5m"}`,
+				AnnotationKeyEvents:                EventsDisabled,
+				AnnotationKeyPriority:              PriorityHigh,
+			}),
+			want: nil,
+		},
+		"EmptyValues": {
+			o: withAnnotations(map[string]string{
+				AnnotationKeyReconciliationPaused: "",
+				AnnotationKeyManagementPolicy:     "",
+			}),
+			want: nil,
+		},
+		"Invalid": {
+			o: withAnnotations(map[string]string{
+				AnnotationKeyExternalCreateFailed: "yesterday",
+				AnnotationKeyReconciliationPaused: "yes",
+				AnnotationKeyManagementPolicy:     "observe-crate-update",
+				AnnotationKeyTTL:                  "-1h",
+			}),
+			want: errors.Join(
+				errors.Wrapf(errors.Errorf(errFmtInvalidTimestamp, "yesterday"), errFmtInvalidAnnotation, AnnotationKeyExternalCreateFailed),
+				errors.Wrapf(errors.Errorf(errFmtInvalidBool, "yes"), errFmtInvalidAnnotation, AnnotationKeyReconciliationPaused),
+				errors.Wrapf(errors.Errorf(errFmtUnknownManagementPolicy, "observe-crate-update", "default, observe-create-update, observe-delete, observe"), errFmtInvalidAnnotation, AnnotationKeyManagementPolicy),
+				errors.Wrapf(errors.Errorf(errFmtInvalidTTL, "-1h"), errFmtInvalidAnnotation, AnnotationKeyTTL),
+			),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateAnnotations(tc.o)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateAnnotations(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package ratelimiter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// adaptiveLimit is the rate currently allowed by each AdaptiveRateLimiter. It
+// is registered with the controller-runtime metrics registry, which is served
+// by the controller manager.
+var adaptiveLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "krateo",
+	Subsystem: "provider",
+	Name:      "adaptive_rate_limit",
+	Help:      "Average number of requeues per second currently allowed by an adaptive rate limiter.",
+}, []string{"limiter"})
+
+func init() {
+	metrics.Registry.MustRegister(adaptiveLimit)
+}
+
+// Feedback records the outcome of calls made on behalf of rate limited
+// requests, for example calls to an external system.
+type Feedback interface {
+	// Record the outcome of a call. A nil error means the call succeeded.
+	Record(err error)
+}
+
+var (
+	_ workqueue.TypedRateLimiter[any] = &AdaptiveRateLimiter{}
+	_ Feedback                        = &AdaptiveRateLimiter{}
+)
+
+// An AdaptiveRateLimiter is a token bucket rate limiter whose rate adapts to
+// the outcome of the calls made on behalf of the requests it limits. It
+// increases its rate additively while calls succeed, and decreases it
+// multiplicatively when the ratio of calls that fail rises, in the manner of
+// TCP congestion control.
+type AdaptiveRateLimiter struct {
+	name    string
+	limiter *rate.Limiter
+
+	min       float64
+	max       float64
+	increase  float64
+	decrease  float64
+	threshold float64
+	window    int
+
+	mu        sync.Mutex
+	rps       float64
+	successes int
+	failures  int
+}
+
+// An AdaptiveOption configures an AdaptiveRateLimiter.
+type AdaptiveOption func(*AdaptiveRateLimiter)
+
+// WithAdaptiveBounds configures the minimum and maximum rate, in average
+// requeues per second, the AdaptiveRateLimiter may adapt to. The defaults are
+// one tenth of, and ten times, the initial rate.
+func WithAdaptiveBounds(minRPS, maxRPS float64) AdaptiveOption {
+	return func(l *AdaptiveRateLimiter) {
+		l.min, l.max = minRPS, maxRPS
+	}
+}
+
+// WithAdaptiveIncrease configures how many requeues per second the
+// AdaptiveRateLimiter adds to its rate after each window in which calls
+// succeed. The default is one.
+func WithAdaptiveIncrease(rps float64) AdaptiveOption {
+	return func(l *AdaptiveRateLimiter) {
+		l.increase = rps
+	}
+}
+
+// WithAdaptiveDecrease configures the factor by which the AdaptiveRateLimiter
+// multiplies its rate after each window in which too many calls fail. The
+// default is 0.5.
+func WithAdaptiveDecrease(factor float64) AdaptiveOption {
+	return func(l *AdaptiveRateLimiter) {
+		l.decrease = factor
+	}
+}
+
+// WithAdaptiveErrorThreshold configures the ratio of calls that may fail
+// within a window before the AdaptiveRateLimiter decreases its rate. The
+// default is 0.1.
+func WithAdaptiveErrorThreshold(ratio float64) AdaptiveOption {
+	return func(l *AdaptiveRateLimiter) {
+		l.threshold = ratio
+	}
+}
+
+// WithAdaptiveWindow configures how many outcomes the AdaptiveRateLimiter
+// records before it adapts its rate. The default is 20.
+func WithAdaptiveWindow(n int) AdaptiveOption {
+	return func(l *AdaptiveRateLimiter) {
+		l.window = max(n, 1)
+	}
+}
+
+// NewAdaptive returns an AdaptiveRateLimiter meant for limiting the number of
+// average total requeues per second for all controllers registered with a
+// controller manager, like NewGlobal. It initially allows the supplied rate.
+// Its current rate is exposed as the krateo_provider_adaptive_rate_limit
+// metric, labelled with the supplied name. The outcome of calls must be
+// recorded using Record for the rate to adapt.
+func NewAdaptive(name string, rps float64, o ...AdaptiveOption) *AdaptiveRateLimiter {
+	l := &AdaptiveRateLimiter{
+		name:      name,
+		min:       rps / 10,
+		max:       rps * 10,
+		increase:  1,
+		decrease:  0.5,
+		threshold: 0.1,
+		window:    20,
+	}
+	for _, fn := range o {
+		fn(l)
+	}
+	l.rps = math.Min(math.Max(rps, l.min), l.max)
+
+	l.limiter = rate.NewLimiter(rate.Limit(l.rps), burst(l.rps))
+	adaptiveLimit.WithLabelValues(name).Set(l.rps)
+	return l
+}
+
+// burst returns the bucket size, i.e. allowed burst, for the supplied rate.
+// Like NewGlobal it's ten times the rate. It's scaled with the rate as the
+// rate adapts, so that a decreased rate isn't undermined by a burst sized for
+// a higher one.
+func burst(rps float64) int {
+	return max(int(math.Ceil(rps*10)), 1)
+}
+
+// When returns how long the supplied item must wait before it is processed.
+func (l *AdaptiveRateLimiter) When(_ any) time.Duration {
+	return l.limiter.Reserve().Delay()
+}
+
+// Forget does nothing. An AdaptiveRateLimiter doesn't track items.
+func (l *AdaptiveRateLimiter) Forget(_ any) {}
+
+// NumRequeues always returns zero. An AdaptiveRateLimiter doesn't track items.
+func (l *AdaptiveRateLimiter) NumRequeues(_ any) int { return 0 }
+
+// Record the outcome of a call. Once a window of outcomes has been recorded
+// the rate is decreased if the ratio of failed calls exceeds the error
+// threshold, and increased otherwise.
+func (l *AdaptiveRateLimiter) Record(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err != nil {
+		l.failures++
+	} else {
+		l.successes++
+	}
+	if l.successes+l.failures < l.window {
+		return
+	}
+
+	ratio := float64(l.failures) / float64(l.successes+l.failures)
+	l.successes, l.failures = 0, 0
+
+	rps := l.rps + l.increase
+	if ratio > l.threshold {
+		rps = l.rps * l.decrease
+	}
+	l.rps = math.Min(math.Max(rps, l.min), l.max)
+	l.limiter.SetLimit(rate.Limit(l.rps))
+	l.limiter.SetBurst(burst(l.rps))
+	adaptiveLimit.WithLabelValues(l.name).Set(l.rps)
+}
+
+// Limit returns the rate, in average requeues per second, currently allowed.
+func (l *AdaptiveRateLimiter) Limit() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rps
+}
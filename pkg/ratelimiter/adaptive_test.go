@@ -0,0 +1,86 @@
+package ratelimiter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type outcomes struct {
+		successes int
+		failures  int
+	}
+
+	cases := map[string]struct {
+		reason   string
+		rps      float64
+		o        []AdaptiveOption
+		outcomes []outcomes
+		want     float64
+	}{
+		"IncompleteWindow": {
+			reason:   "The rate should not change until a window of outcomes has been recorded.",
+			rps:      10,
+			o:        []AdaptiveOption{WithAdaptiveWindow(10)},
+			outcomes: []outcomes{{successes: 9}},
+			want:     10,
+		},
+		"AdditiveIncrease": {
+			reason:   "The rate should increase additively after each window in which calls succeed.",
+			rps:      10,
+			o:        []AdaptiveOption{WithAdaptiveWindow(10), WithAdaptiveIncrease(2)},
+			outcomes: []outcomes{{successes: 10}, {successes: 9, failures: 1}},
+			want:     14,
+		},
+		"MultiplicativeDecrease": {
+			reason:   "The rate should decrease multiplicatively after each window in which too many calls fail.",
+			rps:      10,
+			o:        []AdaptiveOption{WithAdaptiveWindow(10), WithAdaptiveErrorThreshold(0.1)},
+			outcomes: []outcomes{{successes: 8, failures: 2}, {successes: 5, failures: 5}},
+			want:     2.5,
+		},
+		"Minimum": {
+			reason:   "The rate should not decrease below the minimum.",
+			rps:      10,
+			o:        []AdaptiveOption{WithAdaptiveWindow(1), WithAdaptiveBounds(4, 20)},
+			outcomes: []outcomes{{failures: 5}},
+			want:     4,
+		},
+		"Maximum": {
+			reason:   "The rate should not increase above the maximum.",
+			rps:      10,
+			o:        []AdaptiveOption{WithAdaptiveWindow(1), WithAdaptiveBounds(4, 12)},
+			outcomes: []outcomes{{successes: 5}},
+			want:     12,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewAdaptive(name, tc.rps, tc.o...)
+			for _, o := range tc.outcomes {
+				for range o.successes {
+					l.Record(nil)
+				}
+				for range o.failures {
+					l.Record(errBoom)
+				}
+			}
+			if got := l.Limit(); got != tc.want {
+				t.Errorf("\n%s\nLimit(): want %v, got %v", tc.reason, tc.want, got)
+			}
+			if got := testutil.ToFloat64(adaptiveLimit.WithLabelValues(name)); got != tc.want {
+				t.Errorf("\n%s\nkrateo_provider_adaptive_rate_limit: want %v, got %v", tc.reason, tc.want, got)
+			}
+			if want, got := int(math.Ceil(tc.want*10)), l.limiter.Burst(); got != want {
+				t.Errorf("\n%s\nBurst(): want %v, got %v", tc.reason, want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package ratelimiter
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// A CircuitBreaker wraps a per-item rate limiter, complementing its backoff
+// with protection for the external systems items share. Items are grouped by
+// a key, for example the endpoint of the external system they relate to.
+// Once the items sharing a key have failed a number of times in a row the
+// circuit for the key opens, and every item with that key is delayed until a
+// cool-down has passed. The circuit closes when an item with the key
+// succeeds. If an item fails after the cool-down the circuit opens again.
+//
+// A CircuitBreaker counts each call to When as a failure, and each call to
+// Forget as a success, as is the case for a controller's rate limiter. It
+// should not be used as a rate limiter that is forgotten whenever a request
+// is allowed, like that of a Reconciler.
+type CircuitBreaker[T comparable] struct {
+	workqueue.TypedRateLimiter[T]
+
+	key       func(item T) (string, bool)
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker returns a CircuitBreaker that wraps the supplied rate
+// limiter. Items are grouped by the key returned by the supplied function;
+// items for which it returns false aren't subject to a circuit. The circuit
+// for a key opens for the supplied cool-down after the supplied number of
+// consecutive failures. Thresholds below one are treated as one.
+func NewCircuitBreaker[T comparable](l workqueue.TypedRateLimiter[T], key func(item T) (string, bool), threshold int, cooldown time.Duration) *CircuitBreaker[T] {
+	return &CircuitBreaker[T]{
+		TypedRateLimiter: l,
+		key:              key,
+		threshold:        max(threshold, 1),
+		cooldown:         cooldown,
+		now:              time.Now,
+		circuits:         map[string]*circuit{},
+	}
+}
+
+// When records that the supplied item failed, and returns how long to wait
+// before retrying it. This is the longer of the wrapped rate limiter's delay
+// and the time remaining until the circuit for the item's key closes, if it's
+// open.
+func (b *CircuitBreaker[T]) When(item T) time.Duration {
+	d := b.TypedRateLimiter.When(item)
+
+	k, ok := b.key(item)
+	if !ok {
+		return d
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[k]
+	if !ok {
+		c = &circuit{}
+		b.circuits[k] = c
+	}
+	now := b.now()
+
+	// Don't count failures while the circuit is open. They're most likely
+	// failures of calls made before it opened.
+	if now.Before(c.openUntil) {
+		return max(d, c.openUntil.Sub(now))
+	}
+
+	c.failures++
+	if c.failures < b.threshold {
+		return d
+	}
+	c.openUntil = now.Add(b.cooldown)
+	return max(d, b.cooldown)
+}
+
+// Forget records that the supplied item succeeded, closing the circuit for
+// its key, and forgets the item.
+func (b *CircuitBreaker[T]) Forget(item T) {
+	b.TypedRateLimiter.Forget(item)
+
+	k, ok := b.key(item)
+	if !ok {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.circuits, k)
+}
+
+// Open returns true if the circuit for the supplied key is open.
+func (b *CircuitBreaker[T]) Open(key string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	return ok && b.now().Before(c.openUntil)
+}
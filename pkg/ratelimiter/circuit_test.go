@@ -0,0 +1,112 @@
+package ratelimiter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Items are named endpoint/resource.
+	endpoint := func(item any) (string, bool) {
+		s, ok := item.(string)
+		if !ok || !strings.Contains(s, "/") {
+			return "", false
+		}
+		return strings.SplitN(s, "/", 2)[0], true
+	}
+
+	type call struct {
+		after  time.Duration
+		item   string
+		forget bool
+		want   time.Duration
+	}
+
+	cases := map[string]struct {
+		reason string
+		calls  []call
+	}{
+		"BelowThreshold": {
+			reason: "Failures below the threshold should be delayed by the wrapped rate limiter.",
+			calls: []call{
+				{item: "a/1", want: time.Second},
+				{item: "a/2", want: time.Second},
+			},
+		},
+		"Open": {
+			reason: "Once the threshold is reached every item with the key should be delayed until the cool-down has passed.",
+			calls: []call{
+				{item: "a/1", want: time.Second},
+				{item: "a/2", want: time.Second},
+				{item: "a/3", want: time.Minute},
+				{after: 20 * time.Second, item: "a/4", want: 40 * time.Second},
+				{item: "b/1", want: time.Second},
+			},
+		},
+		"HalfOpen": {
+			reason: "A failure after the cool-down should open the circuit again.",
+			calls: []call{
+				{item: "a/1", want: time.Second},
+				{item: "a/2", want: time.Second},
+				{item: "a/3", want: time.Minute},
+				{after: time.Minute, item: "a/1", want: time.Minute},
+			},
+		},
+		"Success": {
+			reason: "A success should close the circuit and reset its failures.",
+			calls: []call{
+				{item: "a/1", want: time.Second},
+				{item: "a/2", want: time.Second},
+				{item: "a/3", want: time.Minute},
+				{item: "a/1", forget: true},
+				{item: "a/2", want: time.Second},
+			},
+		},
+		"NoKey": {
+			reason: "Items without a key should not be subject to a circuit.",
+			calls: []call{
+				{item: "x", want: time.Second},
+				{item: "x", want: time.Second},
+				{item: "x", want: time.Second},
+				{item: "x", want: time.Second},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := start
+			b := NewCircuitBreaker[any](&predictableRateLimiter{d: time.Second}, endpoint, 3, time.Minute)
+			b.now = func() time.Time { return now }
+
+			for i, c := range tc.calls {
+				now = now.Add(c.after)
+				if c.forget {
+					b.Forget(c.item)
+					continue
+				}
+				if got := b.When(c.item); got != c.want {
+					t.Errorf("\n%s\nWhen(%q) call %d: want %v, got %v", tc.reason, c.item, i, c.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerOpen(t *testing.T) {
+	b := NewCircuitBreaker[any](&predictableRateLimiter{}, func(_ any) (string, bool) { return "a", true }, 1, time.Minute)
+	if b.Open("a") {
+		t.Errorf("Open(...): want circuit closed before any failures")
+	}
+	b.When("item")
+	if !b.Open("a") {
+		t.Errorf("Open(...): want circuit open after reaching the threshold")
+	}
+	b.Forget("item")
+	if b.Open("a") {
+		t.Errorf("Open(...): want circuit closed after a success")
+	}
+}
@@ -0,0 +1,52 @@
+package ratelimiter
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// NewMaxOf returns a rate limiter that imposes the longest delay imposed by
+// any of the supplied rate limiters, for example to combine NewGlobal's token
+// bucket with NewController's per-item exponential backoff.
+func NewMaxOf[T comparable](l ...workqueue.TypedRateLimiter[T]) workqueue.TypedRateLimiter[T] {
+	return workqueue.NewTypedMaxOfRateLimiter(l...)
+}
+
+// NewMinOf returns a rate limiter that imposes the shortest delay imposed by
+// any of the supplied rate limiters, for example to cap a per-item
+// exponential backoff using a rate limiter that never imposes a long delay.
+// Every supplied rate limiter is consulted, so that each of them tracks every
+// item. It imposes no delay if no rate limiters are supplied.
+func NewMinOf[T comparable](l ...workqueue.TypedRateLimiter[T]) workqueue.TypedRateLimiter[T] {
+	return &minOfRateLimiter[T]{limiters: l}
+}
+
+type minOfRateLimiter[T comparable] struct {
+	limiters []workqueue.TypedRateLimiter[T]
+}
+
+func (r *minOfRateLimiter[T]) When(item T) time.Duration {
+	var d time.Duration
+	for i, l := range r.limiters {
+		if ld := l.When(item); i == 0 || ld < d {
+			d = ld
+		}
+	}
+	return d
+}
+
+// NumRequeues returns the most requeues counted by any of the rate limiters.
+func (r *minOfRateLimiter[T]) NumRequeues(item T) int {
+	n := 0
+	for _, l := range r.limiters {
+		n = max(n, l.NumRequeues(item))
+	}
+	return n
+}
+
+func (r *minOfRateLimiter[T]) Forget(item T) {
+	for _, l := range r.limiters {
+		l.Forget(item)
+	}
+}
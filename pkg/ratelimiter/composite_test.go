@@ -0,0 +1,65 @@
+package ratelimiter
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestComposite(t *testing.T) {
+	short := &predictableRateLimiter{d: time.Second}
+	long := &predictableRateLimiter{d: time.Minute}
+
+	cases := map[string]struct {
+		reason string
+		l      workqueue.TypedRateLimiter[any]
+		want   time.Duration
+	}{
+		"MaxOf": {
+			reason: "NewMaxOf should impose the longest delay.",
+			l:      NewMaxOf[any](short, long),
+			want:   time.Minute,
+		},
+		"MinOf": {
+			reason: "NewMinOf should impose the shortest delay.",
+			l:      NewMinOf[any](long, short),
+			want:   time.Second,
+		},
+		"MinOfNone": {
+			reason: "NewMinOf should impose no delay if no rate limiters are supplied.",
+			l:      NewMinOf[any](),
+			want:   0,
+		},
+		"MinOfControllerAndTimedFailure": {
+			reason: "NewMinOf should combine the package's rate limiters.",
+			l:      NewMinOf(NewController(), NewGlobalExponential(5*time.Second, time.Minute)),
+			want:   time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.l.When("item"); got != tc.want {
+				t.Errorf("\n%s\nWhen(...): want %v, got %v", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMinOfNumRequeues(t *testing.T) {
+	a := workqueue.NewTypedItemExponentialFailureRateLimiter[any](time.Second, time.Minute)
+	b := workqueue.NewTypedItemExponentialFailureRateLimiter[any](time.Second, time.Minute)
+	b.When("item")
+
+	l := NewMinOf[any](a, b)
+	l.When("item")
+	if got := l.NumRequeues("item"); got != 2 {
+		t.Errorf("NumRequeues(...): want 2, got %d", got)
+	}
+
+	l.Forget("item")
+	if got := l.NumRequeues("item"); got != 0 {
+		t.Errorf("NumRequeues(...) after Forget(...): want 0, got %d", got)
+	}
+}
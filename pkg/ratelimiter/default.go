@@ -14,11 +14,21 @@ import (
 // of average total requeues per second for all controllers registered with a
 // controller manager. The bucket size (i.e. allowed burst) is rps * 10.
 func NewGlobal(rps int) *workqueue.TypedBucketRateLimiter[any] {
-	return &workqueue.TypedBucketRateLimiter[any]{Limiter: rate.NewLimiter(rate.Limit(rps), rps*10)}
+	return NewGlobalWithBurst(float64(rps), rps*10)
 }
 
-func NewGlobalExponential(baseDelay time.Duration, maxDelay time.Duration) workqueue.TypedRateLimiter[any] {
-	return internal_workqueue.NewExponentialTimedFailureRateLimiter[any](baseDelay, maxDelay)
+// NewGlobalWithBurst returns a token bucket rate limiter like NewGlobal, with
+// the supplied bucket size (i.e. allowed burst). The rate may be fractional,
+// for example 0.5 to allow an average of 30 requeues per minute. A burst of
+// one paces requeues evenly, which suits external systems with strict quotas.
+// Bursts below one are treated as one, so that requeues are never blocked
+// forever.
+func NewGlobalWithBurst(rps float64, burst int) *workqueue.TypedBucketRateLimiter[any] {
+	return &workqueue.TypedBucketRateLimiter[any]{Limiter: rate.NewLimiter(rate.Limit(rps), max(burst, 1))}
+}
+
+func NewGlobalExponential(baseDelay time.Duration, maxDelay time.Duration, o ...internal_workqueue.TimedFailureOption) workqueue.TypedRateLimiter[any] {
+	return internal_workqueue.NewExponentialTimedFailureRateLimiter[any](baseDelay, maxDelay, o...)
 }
 
 // NewController returns a rate limiter that takes the maximum delay between the
@@ -18,3 +18,52 @@ func TestDefaultManagedRateLimiter(t *testing.T) {
 		t.Errorf("expected %v, got %v", e, a)
 	}
 }
+
+func TestNewGlobalWithBurst(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		rps       float64
+		burst     int
+		wantBurst int
+		wantLimit float64
+	}{
+		"Fractional": {
+			reason:    "Fractional rates and explicit bursts should be honored.",
+			rps:       0.5,
+			burst:     3,
+			wantBurst: 3,
+			wantLimit: 0.5,
+		},
+		"ZeroBurst": {
+			reason:    "Bursts below one should be treated as one.",
+			rps:       1,
+			burst:     0,
+			wantBurst: 1,
+			wantLimit: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewGlobalWithBurst(tc.rps, tc.burst)
+			if got := l.Burst(); got != tc.wantBurst {
+				t.Errorf("\n%s\nBurst(): want %d, got %d", tc.reason, tc.wantBurst, got)
+			}
+			if got := float64(l.Limit()); got != tc.wantLimit {
+				t.Errorf("\n%s\nLimit(): want %v, got %v", tc.reason, tc.wantLimit, got)
+			}
+		})
+	}
+}
+
+func TestNewGlobalWithBurstPacing(t *testing.T) {
+	// With a burst of one only the first requeue is allowed immediately, and
+	// the second must wait for the bucket to refill at half a token per second.
+	l := NewGlobalWithBurst(0.5, 1)
+	if d := l.When("one"); d != 0 {
+		t.Errorf("first When(...): want 0, got %v", d)
+	}
+	if d := l.When("two"); d < time.Second || d > 2*time.Second {
+		t.Errorf("second When(...): want ~2s, got %v", d)
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimiter
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// A FairRateLimiter splits a global budget of requeues per second among the
+// controllers registered with a controller manager, in proportion to their
+// weights. Unlike the single bucket returned by NewGlobal, it prevents a
+// controller of a noisy kind from starving the others. The budget is split
+// statically; a controller's unused share is not lent to others.
+type FairRateLimiter struct {
+	rps float64
+
+	mu     sync.Mutex
+	shares map[string]*fairShare
+}
+
+type fairShare struct {
+	weight  int
+	limiter *rate.Limiter
+}
+
+// NewFair returns a FairRateLimiter that limits the average total requeues per
+// second of all controllers to the supplied rate.
+func NewFair(rps float64) *FairRateLimiter {
+	return &FairRateLimiter{rps: rps, shares: map[string]*fairShare{}}
+}
+
+// For returns a token bucket rate limiter for the named controller, typically
+// passed to New. Its share of the budget is its weight divided by the total
+// weight of all controllers, and is rebalanced each time a controller is
+// registered. Like NewGlobal the bucket size (i.e. allowed burst) is ten
+// times the rate. Weights below one are treated as one. Calling For again with
+// the same name returns the same rate limiter, with the new weight.
+func (f *FairRateLimiter) For(controller string, weight int) *workqueue.TypedBucketRateLimiter[any] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.shares[controller]
+	if !ok {
+		s = &fairShare{}
+		f.shares[controller] = s
+	}
+	s.weight = max(weight, 1)
+	f.rebalance()
+	return &workqueue.TypedBucketRateLimiter[any]{Limiter: s.limiter}
+}
+
+// Share returns the average requeues per second currently allotted to the
+// named controller, or zero if it's not registered.
+func (f *FairRateLimiter) Share(controller string) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok := f.shares[controller]
+	if !ok {
+		return 0
+	}
+	return float64(s.limiter.Limit())
+}
+
+// rebalance the budget among the registered controllers. It must be called
+// with the lock held.
+func (f *FairRateLimiter) rebalance() {
+	total := 0
+	for _, s := range f.shares {
+		total += s.weight
+	}
+	for _, s := range f.shares {
+		rps := f.rps * float64(s.weight) / float64(total)
+		burst := max(int(rps*10), 1)
+		if s.limiter == nil {
+			// New buckets start full.
+			s.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+			continue
+		}
+		s.limiter.SetLimit(rate.Limit(rps))
+		s.limiter.SetBurst(burst)
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimiter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFairRateLimiter(t *testing.T) {
+	type register struct {
+		controller string
+		weight     int
+	}
+
+	cases := map[string]struct {
+		reason   string
+		rps      float64
+		register []register
+		want     map[string]float64
+	}{
+		"Single": {
+			reason:   "A single controller should be allotted the whole budget.",
+			rps:      10,
+			register: []register{{controller: "a", weight: 1}},
+			want:     map[string]float64{"a": 10},
+		},
+		"Weighted": {
+			reason:   "The budget should be split in proportion to the controllers' weights.",
+			rps:      10,
+			register: []register{{controller: "a", weight: 3}, {controller: "b", weight: 1}, {controller: "c", weight: 1}},
+			want:     map[string]float64{"a": 6, "b": 2, "c": 2},
+		},
+		"MinimumWeight": {
+			reason:   "Weights below one should be treated as one.",
+			rps:      10,
+			register: []register{{controller: "a", weight: 0}, {controller: "b", weight: 1}},
+			want:     map[string]float64{"a": 5, "b": 5},
+		},
+		"Reregister": {
+			reason:   "Registering a controller again should update its weight.",
+			rps:      10,
+			register: []register{{controller: "a", weight: 1}, {controller: "b", weight: 1}, {controller: "a", weight: 4}},
+			want:     map[string]float64{"a": 8, "b": 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := NewFair(tc.rps)
+			for _, r := range tc.register {
+				f.For(r.controller, r.weight)
+			}
+			got := map[string]float64{}
+			for c := range tc.want {
+				got[c] = f.Share(c)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nShare(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFairRateLimiterIsolation(t *testing.T) {
+	f := NewFair(1)
+	noisy := f.For("noisy", 1)
+	quiet := f.For("quiet", 1)
+
+	// Exhaust the noisy controller's bucket.
+	for range 10 {
+		noisy.When("noisy")
+	}
+	if d := noisy.When("noisy"); d == 0 {
+		t.Errorf("noisy When(...): want a delay once its bucket is exhausted, got none")
+	}
+	if d := quiet.When("quiet"); d != 0 {
+		t.Errorf("quiet When(...): want no delay, got %v", d)
+	}
+}
@@ -0,0 +1,125 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultHostRetryAfter is how long a HostReconciler waits before retrying a
+// request whose host is busy, unless configured otherwise.
+const DefaultHostRetryAfter = 1 * time.Second
+
+// A HostFn returns the external API host the supplied request will call, and
+// whether it will call one.
+type HostFn func(ctx context.Context, req reconcile.Request) (string, bool)
+
+// A HostReconciler limits how many requests for each external API host an
+// inner, wrapped Reconciler processes concurrently. Some external APIs reject
+// concurrent mutations, so by default it processes one request per host at a
+// time. Requests whose host is busy immediately return RequeueAfter without
+// calling the wrapped Reconciler, rather than occupying a worker while they
+// wait. Requests for which the HostFn returns false are not limited.
+type HostReconciler struct {
+	inner reconcile.Reconciler
+	host  HostFn
+
+	concurrency int
+	limits      map[string]int
+	retryAfter  time.Duration
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// A HostOption configures a HostReconciler.
+type HostOption func(*HostReconciler)
+
+// WithHostConcurrency configures how many requests for each host may be
+// processed concurrently. The default is one. Values below one are treated as
+// one.
+func WithHostConcurrency(n int) HostOption {
+	return func(r *HostReconciler) {
+		r.concurrency = max(n, 1)
+	}
+}
+
+// WithHostLimit configures how many requests for the supplied host may be
+// processed concurrently, overriding the default concurrency. Values below one
+// are treated as one.
+func WithHostLimit(host string, n int) HostOption {
+	return func(r *HostReconciler) {
+		r.limits[host] = max(n, 1)
+	}
+}
+
+// WithHostRetryAfter configures how long to wait before retrying a request
+// whose host is busy. The default is DefaultHostRetryAfter.
+func WithHostRetryAfter(d time.Duration) HostOption {
+	return func(r *HostReconciler) {
+		r.retryAfter = d
+	}
+}
+
+// NewHost wraps the supplied Reconciler, ensuring it processes no more
+// concurrent requests for each host, as returned by the supplied HostFn, than
+// allowed.
+func NewHost(r reconcile.Reconciler, fn HostFn, o ...HostOption) *HostReconciler {
+	hr := &HostReconciler{
+		inner:       r,
+		host:        fn,
+		concurrency: 1,
+		limits:      map[string]int{},
+		retryAfter:  DefaultHostRetryAfter,
+		slots:       map[string]chan struct{}{},
+	}
+	for _, ho := range o {
+		ho(hr)
+	}
+	return hr
+}
+
+// Reconcile the supplied request, subject to the concurrency limit of its
+// host.
+func (r *HostReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	host, ok := r.host(ctx, req)
+	if !ok {
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	s := r.slotsFor(host)
+	select {
+	case s <- struct{}{}:
+	default:
+		return reconcile.Result{RequeueAfter: r.retryAfter}, nil
+	}
+	defer func() { <-s }()
+
+	return r.inner.Reconcile(ctx, req)
+}
+
+// InFlight returns how many requests for the supplied host are currently
+// being processed.
+func (r *HostReconciler) InFlight(host string) int {
+	return len(r.slotsFor(host))
+}
+
+// slotsFor returns the semaphore of the supplied host, creating it if
+// necessary.
+func (r *HostReconciler) slotsFor(host string) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.slots[host]
+	if !ok {
+		n, ok := r.limits[host]
+		if !ok {
+			n = r.concurrency
+		}
+		s = make(chan struct{}, n)
+		r.slots[host] = s
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestHostReconciler(t *testing.T) {
+	// Requests are for resources in a namespace named after the host they
+	// call. Requests without a namespace don't call a host.
+	host := func(_ context.Context, req reconcile.Request) (string, bool) {
+		return req.Namespace, req.Namespace != ""
+	}
+	request := func(host, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: host, Name: name}}
+	}
+
+	type want struct {
+		res reconcile.Result
+		err error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		o        []HostOption
+		inFlight []reconcile.Request
+		req      reconcile.Request
+		want     want
+	}{
+		"Idle": {
+			reason: "Requests for an idle host should be passed to the inner Reconciler.",
+			req:    request("busy.example.org", "a"),
+			want:   want{res: reconcile.Result{Requeue: true}},
+		},
+		"Busy": {
+			reason:   "Requests for a busy host should be requeued without calling the inner Reconciler.",
+			inFlight: []reconcile.Request{request("busy.example.org", "a")},
+			req:      request("busy.example.org", "b"),
+			want:     want{res: reconcile.Result{RequeueAfter: DefaultHostRetryAfter}},
+		},
+		"BusyRetryAfter": {
+			reason:   "Requests for a busy host should be requeued after the configured duration.",
+			o:        []HostOption{WithHostRetryAfter(5 * time.Second)},
+			inFlight: []reconcile.Request{request("busy.example.org", "a")},
+			req:      request("busy.example.org", "b"),
+			want:     want{res: reconcile.Result{RequeueAfter: 5 * time.Second}},
+		},
+		"OtherHost": {
+			reason:   "Requests should not be limited by other hosts.",
+			inFlight: []reconcile.Request{request("busy.example.org", "a")},
+			req:      request("idle.example.org", "b"),
+			want:     want{res: reconcile.Result{Requeue: true}},
+		},
+		"NoHost": {
+			reason:   "Requests that don't call a host should not be limited.",
+			inFlight: []reconcile.Request{request("", "a")},
+			req:      request("", "b"),
+			want:     want{res: reconcile.Result{Requeue: true}},
+		},
+		"Concurrency": {
+			reason:   "Requests for a host should be passed to the inner Reconciler while it has fewer than the configured number in flight.",
+			o:        []HostOption{WithHostConcurrency(2)},
+			inFlight: []reconcile.Request{request("busy.example.org", "a")},
+			req:      request("busy.example.org", "b"),
+			want:     want{res: reconcile.Result{Requeue: true}},
+		},
+		"HostLimit": {
+			reason:   "A host's configured limit should override the default concurrency.",
+			o:        []HostOption{WithHostConcurrency(2), WithHostLimit("busy.example.org", 1)},
+			inFlight: []reconcile.Request{request("busy.example.org", "a")},
+			req:      request("busy.example.org", "b"),
+			want:     want{res: reconcile.Result{RequeueAfter: DefaultHostRetryAfter}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			entered := make(chan struct{})
+			release := make(chan struct{})
+			blocking := map[reconcile.Request]bool{}
+			for _, req := range tc.inFlight {
+				blocking[req] = true
+			}
+
+			r := NewHost(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+				if blocking[req] {
+					entered <- struct{}{}
+					<-release
+				}
+				return reconcile.Result{Requeue: true}, nil
+			}), host, tc.o...)
+
+			done := make(chan struct{})
+			for _, req := range tc.inFlight {
+				go func() {
+					r.Reconcile(context.Background(), req)
+					done <- struct{}{}
+				}()
+				<-entered
+			}
+
+			got, err := r.Reconcile(context.Background(), tc.req)
+
+			close(release)
+			for range tc.inFlight {
+				<-done
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.res, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got result:\n%s", tc.reason, diff)
+			}
+			if n := r.InFlight(tc.req.Namespace); n != 0 {
+				t.Errorf("\n%s\nr.InFlight(...): want no requests in flight once processed, got %d", tc.reason, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package ratelimiter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ItemState is the state of an item tracked by a rate limiter.
+type ItemState struct {
+	// Item is the tracked item, for example a request or, for a
+	// CircuitBreaker, the key shared by a group of items.
+	Item string `json:"item"`
+
+	// Limited is true if the rate limiter is currently delaying the item.
+	Limited bool `json:"limited,omitempty"`
+
+	// NextAllowed is when the rate limiter will next allow the item, if it
+	// knows.
+	NextAllowed *time.Time `json:"nextAllowed,omitempty"`
+
+	// Attempts is the number of failed attempts the rate limiter counted for
+	// the item, if it counts them.
+	Attempts int `json:"attempts,omitempty"`
+
+	// LastAttempt is when the item was last attempted, if the rate limiter
+	// knows.
+	LastAttempt *time.Time `json:"lastAttempt,omitempty"`
+}
+
+// An Inspector reports the state of the items tracked by a rate limiter, so
+// that operators can tell why a resource isn't being reconciled.
+type Inspector interface {
+	// Inspect returns the state of the tracked items, ordered by item.
+	Inspect() []ItemState
+}
+
+// An InspectorFn is a function that satisfies Inspector.
+type InspectorFn func() []ItemState
+
+// Inspect returns the state of the tracked items.
+func (fn InspectorFn) Inspect() []ItemState {
+	return fn()
+}
+
+// InspectFailures returns an Inspector that reports the attempts tracked by
+// the supplied rate limiter, for example one returned by NewGlobalExponential.
+func InspectFailures[T comparable](t FailureTracker[T]) InspectorFn {
+	return func() []ItemState {
+		f := t.Failures()
+		out := make([]ItemState, 0, len(f))
+		for item, fr := range f {
+			last := fr.LastAttempt
+			out = append(out, ItemState{Item: fmt.Sprint(item), Attempts: fr.Attempts, LastAttempt: &last})
+		}
+		return sortItems(out)
+	}
+}
+
+// Inspect returns the requests the Reconciler is currently delaying, and when
+// they will be allowed.
+func (r *Reconciler) Inspect() []ItemState {
+	r.limitedL.RLock()
+	defer r.limitedL.RUnlock()
+
+	out := make([]ItemState, 0, len(r.limited))
+	for item, until := range r.limited {
+		out = append(out, ItemState{Item: strings.TrimPrefix(item, r.name), Limited: true, NextAllowed: &until})
+	}
+	return sortItems(out)
+}
+
+// Inspect returns the items the RetryAfterRateLimiter is delaying per their
+// hints, and when they will be allowed.
+func (l *RetryAfterRateLimiter[T]) Inspect() []ItemState {
+	l.hintsL.Lock()
+	defer l.hintsL.Unlock()
+
+	now := l.now()
+	out := make([]ItemState, 0, len(l.hints))
+	for item, until := range l.hints {
+		if !now.Before(until) {
+			continue
+		}
+		out = append(out, ItemState{Item: fmt.Sprint(item), Limited: true, NextAllowed: &until})
+	}
+	return sortItems(out)
+}
+
+// Inspect returns the keys for which the CircuitBreaker counted failures, and
+// when their circuit closes if it's open.
+func (b *CircuitBreaker[T]) Inspect() []ItemState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	out := make([]ItemState, 0, len(b.circuits))
+	for k, c := range b.circuits {
+		s := ItemState{Item: k, Attempts: c.failures}
+		if now.Before(c.openUntil) {
+			until := c.openUntil
+			s.Limited, s.NextAllowed = true, &until
+		}
+		out = append(out, s)
+	}
+	return sortItems(out)
+}
+
+func sortItems(s []ItemState) []ItemState {
+	sort.Slice(s, func(i, j int) bool { return s[i].Item < s[j].Item })
+	return s
+}
+
+// NewInspectHandler returns an HTTP handler that serves the state of the
+// items tracked by the supplied rate limiters as JSON, by rate limiter name.
+// Only items containing the value of the optional 'item' query parameter are
+// served. It is intended to be served by the controller manager's metrics
+// server, for example:
+//
+//	mgr.AddMetricsServerExtraHandler("/debug/ratelimiters", ratelimiter.NewInspectHandler(map[string]ratelimiter.Inspector{
+//		"global": r,
+//	}))
+func NewInspectHandler(i map[string]Inspector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		filter := req.URL.Query().Get("item")
+		out := make(map[string][]ItemState, len(i))
+		for name, in := range i {
+			states := []ItemState{}
+			for _, s := range in.Inspect() {
+				if strings.Contains(s.Item, filter) {
+					states = append(states, s)
+				}
+			}
+			out[name] = states
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	})
+}
+
+var (
+	_ Inspector = &Reconciler{}
+	_ Inspector = &RetryAfterRateLimiter[any]{}
+	_ Inspector = &CircuitBreaker[any]{}
+	_ Inspector = InspectorFn(nil)
+)
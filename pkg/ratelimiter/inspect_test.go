@@ -0,0 +1,105 @@
+package ratelimiter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	internal_workqueue "github.com/krateoplatformops/provider-runtime/pkg/workqueue"
+)
+
+func TestInspect(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Minute)
+
+	cases := map[string]struct {
+		reason string
+		i      func() Inspector
+		opts   []cmp.Option
+		want   []ItemState
+	}{
+		"Reconciler": {
+			reason: "A Reconciler should report the requests it's delaying.",
+			i: func() Inspector {
+				r := New("test", nil, &predictableRateLimiter{d: time.Minute})
+				r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}})
+				r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "a"}})
+				return r
+			},
+			// The Reconciler's delays are relative to the current time.
+			opts: []cmp.Option{cmpopts.IgnoreFields(ItemState{}, "NextAllowed")},
+			want: []ItemState{{Item: "/a", Limited: true}, {Item: "ns/b", Limited: true}},
+		},
+		"RetryAfter": {
+			reason: "A RetryAfterRateLimiter should report the items it's delaying per their hints.",
+			i: func() Inspector {
+				l := NewRetryAfter[any](&predictableRateLimiter{})
+				l.now = func() time.Time { return now }
+				l.Hint("a", time.Minute)
+				l.Hint("expired", 0)
+				return l
+			},
+			want: []ItemState{{Item: "a", Limited: true, NextAllowed: &later}},
+		},
+		"CircuitBreaker": {
+			reason: "A CircuitBreaker should report the failures it counted by key, and when open circuits close.",
+			i: func() Inspector {
+				b := NewCircuitBreaker[any](&predictableRateLimiter{}, func(item any) (string, bool) { return item.(string), true }, 2, time.Minute)
+				b.now = func() time.Time { return now }
+				b.When("closed")
+				b.When("open")
+				b.When("open")
+				return b
+			},
+			want: []ItemState{
+				{Item: "closed", Attempts: 1},
+				{Item: "open", Attempts: 2, Limited: true, NextAllowed: &later},
+			},
+		},
+		"Failures": {
+			reason: "InspectFailures should report the attempts tracked by a rate limiter.",
+			i: func() Inspector {
+				l := internal_workqueue.NewExponentialTimedFailureRateLimiter[any](time.Second, time.Minute).(*internal_workqueue.TypedItemExponentialTimedFailureRateLimiter[any])
+				l.Restore(map[any]internal_workqueue.FailureRequest{"a": {Attempts: 3, LastAttempt: now}})
+				return InspectFailures[any](l)
+			},
+			want: []ItemState{{Item: "a", Attempts: 3, LastAttempt: &now}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.i().Inspect()
+			if diff := cmp.Diff(tc.want, got, tc.opts...); diff != "" {
+				t.Errorf("\n%s\nInspect(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInspectHandler(t *testing.T) {
+	h := NewInspectHandler(map[string]Inspector{
+		"test": InspectorFn(func() []ItemState {
+			return []ItemState{{Item: "ns/a", Attempts: 1}, {Item: "ns/b", Attempts: 2}}
+		}),
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/debug/ratelimiters?item=b", nil))
+
+	got := map[string][]ItemState{}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("Decode(...): %v", err)
+	}
+	want := map[string][]ItemState{"test": {{Item: "ns/b", Attempts: 2}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nServeHTTP(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,85 @@
+package ratelimiter
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ workqueue.TypedRateLimiter[reconcile.Request] = &NamespaceRateLimiter{}
+
+// A NamespaceRateLimiter limits the average requeues per second of requests
+// in each namespace, so that the churn of resources in one namespace (e.g.
+// one tenant's) can't consume the whole reconcile budget of a provider. Each
+// namespace has its own token bucket. Requests for cluster scoped resources
+// share the bucket of the empty namespace.
+type NamespaceRateLimiter struct {
+	rps    float64
+	limits map[string]float64
+
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+// A NamespaceOption configures a NamespaceRateLimiter.
+type NamespaceOption func(*NamespaceRateLimiter)
+
+// WithNamespaceLimit configures the average requeues per second allowed for
+// requests in the supplied namespace, overriding the default rate.
+func WithNamespaceLimit(namespace string, rps float64) NamespaceOption {
+	return func(l *NamespaceRateLimiter) {
+		l.limits[namespace] = rps
+	}
+}
+
+// NewNamespaceRateLimiter returns a NamespaceRateLimiter that allows the
+// supplied average requeues per second in each namespace, unless configured
+// otherwise for a namespace. Like NewGlobal the bucket size (i.e. allowed
+// burst) of each namespace is ten times its rate. It is typically passed to
+// a Reconciler using WithRequestRateLimiter.
+func NewNamespaceRateLimiter(rps float64, o ...NamespaceOption) *NamespaceRateLimiter {
+	l := &NamespaceRateLimiter{rps: rps, limits: map[string]float64{}, buckets: map[string]*rate.Limiter{}}
+	for _, fn := range o {
+		fn(l)
+	}
+	return l
+}
+
+// When returns how long the supplied request must wait before it is processed.
+func (l *NamespaceRateLimiter) When(req reconcile.Request) time.Duration {
+	return l.bucket(req.Namespace).Reserve().Delay()
+}
+
+// Forget does nothing. A NamespaceRateLimiter doesn't track requests.
+func (l *NamespaceRateLimiter) Forget(_ reconcile.Request) {}
+
+// NumRequeues always returns zero. A NamespaceRateLimiter doesn't track
+// requests.
+func (l *NamespaceRateLimiter) NumRequeues(_ reconcile.Request) int { return 0 }
+
+// Limit returns the average requeues per second allowed for requests in the
+// supplied namespace.
+func (l *NamespaceRateLimiter) Limit(namespace string) float64 {
+	if rps, ok := l.limits[namespace]; ok {
+		return rps
+	}
+	return l.rps
+}
+
+// bucket returns the token bucket of the supplied namespace, creating it if
+// necessary. New buckets start full.
+func (l *NamespaceRateLimiter) bucket(namespace string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[namespace]
+	if !ok {
+		rps := l.Limit(namespace)
+		b = rate.NewLimiter(rate.Limit(rps), max(int(rps*10), 1))
+		l.buckets[namespace] = b
+	}
+	return b
+}
@@ -0,0 +1,39 @@
+package ratelimiter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNamespaceRateLimiterLimit(t *testing.T) {
+	l := NewNamespaceRateLimiter(1, WithNamespaceLimit("big", 10), WithNamespaceLimit("", 5))
+
+	want := map[string]float64{"big": 10, "small": 1, "": 5}
+	got := map[string]float64{}
+	for ns := range want {
+		got[ns] = l.Limit(ns)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nLimit(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestNamespaceRateLimiterIsolation(t *testing.T) {
+	l := NewNamespaceRateLimiter(1)
+	noisy := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "noisy", Name: "a"}}
+	quiet := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "quiet", Name: "a"}}
+
+	// Exhaust the noisy namespace's bucket.
+	for range 10 {
+		l.When(noisy)
+	}
+	if d := l.When(noisy); d == 0 {
+		t.Errorf("noisy When(...): want a delay once its bucket is exhausted, got none")
+	}
+	if d := l.When(quiet); d != 0 {
+		t.Errorf("quiet When(...): want no delay, got %v", d)
+	}
+}
@@ -0,0 +1,229 @@
+package ratelimiter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	internal_workqueue "github.com/krateoplatformops/provider-runtime/pkg/workqueue"
+)
+
+// Error strings.
+const (
+	errGetFailures    = "cannot get rate limiter failures ConfigMap"
+	errCreateFailures = "cannot create rate limiter failures ConfigMap"
+	errUpdateFailures = "cannot update rate limiter failures ConfigMap"
+	errDecodeFailures = "cannot decode rate limiter failures"
+	errEncodeFailures = "cannot encode rate limiter failures"
+)
+
+// FailuresConfigMapKey is the key of the ConfigMap data in which a
+// ConfigMapFailureStore stores failures.
+const FailuresConfigMapKey = "failures"
+
+const (
+	defaultPersistInterval = 30 * time.Second
+	persistTimeout         = 10 * time.Second
+)
+
+// A FailureStore persists the failures tracked by a rate limiter, by item key.
+type FailureStore interface {
+	// Load the persisted failures. It returns no failures and no error if
+	// none have been persisted.
+	Load(ctx context.Context) (map[string]internal_workqueue.FailureRequest, error)
+
+	// Save the supplied failures, replacing any persisted failures.
+	Save(ctx context.Context, f map[string]internal_workqueue.FailureRequest) error
+}
+
+// A ConfigMapFailureStore persists failures as JSON in a ConfigMap. ConfigMaps
+// are limited to 1MiB, which is enough for the failures of several thousand
+// items.
+type ConfigMapFailureStore struct {
+	client client.Client
+	cm     types.NamespacedName
+}
+
+// NewConfigMapFailureStore returns a FailureStore that persists failures in
+// the named ConfigMap, using the supplied client. The ConfigMap is created if
+// it doesn't exist.
+func NewConfigMapFailureStore(c client.Client, cm types.NamespacedName) *ConfigMapFailureStore {
+	return &ConfigMapFailureStore{client: c, cm: cm}
+}
+
+// Load the failures persisted in the ConfigMap.
+func (s *ConfigMapFailureStore) Load(ctx context.Context) (map[string]internal_workqueue.FailureRequest, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, s.cm, cm); err != nil {
+		return nil, errors.Wrap(client.IgnoreNotFound(err), errGetFailures)
+	}
+	v, ok := cm.Data[FailuresConfigMapKey]
+	if !ok {
+		return nil, nil
+	}
+	f := map[string]internal_workqueue.FailureRequest{}
+	return f, errors.Wrap(json.Unmarshal([]byte(v), &f), errDecodeFailures)
+}
+
+// Save the supplied failures in the ConfigMap.
+func (s *ConfigMapFailureStore) Save(ctx context.Context, f map[string]internal_workqueue.FailureRequest) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return errors.Wrap(err, errEncodeFailures)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = s.client.Get(ctx, s.cm, cm)
+	if kerrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.cm.Namespace, Name: s.cm.Name},
+			Data:       map[string]string{FailuresConfigMapKey: string(b)},
+		}
+		return errors.Wrap(s.client.Create(ctx, cm), errCreateFailures)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetFailures)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[FailuresConfigMapKey] = string(b)
+	return errors.Wrap(s.client.Update(ctx, cm), errUpdateFailures)
+}
+
+// A FailureTracker is a rate limiter that tracks failures by item, and whose
+// failures can be restored. The rate limiter returned by NewGlobalExponential
+// is a FailureTracker.
+type FailureTracker[T comparable] interface {
+	Failures() map[T]internal_workqueue.FailureRequest
+	Restore(f map[T]internal_workqueue.FailureRequest)
+}
+
+// An ItemCodec converts the items tracked by a FailureTracker to and from the
+// keys by which their failures are persisted. Items that can't be converted
+// are not persisted.
+type ItemCodec[T comparable] struct {
+	Key  func(item T) (string, bool)
+	Item func(key string) (T, bool)
+}
+
+// StringItems is an ItemCodec for rate limiters whose items are strings, like
+// the rate limiters of a Reconciler.
+var StringItems = ItemCodec[any]{
+	Key: func(item any) (string, bool) {
+		s, ok := item.(string)
+		return s, ok
+	},
+	Item: func(key string) (any, bool) { return key, true },
+}
+
+// A PersistentBackoff periodically persists the failures tracked by a rate
+// limiter, and restores them when it starts, so that restarting a provider
+// doesn't reset the backoff of failing items. Otherwise every item would be
+// retried immediately after a restart, hammering a failing external system.
+type PersistentBackoff[T comparable] struct {
+	tracker  FailureTracker[T]
+	store    FailureStore
+	codec    ItemCodec[T]
+	interval time.Duration
+	log      logging.Logger
+}
+
+// A PersistentBackoffOption configures a PersistentBackoff.
+type PersistentBackoffOption func(*persistentBackoffOptions)
+
+type persistentBackoffOptions struct {
+	interval time.Duration
+	log      logging.Logger
+}
+
+// WithPersistInterval configures how often failures are persisted. The
+// default is every 30 seconds.
+func WithPersistInterval(d time.Duration) PersistentBackoffOption {
+	return func(o *persistentBackoffOptions) {
+		o.interval = d
+	}
+}
+
+// WithPersistLogger configures the logger used to report failures that can't
+// be loaded or saved.
+func WithPersistLogger(l logging.Logger) PersistentBackoffOption {
+	return func(o *persistentBackoffOptions) {
+		o.log = l
+	}
+}
+
+// NewPersistentBackoff returns a PersistentBackoff that persists the failures
+// tracked by the supplied rate limiter in the supplied store, converting items
+// to keys using the supplied codec.
+func NewPersistentBackoff[T comparable](t FailureTracker[T], s FailureStore, c ItemCodec[T], o ...PersistentBackoffOption) *PersistentBackoff[T] {
+	opts := &persistentBackoffOptions{interval: defaultPersistInterval, log: logging.NewNopLogger()}
+	for _, fn := range o {
+		fn(opts)
+	}
+	return &PersistentBackoff[T]{tracker: t, store: s, codec: c, interval: opts.interval, log: opts.log}
+}
+
+// Start restores persisted failures, then persists failures periodically
+// until the supplied context is done, and once more before returning. It
+// satisfies manager.Runnable, so a PersistentBackoff may be added to a
+// manager.
+func (p *PersistentBackoff[T]) Start(ctx context.Context) error {
+	p.restore(ctx)
+	wait.UntilWithContext(ctx, p.save, p.interval)
+
+	// The supplied context is done, but we want one last chance to save.
+	sctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+	defer cancel()
+	p.save(sctx)
+	return nil
+}
+
+// NeedLeaderElection returns true. Only the leader reconciles, and thus only
+// it has failures worth persisting.
+func (p *PersistentBackoff[T]) NeedLeaderElection() bool { return true }
+
+// restore the persisted failures to the rate limiter.
+func (p *PersistentBackoff[T]) restore(ctx context.Context) {
+	f, err := p.store.Load(ctx)
+	if err != nil {
+		p.log.Debug("Cannot load rate limiter failures", "error", err)
+		return
+	}
+	restored := make(map[T]internal_workqueue.FailureRequest, len(f))
+	for k, fr := range f {
+		if item, ok := p.codec.Item(k); ok {
+			restored[item] = fr
+		}
+	}
+	p.tracker.Restore(restored)
+}
+
+func (p *PersistentBackoff[T]) save(ctx context.Context) {
+	f := p.tracker.Failures()
+	persisted := make(map[string]internal_workqueue.FailureRequest, len(f))
+	for item, fr := range f {
+		if k, ok := p.codec.Key(item); ok {
+			persisted[k] = fr
+		}
+	}
+	if err := p.store.Save(ctx, persisted); err != nil {
+		p.log.Debug("Cannot save rate limiter failures", "error", err)
+	}
+}
+
+var (
+	_ manager.Runnable               = &PersistentBackoff[any]{}
+	_ manager.LeaderElectionRunnable = &PersistentBackoff[any]{}
+	_ FailureTracker[any]            = &internal_workqueue.TypedItemExponentialTimedFailureRateLimiter[any]{}
+)
@@ -0,0 +1,127 @@
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	internal_workqueue "github.com/krateoplatformops/provider-runtime/pkg/workqueue"
+)
+
+func TestConfigMapFailureStore(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "coolns", Name: "backoff"}
+	last := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	failures := map[string]internal_workqueue.FailureRequest{
+		"a": {Attempts: 3, LastAttempt: last},
+		"b": {Attempts: 1, LastAttempt: last},
+	}
+
+	cases := map[string]struct {
+		reason string
+		objs   []corev1.ConfigMap
+		save   map[string]internal_workqueue.FailureRequest
+		want   map[string]internal_workqueue.FailureRequest
+	}{
+		"NotFound": {
+			reason: "Loading from a ConfigMap that doesn't exist should return no failures.",
+		},
+		"Create": {
+			reason: "Saving to a ConfigMap that doesn't exist should create it.",
+			save:   failures,
+			want:   failures,
+		},
+		"Update": {
+			reason: "Saving to a ConfigMap that exists should replace its failures, and preserve its other data.",
+			objs: []corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: nn.Namespace, Name: nn.Name},
+				Data:       map[string]string{FailuresConfigMapKey: `{"c":{"attempts":1}}`, "other": "data"},
+			}},
+			save: failures,
+			want: failures,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := clientfake.NewClientBuilder()
+			for i := range tc.objs {
+				b = b.WithObjects(&tc.objs[i])
+			}
+			c := b.Build()
+			s := NewConfigMapFailureStore(c, nn)
+
+			if tc.save != nil {
+				if err := s.Save(context.Background(), tc.save); err != nil {
+					t.Fatalf("\n%s\nSave(...): %v", tc.reason, err)
+				}
+			}
+			got, err := s.Load(context.Background())
+			if err != nil {
+				t.Fatalf("\n%s\nLoad(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			for _, o := range tc.objs {
+				cm := &corev1.ConfigMap{}
+				if err := c.Get(context.Background(), nn, cm); err != nil {
+					t.Fatalf("\n%s\nGet(...): %v", tc.reason, err)
+				}
+				for k, v := range o.Data {
+					if k != FailuresConfigMapKey && cm.Data[k] != v {
+						t.Errorf("\n%s\nData[%q]: want %q, got %q", tc.reason, k, v, cm.Data[k])
+					}
+				}
+			}
+		})
+	}
+}
+
+type memoryFailureStore struct {
+	failures map[string]internal_workqueue.FailureRequest
+}
+
+func (s *memoryFailureStore) Load(_ context.Context) (map[string]internal_workqueue.FailureRequest, error) {
+	return s.failures, nil
+}
+
+func (s *memoryFailureStore) Save(_ context.Context, f map[string]internal_workqueue.FailureRequest) error {
+	s.failures = f
+	return nil
+}
+
+func TestPersistentBackoff(t *testing.T) {
+	store := &memoryFailureStore{}
+
+	// Fail an item a few times, then stop, persisting its failures.
+	before := NewGlobalExponential(time.Second, time.Hour)
+	for range 3 {
+		before.When("cool")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewPersistentBackoff(before.(FailureTracker[any]), store, StringItems).Start(ctx); err != nil {
+		t.Fatalf("Start(...): %v", err)
+	}
+
+	// After a restart the item's backoff should continue where it left off.
+	after := NewGlobalExponential(time.Second, time.Hour)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := NewPersistentBackoff(after.(FailureTracker[any]), store, StringItems).Start(ctx); err != nil {
+		t.Fatalf("Start(...): %v", err)
+	}
+	if got := after.NumRequeues("cool"); got != 3 {
+		t.Errorf("NumRequeues(...): want 3, got %d", got)
+	}
+	if got := after.When("cool"); got != 8*time.Second {
+		t.Errorf("When(...): want 8s, got %v", got)
+	}
+}
@@ -17,27 +17,75 @@ type Reconciler struct {
 	inner reconcile.Reconciler
 	limit workqueue.TypedRateLimiter[any]
 
-	limited  map[string]struct{}
+	unlimited UnlimitedFn
+	requests  workqueue.TypedRateLimiter[reconcile.Request]
+
+	limited  map[string]time.Time
 	limitedL sync.RWMutex
 }
 
+// An UnlimitedFn returns true if the supplied request should not be rate
+// limited.
+type UnlimitedFn func(ctx context.Context, req reconcile.Request) bool
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithUnlimited configures a Reconciler to pass requests for which the
+// supplied function returns true to the wrapped Reconciler immediately,
+// without rate limiting them.
+func WithUnlimited(fn UnlimitedFn) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.unlimited = fn
+	}
+}
+
+// WithRequestRateLimiter configures a Reconciler to also subject requests to
+// the supplied rate limiter, which limits them by request rather than by
+// opaque item, for example a NamespaceRateLimiter. Requests are delayed by the
+// longer of the two rate limiters' delays.
+func WithRequestRateLimiter(l workqueue.TypedRateLimiter[reconcile.Request]) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.requests = l
+	}
+}
+
 // New wraps the supplied Reconciler, ensuring requests are passed to
 // it no more frequently than the supplied RateLimiter allows. Multiple uniquely
 // named Reconcilers can share the same RateLimiter.
-func New(name string, r reconcile.Reconciler, l workqueue.TypedRateLimiter[any]) *Reconciler {
-	return &Reconciler{name: name, inner: r, limit: l, limited: make(map[string]struct{})}
+func New(name string, r reconcile.Reconciler, l workqueue.TypedRateLimiter[any], o ...ReconcilerOption) *Reconciler {
+	rl := &Reconciler{name: name, inner: r, limit: l, limited: make(map[string]time.Time)}
+	for _, ro := range o {
+		ro(rl)
+	}
+	return rl
 }
 
 // Reconcile the supplied request subject to rate limiting.
 func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	item := r.name + req.String()
+	if r.unlimited != nil && r.unlimited(ctx, req) {
+		r.limitedL.Lock()
+		delete(r.limited, item)
+		r.limitedL.Unlock()
+		r.forget(item, req)
+		return r.inner.Reconcile(ctx, req)
+	}
 	if d := r.when(req); d > 0 {
 		return reconcile.Result{RequeueAfter: d}, nil
 	}
-	r.limit.Forget(item)
+	r.forget(item, req)
 	return r.inner.Reconcile(ctx, req)
 }
 
+// forget the supplied request in the upstream rate limiters.
+func (r *Reconciler) forget(item string, req reconcile.Request) {
+	r.limit.Forget(item)
+	if r.requests != nil {
+		r.requests.Forget(req)
+	}
+}
+
 // when adapts the upstream rate limiter's 'When' method such that rate limited
 // requests can call it again when they return and will be allowed to proceed
 // immediately without being subject to further rate limiting. It is optimised
@@ -60,12 +108,15 @@ func (r *Reconciler) when(req reconcile.Request) time.Duration {
 	}
 
 	d := r.limit.When(item)
+	if r.requests != nil {
+		d = max(d, r.requests.When(req))
+	}
 
 	// Record that this request was rate limited so that we can let it
 	// through immediately when it requeues after the supplied duration.
 	if d != 0 {
 		r.limitedL.Lock()
-		r.limited[item] = struct{}{}
+		r.limited[item] = time.Now().Add(d)
 		r.limitedL.Unlock()
 	}
 
@@ -56,6 +56,28 @@ func TestReconcile(t *testing.T) {
 				err: nil,
 			},
 		},
+		"Unlimited": {
+			reason: "Requests that should not be rate limited should be passed to the inner Reconciler immediately.",
+			r: New("test",
+				reconcile.Func(func(c context.Context, r reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{Requeue: true}, nil
+				}),
+				&predictableRateLimiter{d: 8 * time.Second},
+				WithUnlimited(func(_ context.Context, _ reconcile.Request) bool { return true })),
+			want: want{
+				res: reconcile.Result{Requeue: true},
+				err: nil,
+			},
+		},
+		"Limited": {
+			reason: "Requests that should be rate limited should be requeued after the duration specified by the RateLimiter.",
+			r: New("test", nil, &predictableRateLimiter{d: 8 * time.Second},
+				WithUnlimited(func(_ context.Context, _ reconcile.Request) bool { return false })),
+			want: want{
+				res: reconcile.Result{RequeueAfter: 8 * time.Second},
+				err: nil,
+			},
+		},
 		"Returning": {
 			reason: "Returning requests that were previously rate limited should be allowed through without further rate limiting.",
 			r: func() reconcile.Reconciler {
@@ -93,6 +115,22 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileRequestRateLimiter(t *testing.T) {
+	l := NewNamespaceRateLimiter(0.1)
+
+	// Exhaust the namespace's bucket.
+	l.When(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "tenant", Name: "other"}})
+
+	r := New("test", nil, &predictableRateLimiter{}, WithRequestRateLimiter(l))
+	got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "tenant", Name: "limited"}})
+	if err != nil {
+		t.Errorf("r.Reconcile(...): %v", err)
+	}
+	if got.RequeueAfter == 0 {
+		t.Errorf("r.Reconcile(...): want requests rate limited by the request rate limiter to be requeued after a delay, got none")
+	}
+}
+
 func EquateErrors() cmp.Option {
 	return cmp.Comparer(func(a, b error) bool {
 		if a == nil || b == nil {
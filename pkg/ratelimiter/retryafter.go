@@ -0,0 +1,151 @@
+package ratelimiter
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// A RetryAfterRateLimiter wraps a rate limiter, honoring the backoff
+// requested by external systems, for example using an HTTP Retry-After
+// header. Providers feed these requests to it using Hint.
+type RetryAfterRateLimiter[T comparable] struct {
+	workqueue.TypedRateLimiter[T]
+
+	now func() time.Time
+
+	hintsL sync.Mutex
+	hints  map[T]time.Time
+}
+
+// NewRetryAfter returns a RetryAfterRateLimiter that wraps the supplied rate
+// limiter.
+func NewRetryAfter[T comparable](l workqueue.TypedRateLimiter[T]) *RetryAfterRateLimiter[T] {
+	return &RetryAfterRateLimiter[T]{TypedRateLimiter: l, now: time.Now, hints: map[T]time.Time{}}
+}
+
+// Hint that the supplied item should not be retried until the supplied
+// duration has passed. Later hints replace earlier ones.
+func (l *RetryAfterRateLimiter[T]) Hint(item T, d time.Duration) {
+	l.hintsL.Lock()
+	defer l.hintsL.Unlock()
+	l.hints[item] = l.now().Add(d)
+}
+
+// When returns the longer of the delay imposed by the wrapped rate limiter and
+// the time remaining until the supplied item may be retried per its hint, if
+// any.
+func (l *RetryAfterRateLimiter[T]) When(item T) time.Duration {
+	d := l.TypedRateLimiter.When(item)
+
+	l.hintsL.Lock()
+	defer l.hintsL.Unlock()
+	until, ok := l.hints[item]
+	if !ok {
+		return d
+	}
+	remaining := until.Sub(l.now())
+	if remaining <= 0 {
+		delete(l.hints, item)
+		return d
+	}
+	return max(d, remaining)
+}
+
+// Forget the supplied item, including its hint.
+func (l *RetryAfterRateLimiter[T]) Forget(item T) {
+	l.TypedRateLimiter.Forget(item)
+
+	l.hintsL.Lock()
+	defer l.hintsL.Unlock()
+	delete(l.hints, item)
+}
+
+// A RetryAfterError is an error that suggests how long to wait before
+// retrying the operation that returned it.
+type RetryAfterError interface {
+	error
+
+	// RetryAfter returns how long to wait before retrying.
+	RetryAfter() time.Duration
+}
+
+type retryAfterError struct {
+	error
+	after time.Duration
+}
+
+func (e retryAfterError) RetryAfter() time.Duration { return e.after }
+func (e retryAfterError) Unwrap() error             { return e.error }
+
+// WithRetryAfter returns an error that wraps the supplied error, suggesting
+// that the operation that returned it should be retried after the supplied
+// duration. External clients use it to report that they were throttled, for
+// example by an SDK's throttling error.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return retryAfterError{error: err, after: d}
+}
+
+// RetryAfter returns how long to wait before retrying the operation that
+// returned the supplied error, and whether the error suggested a delay at all.
+// It recognizes errors that satisfy RetryAfterError anywhere in the supplied
+// error's chain, and Kubernetes API errors that suggest a client delay. It is
+// intended to be used in a reconciler's error path, to requeue after the
+// suggested delay.
+func RetryAfter(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var ra RetryAfterError
+	if errors.As(err, &ra) {
+		return ra.RetryAfter(), true
+	}
+	if s, ok := kerrors.SuggestsClientDelay(err); ok {
+		return time.Duration(s) * time.Second, true
+	}
+	return 0, false
+}
+
+// ParseRetryAfter parses the value of an HTTP Retry-After header, which may be
+// either a number of seconds or an HTTP date. It returns false if the value
+// can't be parsed. Dates in the past produce a zero duration.
+func ParseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	return max(t.Sub(now), 0), true
+}
+
+// RetryAfterFromResponse returns the backoff requested by the supplied HTTP
+// response, and whether it requested one. Only 429 Too Many Requests and 503
+// Service Unavailable responses with a valid Retry-After header request a
+// backoff.
+func RetryAfterFromResponse(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	return ParseRetryAfter(v, time.Now())
+}
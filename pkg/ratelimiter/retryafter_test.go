@@ -0,0 +1,218 @@
+package ratelimiter
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+func TestRetryAfter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		d  time.Duration
+		ok bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"NilError": {
+			reason: "A nil error should not suggest a delay.",
+		},
+		"PlainError": {
+			reason: "An error that doesn't suggest a delay should not suggest a delay.",
+			err:    errBoom,
+		},
+		"RetryAfterError": {
+			reason: "An error returned by WithRetryAfter should suggest its delay.",
+			err:    WithRetryAfter(errBoom, 10*time.Second),
+			want:   want{d: 10 * time.Second, ok: true},
+		},
+		"WrappedRetryAfterError": {
+			reason: "A wrapped error returned by WithRetryAfter should suggest its delay.",
+			err:    errors.Wrap(WithRetryAfter(errBoom, 10*time.Second), "cannot observe"),
+			want:   want{d: 10 * time.Second, ok: true},
+		},
+		"KubernetesTooManyRequests": {
+			reason: "A Kubernetes API error that suggests a client delay should suggest that delay.",
+			err:    kerrors.NewTooManyRequests("slow down", 5),
+			want:   want{d: 5 * time.Second, ok: true},
+		},
+		"KubernetesNotFound": {
+			reason: "A Kubernetes API error that doesn't suggest a client delay should not suggest a delay.",
+			err:    kerrors.NewNotFound(schema.GroupResource{}, "cool"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, ok := RetryAfter(tc.err)
+			if diff := cmp.Diff(tc.want, want{d: d, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nRetryAfter(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type want struct {
+		d  time.Duration
+		ok bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		v      string
+		want   want
+	}{
+		"Seconds": {
+			reason: "A number of seconds should be parsed.",
+			v:      "120",
+			want:   want{d: 2 * time.Minute, ok: true},
+		},
+		"NegativeSeconds": {
+			reason: "A negative number of seconds is invalid.",
+			v:      "-1",
+		},
+		"Date": {
+			reason: "An HTTP date should be parsed relative to now.",
+			v:      now.Add(90 * time.Second).Format(http.TimeFormat),
+			want:   want{d: 90 * time.Second, ok: true},
+		},
+		"PastDate": {
+			reason: "An HTTP date in the past should produce a zero delay.",
+			v:      now.Add(-time.Hour).Format(http.TimeFormat),
+			want:   want{d: 0, ok: true},
+		},
+		"Invalid": {
+			reason: "An invalid value should not be parsed.",
+			v:      "soon",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, ok := ParseRetryAfter(tc.v, now)
+			if diff := cmp.Diff(tc.want, want{d: d, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nParseRetryAfter(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryAfterFromResponse(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		resp   *http.Response
+		want   time.Duration
+		ok     bool
+	}{
+		"TooManyRequests": {
+			reason: "A 429 response with a Retry-After header should request a backoff.",
+			resp:   &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"7"}}},
+			want:   7 * time.Second,
+			ok:     true,
+		},
+		"ServiceUnavailable": {
+			reason: "A 503 response with a Retry-After header should request a backoff.",
+			resp:   &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": []string{"7"}}},
+			want:   7 * time.Second,
+			ok:     true,
+		},
+		"NoHeader": {
+			reason: "A 429 response without a Retry-After header should not request a backoff.",
+			resp:   &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}},
+		},
+		"OK": {
+			reason: "A 200 response should not request a backoff.",
+			resp:   &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Retry-After": []string{"7"}}},
+		},
+		"NilResponse": {
+			reason: "A nil response should not request a backoff.",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d, ok := RetryAfterFromResponse(tc.resp)
+			if d != tc.want || ok != tc.ok {
+				t.Errorf("\n%s\nRetryAfterFromResponse(...): want %v, %t, got %v, %t", tc.reason, tc.want, tc.ok, d, ok)
+			}
+		})
+	}
+}
+
+func TestRetryAfterRateLimiter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type call struct {
+		after  time.Duration
+		hint   time.Duration
+		forget bool
+		want   time.Duration
+	}
+
+	cases := map[string]struct {
+		reason string
+		d      time.Duration
+		calls  []call
+	}{
+		"NoHint": {
+			reason: "Without a hint the wrapped rate limiter's delay should be returned.",
+			d:      time.Second,
+			calls:  []call{{want: time.Second}},
+		},
+		"HintLonger": {
+			reason: "A hint longer than the wrapped rate limiter's delay should be honored.",
+			d:      time.Second,
+			calls:  []call{{hint: time.Minute, want: time.Minute}, {after: 20 * time.Second, want: 40 * time.Second}},
+		},
+		"HintShorter": {
+			reason: "A hint shorter than the wrapped rate limiter's delay should be ignored.",
+			d:      time.Minute,
+			calls:  []call{{hint: time.Second, want: time.Minute}},
+		},
+		"HintElapsed": {
+			reason: "A hint should be ignored once it has elapsed.",
+			d:      time.Second,
+			calls:  []call{{hint: time.Minute, want: time.Minute}, {after: time.Minute, want: time.Second}},
+		},
+		"Forget": {
+			reason: "Forgetting an item should forget its hint.",
+			d:      time.Second,
+			calls:  []call{{hint: time.Minute, forget: true, want: time.Second}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := start
+			l := NewRetryAfter[any](&predictableRateLimiter{d: tc.d})
+			l.now = func() time.Time { return now }
+
+			for i, c := range tc.calls {
+				now = now.Add(c.after)
+				if c.hint > 0 {
+					l.Hint("item", c.hint)
+				}
+				if c.forget {
+					l.Forget("item")
+				}
+				if got := l.When("item"); got != c.want {
+					t.Errorf("\n%s\nWhen(...) call %d: want %v, got %v", tc.reason, i, c.want, got)
+				}
+			}
+		})
+	}
+}
@@ -1,26 +1,105 @@
 package reconciler
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
 	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	"github.com/krateoplatformops/provider-runtime/pkg/reference"
 	"github.com/krateoplatformops/provider-runtime/pkg/resource"
 )
 
 // Error strings.
 const (
-	errCreateOrUpdateSecret      = "cannot create or update connection secret"
-	errUpdateManaged             = "cannot update managed resource"
-	errUpdateManagedStatus       = "cannot update managed resource status"
-	errResolveReferences         = "cannot resolve references"
-	errUpdateCriticalAnnotations = "cannot update critical annotations"
+	errCreateOrUpdateSecret       = "cannot create or update connection secret"
+	errUpdateManaged              = "cannot update managed resource"
+	errUpdateManagedStatus        = "cannot update managed resource status"
+	errResolveReferences          = "cannot resolve references"
+	errUpdateCriticalAnnotations  = "cannot update critical annotations"
+	errFmtGetDependency           = "cannot get dependency %s"
+	errFmtDependencyCycle         = "dependency cycle: %s"
+	errParseExternalNameTemplate  = "cannot parse external name template"
+	errRenderExternalNameTemplate = "cannot render external name template"
+	errFmtInvalidExternalName     = "rendered external name %q is invalid: it must be non-empty and must not contain whitespace"
+	errFmtNotObject               = "managed resource kind %s is not a client.Object"
 )
 
+type errDependencyCycle struct{ error }
+
+func (e errDependencyCycle) DependencyCycle() bool {
+	return true
+}
+
+// IsDependencyCycle returns true if the supplied error indicates that a
+// managed resource transitively depends on itself.
+func IsDependencyCycle(err error) bool {
+	_, ok := err.(interface { //nolint: errorlint // Skip errorlint for interface type
+		DependencyCycle() bool
+	})
+	return ok
+}
+
+// An APISimpleReferenceResolver resolves references from one managed resource
+// to others by calling the referencing resource's ResolveReferences method, if
+// any.
+type APISimpleReferenceResolver struct {
+	client client.Client
+}
+
+// NewAPISimpleReferenceResolver returns a ReferenceResolver that resolves
+// references from one managed resource to others by calling the referencing
+// resource's ResolveReferences method, if any.
+func NewAPISimpleReferenceResolver(c client.Client) *APISimpleReferenceResolver {
+	return &APISimpleReferenceResolver{client: c}
+}
+
+// ResolveReferences of the supplied managed resource by calling its
+// ResolveReferences method, if any. The managed resource is updated if
+// resolution changed it.
+func (a *APISimpleReferenceResolver) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	rr, ok := mg.(interface {
+		ResolveReferences(context.Context, client.Reader) error
+	})
+	if !ok {
+		// This managed resource doesn't have any references to resolve.
+		return nil
+	}
+
+	existing := mg.DeepCopyObject()
+	if err := rr.ResolveReferences(ctx, a.client); err != nil {
+		return errors.Wrap(err, errResolveReferences)
+	}
+
+	if equality.Semantic.DeepEqual(existing, mg) {
+		// The resource didn't change during reference resolution.
+		return nil
+	}
+
+	return errors.Wrap(a.client.Update(ctx, mg), errUpdateManaged)
+}
+
 // A RetryingCriticalAnnotationUpdater is a CriticalAnnotationUpdater that
 // retries annotation updates in the face of API server errors.
 type RetryingCriticalAnnotationUpdater struct {
@@ -50,3 +129,405 @@ func (u *RetryingCriticalAnnotationUpdater) UpdateCriticalAnnotations(ctx contex
 	})
 	return errors.Wrap(err, errUpdateCriticalAnnotations)
 }
+
+// An ExternalNameTemplate initializes the external name of a managed resource
+// by rendering its external name template annotation, if any.
+type ExternalNameTemplate struct {
+	client client.Client
+}
+
+// NewExternalNameTemplate returns a new ExternalNameTemplate.
+func NewExternalNameTemplate(c client.Client) *ExternalNameTemplate {
+	return &ExternalNameTemplate{client: c}
+}
+
+// Initialize the external name of the supplied managed resource by rendering
+// its external name template annotation, unless it has no such annotation or
+// already has an external name. The template is executed with the managed
+// resource's JSON representation, and fails if it refers to a field that does
+// not exist. The rendered external name must be non-empty and must not contain
+// whitespace.
+func (a *ExternalNameTemplate) Initialize(ctx context.Context, mg resource.Managed) error {
+	tmpl := mg.GetAnnotations()[meta.AnnotationKeyExternalNameTemplate]
+	if tmpl == "" || meta.GetExternalName(mg) != "" {
+		return nil
+	}
+	name, err := renderExternalName(tmpl, mg)
+	if err != nil {
+		return err
+	}
+	meta.SetExternalName(mg, name)
+	return errors.Wrap(a.client.Update(ctx, mg), errUpdateManaged)
+}
+
+// A ProviderIdentity initializes a managed resource by recording the name and
+// version of the provider that manages it.
+type ProviderIdentity struct {
+	client  client.Client
+	name    string
+	version string
+}
+
+// NewProviderIdentity returns a new ProviderIdentity that records the
+// supplied provider name and version.
+func NewProviderIdentity(c client.Client, name, version string) *ProviderIdentity {
+	return &ProviderIdentity{client: c, name: name, version: version}
+}
+
+// Initialize the supplied managed resource by recording the name and version
+// of the provider that manages it, unless they are already recorded. A
+// managed resource is updated when it is first reconciled, and again when a
+// different provider or provider version reconciles it.
+func (a *ProviderIdentity) Initialize(ctx context.Context, mg resource.Managed) error {
+	if meta.GetManagedBy(mg) == a.name && meta.GetProviderVersion(mg) == a.version {
+		return nil
+	}
+	meta.SetManagedBy(mg, a.name)
+	meta.SetProviderVersion(mg, a.version)
+	return errors.Wrap(a.client.Update(ctx, mg), errUpdateManaged)
+}
+
+func renderExternalName(tmpl string, o runtime.Object) (string, error) {
+	t, err := template.New("external-name").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(err, errParseExternalNameTemplate)
+	}
+
+	j, err := json.Marshal(o)
+	if err != nil {
+		return "", errors.Wrap(err, errRenderExternalNameTemplate)
+	}
+	data := map[string]any{}
+	if err := json.Unmarshal(j, &data); err != nil {
+		return "", errors.Wrap(err, errRenderExternalNameTemplate)
+	}
+
+	b := &bytes.Buffer{}
+	if err := t.Execute(b, data); err != nil {
+		return "", errors.Wrap(err, errRenderExternalNameTemplate)
+	}
+
+	name := b.String()
+	if name == "" || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return "", errors.Errorf(errFmtInvalidExternalName, name)
+	}
+	return name, nil
+}
+
+// An APIDependencyWaiter determines which of the objects a managed resource
+// depends on, as listed by its depends-on annotation, are not yet ready. An
+// object is ready when its Ready condition is true. Objects are read as
+// unstructured objects, so their kinds need not be registered with the
+// client's scheme.
+type APIDependencyWaiter struct {
+	client client.Reader
+}
+
+// NewAPIDependencyWaiter returns a DependencyWaiter that reads the objects a
+// managed resource depends on from the Kubernetes API server.
+func NewAPIDependencyWaiter(c client.Reader) *APIDependencyWaiter {
+	return &APIDependencyWaiter{client: c}
+}
+
+// WaitingFor returns the objects the supplied managed resource depends on
+// that do not yet exist or are not yet ready. It returns an error satisfying
+// IsDependencyCycle if an object that is not yet ready transitively depends on
+// the supplied managed resource, since neither would ever become ready.
+func (w *APIDependencyWaiter) WaitingFor(ctx context.Context, mg resource.Managed) ([]string, error) {
+	refs, err := meta.GetDependsOn(mg)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting := []string{}
+	for _, ref := range refs {
+		name := dependencyName(ref)
+		u, err := w.get(ctx, ref)
+		if kerrors.IsNotFound(err) {
+			waiting = append(waiting, name)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGetDependency, name)
+		}
+		if resource.IsReady(u) {
+			continue
+		}
+		if cycle := w.findCycle(ctx, mg.GetUID(), u, []string{name}, map[types.UID]bool{}); cycle != nil {
+			return nil, errDependencyCycle{errors.Errorf(errFmtDependencyCycle, strings.Join(cycle, " -> "))}
+		}
+		waiting = append(waiting, name)
+	}
+	return waiting, nil
+}
+
+// findCycle walks the dependencies of the supplied object depth first, and
+// returns the path to the object with the supplied UID if it is found. Objects
+// that can't be read are skipped, since cycle detection is best effort.
+func (w *APIDependencyWaiter) findCycle(ctx context.Context, target types.UID, o *reference.Unstructured, path []string, visited map[types.UID]bool) []string {
+	visited[o.GetUID()] = true
+
+	refs, err := meta.GetDependsOn(o)
+	if err != nil {
+		return nil
+	}
+	for _, ref := range refs {
+		u, err := w.get(ctx, ref)
+		if err != nil {
+			continue
+		}
+		p := append(path[:len(path):len(path)], dependencyName(ref))
+		if u.GetUID() == target {
+			return p
+		}
+		if visited[u.GetUID()] {
+			continue
+		}
+		if cycle := w.findCycle(ctx, target, u, p, visited); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+func (w *APIDependencyWaiter) get(ctx context.Context, ref corev1.ObjectReference) (*reference.Unstructured, error) {
+	u := &reference.Unstructured{}
+	u.SetAPIVersion(ref.APIVersion)
+	u.SetKind(ref.Kind)
+	err := w.client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, u)
+	return u, err
+}
+
+func dependencyName(ref corev1.ObjectReference) string {
+	if ref.Namespace == "" {
+		return ref.Kind + " " + ref.Name
+	}
+	return ref.Kind + " " + ref.Namespace + "/" + ref.Name
+}
+
+// SyncRequested returns an UnlimitedFn that is satisfied by requests for
+// managed resources of the supplied kind that have a sync-now annotation. It
+// is intended to be used with ratelimiter.WithUnlimited, so that requested
+// syncs are not delayed by rate limiting. Requests for managed resources that
+// can't be read are rate limited as usual.
+func SyncRequested(c client.Client, of resource.ManagedKind) ratelimiter.UnlimitedFn {
+	return func(ctx context.Context, req reconcile.Request) bool {
+		o, err := getManaged(ctx, c, of, req)
+		if err != nil {
+			return false
+		}
+		return meta.IsSyncRequested(o)
+	}
+}
+
+// HighPriority returns an UnlimitedFn that is satisfied by requests for
+// managed resources of the supplied kind whose priority annotation is set to
+// high. It is intended to be used with ratelimiter.WithUnlimited, so that
+// reconciles of critical resources are not delayed behind bulk reconciles of
+// others. Requests for managed resources that can't be read are rate limited
+// as usual.
+func HighPriority(c client.Client, of resource.ManagedKind) ratelimiter.UnlimitedFn {
+	return func(ctx context.Context, req reconcile.Request) bool {
+		o, err := getManaged(ctx, c, of, req)
+		if err != nil {
+			return false
+		}
+		return meta.GetPriority(o) == meta.PriorityHigh
+	}
+}
+
+// getManaged returns the requested managed resource of the supplied kind.
+func getManaged(ctx context.Context, c client.Client, of resource.ManagedKind, req reconcile.Request) (client.Object, error) {
+	mg, err := c.Scheme().New(schema.GroupVersionKind(of))
+	if err != nil {
+		return nil, err
+	}
+	o, ok := mg.(client.Object)
+	if !ok {
+		return nil, errors.Errorf(errFmtNotObject, of)
+	}
+	return o, c.Get(ctx, req.NamespacedName, o)
+}
+
+// RateLimitPolicies records the rate limiting policies of the managed
+// resources a Reconciler reads, so that rate limiters can honor them without
+// reading the managed resources again each time a request is requeued.
+type RateLimitPolicies struct {
+	mu        sync.RWMutex
+	reconcile map[reconcile.Request]*prv1.ReconcilePolicy
+	priority  map[reconcile.Request]string
+}
+
+// NewRateLimitPolicies returns an empty set of RateLimitPolicies.
+func NewRateLimitPolicies() *RateLimitPolicies {
+	return &RateLimitPolicies{
+		reconcile: map[reconcile.Request]*prv1.ReconcilePolicy{},
+		priority:  map[reconcile.Request]string{},
+	}
+}
+
+// Record the rate limiting policies of the supplied managed resource, which
+// was read in response to the supplied request.
+func (p *RateLimitPolicies) Record(req reconcile.Request, mg resource.Managed) {
+	var rp *prv1.ReconcilePolicy
+	if h, ok := mg.(resource.ReconcilePolicyHolder); ok && h.GetReconcilePolicy() != nil {
+		rp = h.GetReconcilePolicy().DeepCopy()
+	}
+
+	pr := meta.GetPriority(mg)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pr == meta.PriorityNormal {
+		delete(p.priority, req)
+	} else {
+		p.priority[req] = pr
+	}
+	if rp == nil {
+		delete(p.reconcile, req)
+		return
+	}
+	p.reconcile[req] = rp
+}
+
+// Forget the rate limiting policies recorded for the supplied request, for
+// example because its managed resource no longer exists.
+func (p *RateLimitPolicies) Forget(req reconcile.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.reconcile, req)
+	delete(p.priority, req)
+}
+
+// ReconcilePolicy returns the reconcile policy recorded for the supplied
+// request, or nil if none was recorded.
+func (p *RateLimitPolicies) ReconcilePolicy(req reconcile.Request) *prv1.ReconcilePolicy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.reconcile[req]
+}
+
+// Priority returns the priority recorded for the supplied request, or
+// meta.PriorityNormal if none was recorded.
+func (p *RateLimitPolicies) Priority(req reconcile.Request) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if pr, ok := p.priority[req]; ok {
+		return pr
+	}
+	return meta.PriorityNormal
+}
+
+// A PolicyRateLimiter wraps the rate limiter of a managed resource
+// controller, applying the max backoff and retry budget of each managed
+// resource's reconcile policy to its failed reconciles.
+type PolicyRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+
+	policies     *RateLimitPolicies
+	pollInterval time.Duration
+}
+
+// NewPolicyRateLimiter returns a PolicyRateLimiter that honors the reconcile
+// policies recorded in the supplied RateLimitPolicies, typically those of a
+// Reconciler. Failed reconciles of a managed resource whose retry budget is
+// exhausted are retried after its poll interval, or the supplied default poll
+// interval if it doesn't specify one. Most controllers should use the
+// RateLimiter of their Reconciler, which is built using this rate limiter.
+func NewPolicyRateLimiter(p *RateLimitPolicies, l workqueue.TypedRateLimiter[reconcile.Request], pollInterval time.Duration) *PolicyRateLimiter {
+	return &PolicyRateLimiter{TypedRateLimiter: l, policies: p, pollInterval: pollInterval}
+}
+
+// When returns how long to wait before retrying the supplied request.
+func (l *PolicyRateLimiter) When(req reconcile.Request) time.Duration {
+	d := l.TypedRateLimiter.When(req)
+
+	p := l.policies.ReconcilePolicy(req)
+	if p == nil {
+		return d
+	}
+	if p.RetryBudget != nil && int64(l.NumRequeues(req)) > *p.RetryBudget {
+		if p.PollInterval != nil && p.PollInterval.Duration > 0 {
+			return p.PollInterval.Duration
+		}
+		return l.pollInterval
+	}
+	if p.MaxBackoff != nil && p.MaxBackoff.Duration > 0 && d > p.MaxBackoff.Duration {
+		return p.MaxBackoff.Duration
+	}
+	return d
+}
+
+// DefaultPriorityFactor is the factor by which a PriorityRateLimiter divides
+// the delays of high priority resources, and multiplies those of low priority
+// resources.
+const DefaultPriorityFactor = 4
+
+// A PriorityRateLimiter wraps the rate limiter of a managed resource
+// controller, scaling the delay before a failed reconcile is retried according
+// to the priority annotation of the managed resource, so that critical
+// resources are retried ahead of bulk low priority resources.
+type PriorityRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+
+	policies *RateLimitPolicies
+	factor   float64
+}
+
+// A PriorityRateLimiterOption configures a PriorityRateLimiter.
+type PriorityRateLimiterOption func(*PriorityRateLimiter)
+
+// WithPriorityFactor configures the factor by which the delays of high
+// priority resources are divided, and those of low priority resources are
+// multiplied. Factors below one are treated as one.
+func WithPriorityFactor(f float64) PriorityRateLimiterOption {
+	return func(l *PriorityRateLimiter) {
+		l.factor = max(f, 1)
+	}
+}
+
+// NewPriorityRateLimiter returns a PriorityRateLimiter that honors the
+// priorities recorded in the supplied RateLimitPolicies, typically those of a
+// Reconciler. Delays are scaled by DefaultPriorityFactor unless configured
+// otherwise. Most controllers should use the RateLimiter of their Reconciler,
+// which is built using this rate limiter.
+func NewPriorityRateLimiter(p *RateLimitPolicies, l workqueue.TypedRateLimiter[reconcile.Request], o ...PriorityRateLimiterOption) *PriorityRateLimiter {
+	pl := &PriorityRateLimiter{TypedRateLimiter: l, policies: p, factor: DefaultPriorityFactor}
+	for _, fn := range o {
+		fn(pl)
+	}
+	return pl
+}
+
+// When returns how long to wait before retrying the supplied request.
+func (l *PriorityRateLimiter) When(req reconcile.Request) time.Duration {
+	d := l.TypedRateLimiter.When(req)
+
+	switch l.policies.Priority(req) {
+	case meta.PriorityHigh:
+		return time.Duration(float64(d) / l.factor)
+	case meta.PriorityLow:
+		return time.Duration(float64(d) * l.factor)
+	default:
+		return d
+	}
+}
+
+// SyncNowChanged returns a predicate that is satisfied only by updates that
+// set or change the sync-now annotation. It is intended to be combined with other
+// predicates that would otherwise filter out annotation changes, for example
+// predicate.Or(predicate.GenerationChangedPredicate{}, SyncNowChanged()).
+func SyncNowChanged() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return meta.IsSyncRequested(e.ObjectNew) &&
+				e.ObjectOld.GetAnnotations()[meta.AnnotationKeySyncNow] != e.ObjectNew.GetAnnotations()[meta.AnnotationKeySyncNow]
+		},
+	}
+}
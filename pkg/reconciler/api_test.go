@@ -0,0 +1,533 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/reference"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestAPIDependencyWaiter(t *testing.T) {
+	errBoom := errors.New("boom")
+	dependsOn := `[{"apiVersion":"v1","kind":"Bucket","name":"ready"},{"apiVersion":"v1","kind":"Bucket","name":"unready"},{"apiVersion":"v1","kind":"Bucket","name":"missing"}]`
+
+	type want struct {
+		waiting []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		mg     *fake.Managed
+		want   want
+	}{
+		"NoDependencies": {
+			reason: "A managed resource without dependencies should not wait for anything.",
+			mg:     &fake.Managed{},
+			want:   want{waiting: []string{}},
+		},
+		"GetError": {
+			reason: "Errors getting a dependency should be returned.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "coolns",
+				Annotations: map[string]string{meta.AnnotationKeyDependsOn: `[{"apiVersion":"v1","kind":"Bucket","name":"ready"}]`},
+			}},
+			want: want{err: errors.Wrapf(errBoom, errFmtGetDependency, "Bucket coolns/ready")},
+		},
+		"Cycle": {
+			reason: "A dependency that transitively depends on the managed resource should be reported as a cycle.",
+			c: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				u := obj.(*reference.Unstructured)
+				u.SetNamespace(key.Namespace)
+				u.SetUID(types.UID(key.Name))
+				switch key.Name {
+				case "a":
+					meta.AddAnnotations(u, map[string]string{meta.AnnotationKeyDependsOn: `[{"apiVersion":"v1","kind":"Bucket","name":"b"}]`})
+				case "b":
+					meta.AddAnnotations(u, map[string]string{meta.AnnotationKeyDependsOn: `[{"apiVersion":"v1","kind":"Bucket","name":"a"},{"apiVersion":"v1","kind":"Bucket","name":"self"}]`})
+				}
+				return nil
+			}},
+			mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "coolns",
+				UID:         "self",
+				Annotations: map[string]string{meta.AnnotationKeyDependsOn: `[{"apiVersion":"v1","kind":"Bucket","name":"a"}]`},
+			}},
+			want: want{err: errDependencyCycle{errors.Errorf(errFmtDependencyCycle, "Bucket coolns/a -> Bucket coolns/b -> Bucket coolns/self")}},
+		},
+		"WaitingFor": {
+			reason: "Dependencies that are missing or not ready should be waited for.",
+			c: &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch key.Name {
+				case "missing":
+					return kerrors.NewNotFound(schema.GroupResource{Resource: "buckets"}, key.Name)
+				case "ready":
+					obj.(*reference.Unstructured).SetConditions(prv1.Available())
+				}
+				return nil
+			}},
+			mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "coolns",
+				Annotations: map[string]string{meta.AnnotationKeyDependsOn: dependsOn},
+			}},
+			want: want{waiting: []string{"Bucket coolns/unready", "Bucket coolns/missing"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := NewAPIDependencyWaiter(tc.c)
+			got, err := w.WaitingFor(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWaitingFor(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.waiting, got); diff != "" {
+				t.Errorf("\n%s\nWaitingFor(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExternalNameTemplate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	managed := func(annotations map[string]string) *reference.Unstructured {
+		u := &reference.Unstructured{}
+		u.SetNamespace("coolns")
+		u.SetName("cool")
+		u.SetAnnotations(annotations)
+		_ = unstructured.SetNestedField(u.Object, "eu-west-1", "spec", "region")
+		return u
+	}
+
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		mg     *reference.Unstructured
+		want   want
+	}{
+		"NoTemplate": {
+			reason: "A managed resource without a template should not be initialized.",
+			mg:     managed(nil),
+		},
+		"ExternalNameExists": {
+			reason: "A managed resource that already has an external name should not be initialized.",
+			mg: managed(map[string]string{
+				meta.AnnotationKeyExternalNameTemplate: "{{ .metadata.name }}",
+				meta.AnnotationKeyExternalName:         "existing",
+			}),
+			want: want{externalName: "existing"},
+		},
+		"MissingField": {
+			reason: "A template that refers to a field that does not exist should return an error.",
+			mg:     managed(map[string]string{meta.AnnotationKeyExternalNameTemplate: "{{ .spec.zone }}"}),
+			want:   want{err: errors.Wrap(errors.New(`template: external-name:1:8: executing "external-name" at <.spec.zone>: map has no entry for key "zone"`), errRenderExternalNameTemplate)},
+		},
+		"InvalidName": {
+			reason: "A template that renders an external name containing whitespace should return an error.",
+			mg:     managed(map[string]string{meta.AnnotationKeyExternalNameTemplate: "{{ .metadata.name }} {{ .spec.region }}"}),
+			want:   want{err: errors.Errorf(errFmtInvalidExternalName, "cool eu-west-1")},
+		},
+		"UpdateError": {
+			reason: "Errors updating the managed resource should be returned.",
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:     managed(map[string]string{meta.AnnotationKeyExternalNameTemplate: "{{ .metadata.name }}"}),
+			want: want{
+				externalName: "cool",
+				err:          errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+		"Success": {
+			reason: "The rendered template should be set as the external name.",
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     managed(map[string]string{meta.AnnotationKeyExternalNameTemplate: "{{ .metadata.namespace }}-{{ .metadata.name }}-{{ .spec.region }}"}),
+			want:   want{externalName: "coolns-cool-eu-west-1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewExternalNameTemplate(tc.c).Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want external name, +got external name:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderIdentity(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	managed := func(managedBy, version string) *fake.Managed {
+		mg := &fake.Managed{}
+		if managedBy != "" {
+			meta.SetManagedBy(mg, managedBy)
+			meta.SetProviderVersion(mg, version)
+		}
+		return mg
+	}
+
+	type want struct {
+		managedBy string
+		version   string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		mg     *fake.Managed
+		want   want
+	}{
+		"AlreadyRecorded": {
+			reason: "A managed resource that records the provider identity should not be updated.",
+			mg:     managed("provider-cool", "v1.0.0"),
+			want:   want{managedBy: "provider-cool", version: "v1.0.0"},
+		},
+		"FirstReconcile": {
+			reason: "The provider identity should be recorded on a managed resource that doesn't record it.",
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     managed("", ""),
+			want:   want{managedBy: "provider-cool", version: "v1.0.0"},
+		},
+		"Upgraded": {
+			reason: "The provider identity should be recorded on a managed resource that records a different provider version.",
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			mg:     managed("provider-cool", "v0.9.0"),
+			want:   want{managedBy: "provider-cool", version: "v1.0.0"},
+		},
+		"UpdateError": {
+			reason: "Errors updating the managed resource should be returned.",
+			c:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg:     managed("", ""),
+			want: want{
+				managedBy: "provider-cool",
+				version:   "v1.0.0",
+				err:       errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewProviderIdentity(tc.c, "provider-cool", "v1.0.0").Initialize(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.managedBy, meta.GetManagedBy(tc.mg)); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want managed by, +got managed by:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.version, meta.GetProviderVersion(tc.mg)); diff != "" {
+				t.Errorf("\n%s\nInitialize(...): -want version, +got version:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSyncRequested(t *testing.T) {
+	errBoom := errors.New("boom")
+	scheme := fake.SchemeWith(&fake.Managed{})
+	of := resource.ManagedKind(fake.GVK(&fake.Managed{}))
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		want   bool
+	}{
+		"SyncRequested": {
+			reason: "A managed resource with a sync-now annotation should not be rate limited.",
+			c: &test.MockClient{
+				MockScheme: test.NewMockSchemeFn(scheme),
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					meta.AddAnnotations(obj, map[string]string{meta.AnnotationKeySyncNow: "now"})
+					return nil
+				}),
+			},
+			want: true,
+		},
+		"NoSyncRequested": {
+			reason: "A managed resource without a sync-now annotation should be rate limited.",
+			c: &test.MockClient{
+				MockScheme: test.NewMockSchemeFn(scheme),
+				MockGet:    test.NewMockGetFn(nil),
+			},
+			want: false,
+		},
+		"GetError": {
+			reason: "A managed resource that can't be read should be rate limited.",
+			c: &test.MockClient{
+				MockScheme: test.NewMockSchemeFn(scheme),
+				MockGet:    test.NewMockGetFn(errBoom),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SyncRequested(tc.c, of)(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nReason: %s\nSyncRequested(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type policyManaged struct {
+	fake.Managed
+	fake.ReconcilePolicyHolder
+}
+
+func TestPolicyRateLimiter(t *testing.T) {
+	withPolicy := func(p *prv1.ReconcilePolicy) *RateLimitPolicies {
+		mg := &policyManaged{}
+		mg.SetReconcilePolicy(p)
+		rp := NewRateLimitPolicies()
+		rp.Record(reconcile.Request{}, mg)
+		return rp
+	}
+	budget := func(i int64) *int64 { return &i }
+
+	cases := map[string]struct {
+		reason string
+		p      *RateLimitPolicies
+		want   time.Duration
+	}{
+		"NoPolicy": {
+			reason: "Managed resources without a reconcile policy should be backed off as usual.",
+			p:      withPolicy(nil),
+			want:   time.Second,
+		},
+		"NotRecorded": {
+			reason: "Managed resources whose policies weren't recorded should be backed off as usual.",
+			p:      NewRateLimitPolicies(),
+			want:   time.Second,
+		},
+		"Forgotten": {
+			reason: "Managed resources whose policies were forgotten should be backed off as usual.",
+			p: func() *RateLimitPolicies {
+				rp := withPolicy(&prv1.ReconcilePolicy{MaxBackoff: &metav1.Duration{Duration: 500 * time.Millisecond}})
+				rp.Forget(reconcile.Request{})
+				return rp
+			}(),
+			want: time.Second,
+		},
+		"MaxBackoff": {
+			reason: "Backoff should not exceed the max backoff of the reconcile policy.",
+			p:      withPolicy(&prv1.ReconcilePolicy{MaxBackoff: &metav1.Duration{Duration: 500 * time.Millisecond}}),
+			want:   500 * time.Millisecond,
+		},
+		"RetryBudgetRemaining": {
+			reason: "Managed resources with a remaining retry budget should be backed off as usual.",
+			p:      withPolicy(&prv1.ReconcilePolicy{RetryBudget: budget(1)}),
+			want:   time.Second,
+		},
+		"RetryBudgetExhausted": {
+			reason: "Managed resources with an exhausted retry budget should be retried after their poll interval.",
+			p:      withPolicy(&prv1.ReconcilePolicy{RetryBudget: budget(0), PollInterval: &metav1.Duration{Duration: time.Minute}}),
+			want:   time.Minute,
+		},
+		"RetryBudgetExhaustedDefaultPollInterval": {
+			reason: "Managed resources with an exhausted retry budget and no poll interval should be retried after the default poll interval.",
+			p:      withPolicy(&prv1.ReconcilePolicy{RetryBudget: budget(0)}),
+			want:   2 * time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewPolicyRateLimiter(tc.p, workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](time.Second, time.Hour), 2*time.Minute)
+			got := l.When(reconcile.Request{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nReason: %s\nWhen(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHighPriority(t *testing.T) {
+	scheme := fake.SchemeWith(&fake.Managed{})
+	of := resource.ManagedKind(fake.GVK(&fake.Managed{}))
+	withPriority := func(p string) client.Client {
+		return &test.MockClient{
+			MockScheme: test.NewMockSchemeFn(scheme),
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				meta.AddAnnotations(obj, map[string]string{meta.AnnotationKeyPriority: p})
+				return nil
+			}),
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		want   bool
+	}{
+		"High": {
+			reason: "A high priority managed resource should not be rate limited.",
+			c:      withPriority(meta.PriorityHigh),
+			want:   true,
+		},
+		"Low": {
+			reason: "A low priority managed resource should be rate limited.",
+			c:      withPriority(meta.PriorityLow),
+			want:   false,
+		},
+		"GetError": {
+			reason: "A managed resource that can't be read should be rate limited.",
+			c: &test.MockClient{
+				MockScheme: test.NewMockSchemeFn(scheme),
+				MockGet:    test.NewMockGetFn(errors.New("boom")),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HighPriority(tc.c, of)(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nReason: %s\nHighPriority(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPriorityRateLimiter(t *testing.T) {
+	withPriority := func(p string) *RateLimitPolicies {
+		mg := &fake.Managed{}
+		if p != "" {
+			meta.AddAnnotations(mg, map[string]string{meta.AnnotationKeyPriority: p})
+		}
+		rp := NewRateLimitPolicies()
+		rp.Record(reconcile.Request{}, mg)
+		return rp
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      *RateLimitPolicies
+		o      []PriorityRateLimiterOption
+		want   time.Duration
+	}{
+		"Normal": {
+			reason: "Managed resources without a priority should be backed off as usual.",
+			p:      withPriority(""),
+			want:   4 * time.Second,
+		},
+		"High": {
+			reason: "High priority managed resources should be retried sooner.",
+			p:      withPriority(meta.PriorityHigh),
+			want:   time.Second,
+		},
+		"Low": {
+			reason: "Low priority managed resources should be retried later.",
+			p:      withPriority(meta.PriorityLow),
+			want:   16 * time.Second,
+		},
+		"Factor": {
+			reason: "Delays should be scaled by the configured factor.",
+			p:      withPriority(meta.PriorityHigh),
+			o:      []PriorityRateLimiterOption{WithPriorityFactor(2)},
+			want:   2 * time.Second,
+		},
+		"NotRecorded": {
+			reason: "Managed resources whose priority wasn't recorded should be backed off as usual.",
+			p:      NewRateLimitPolicies(),
+			want:   4 * time.Second,
+		},
+		"Forgotten": {
+			reason: "Managed resources whose priority was forgotten should be backed off as usual.",
+			p: func() *RateLimitPolicies {
+				rp := withPriority(meta.PriorityLow)
+				rp.Forget(reconcile.Request{})
+				return rp
+			}(),
+			want: 4 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewPriorityRateLimiter(tc.p, workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](4*time.Second, time.Hour), tc.o...)
+			got := l.When(reconcile.Request{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nReason: %s\nWhen(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSyncNowChanged(t *testing.T) {
+	withSyncNow := func(v string) *fake.Managed {
+		mg := &fake.Managed{}
+		if v != "" {
+			meta.AddAnnotations(mg, map[string]string{meta.AnnotationKeySyncNow: v})
+		}
+		return mg
+	}
+
+	cases := map[string]struct {
+		reason string
+		e      event.UpdateEvent
+		want   bool
+	}{
+		"Set": {
+			reason: "Setting the sync-now annotation should satisfy the predicate.",
+			e:      event.UpdateEvent{ObjectOld: withSyncNow(""), ObjectNew: withSyncNow("a")},
+			want:   true,
+		},
+		"Changed": {
+			reason: "Changing the sync-now annotation should satisfy the predicate.",
+			e:      event.UpdateEvent{ObjectOld: withSyncNow("a"), ObjectNew: withSyncNow("b")},
+			want:   true,
+		},
+		"Unchanged": {
+			reason: "Leaving the sync-now annotation unchanged should not satisfy the predicate.",
+			e:      event.UpdateEvent{ObjectOld: withSyncNow("a"), ObjectNew: withSyncNow("a")},
+			want:   false,
+		},
+		"Cleared": {
+			reason: "Clearing the sync-now annotation should not satisfy the predicate.",
+			e:      event.UpdateEvent{ObjectOld: withSyncNow("a"), ObjectNew: withSyncNow("")},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SyncNowChanged().Update(tc.e)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nReason: %s\nSyncNowChanged().Update(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package reconciler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/redact"
+)
+
+const defaultMaxDiffSize = 4096
+
+type diffOptions struct {
+	maxSize int
+	hash    bool
+}
+
+// A DiffOption configures how SafeDiff prepares a diff to be logged.
+type DiffOption func(*diffOptions)
+
+// WithMaxDiffSize configures the maximum size in bytes of a diff, beyond
+// which it is truncated. The default is 4096 bytes. Diffs are not truncated
+// if the size is zero or less.
+func WithMaxDiffSize(n int) DiffOption {
+	return func(o *diffOptions) {
+		o.maxSize = n
+	}
+}
+
+// WithDiffHash configures SafeDiff to return a hash of the diff rather than
+// the diff itself, so that it can be told whether a diff has changed without
+// logging its content.
+func WithDiffHash() DiffOption {
+	return func(o *diffOptions) {
+		o.hash = true
+	}
+}
+
+// SafeDiff returns the supplied diff, typically an ExternalObservation's Diff,
+// prepared to be logged. A raw diff may contain secrets and can be very
+// large, so secrets are redacted and the diff is truncated to a maximum size.
+// When configured to hash the diff it returns a SHA-256 hash of the redacted
+// diff, and its size.
+func SafeDiff(diff string, o ...DiffOption) string {
+	opts := &diffOptions{maxSize: defaultMaxDiffSize}
+	for _, fn := range o {
+		fn(opts)
+	}
+
+	diff = redact.Redact(diff)
+	if opts.hash {
+		sum := sha256.Sum256([]byte(diff))
+		return fmt.Sprintf("sha256:%s (%d bytes)", hex.EncodeToString(sum[:]), len(diff))
+	}
+	if opts.maxSize > 0 && len(diff) > opts.maxSize {
+		t := truncate(diff, opts.maxSize)
+		return fmt.Sprintf("%s... (truncated %d bytes)", t, len(diff)-len(t))
+	}
+	return diff
+}
+
+// truncate returns at most the first n bytes of the supplied string, without
+// splitting a UTF-8 encoded rune.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,63 @@
+package reconciler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/redact"
+)
+
+func TestSafeDiff(t *testing.T) {
+	type args struct {
+		diff string
+		o    []DiffOption
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Small": {
+			reason: "A small diff without secrets should be returned unchanged.",
+			args:   args{diff: "-a\n+b"},
+			want:   "-a\n+b",
+		},
+		"Redacted": {
+			reason: "Secrets in a diff should be redacted.",
+			args:   args{diff: `-password: "old"` + "\n" + `+password: "new"`},
+			want:   `-password: "` + redact.Placeholder + `"` + "\n" + `+password: "` + redact.Placeholder + `"`,
+		},
+		"Truncated": {
+			reason: "A diff larger than the maximum size should be truncated.",
+			args:   args{diff: "-aaaa\n+bbbb", o: []DiffOption{WithMaxDiffSize(5)}},
+			want:   "-aaaa... (truncated 6 bytes)",
+		},
+		"TruncatedRune": {
+			reason: "A diff should not be truncated in the middle of a rune.",
+			args:   args{diff: "-ééé", o: []DiffOption{WithMaxDiffSize(4)}},
+			want:   "-é... (truncated 4 bytes)",
+		},
+		"Unlimited": {
+			reason: "A diff should not be truncated if the maximum size is zero.",
+			args:   args{diff: strings.Repeat("a", 5000), o: []DiffOption{WithMaxDiffSize(0)}},
+			want:   strings.Repeat("a", 5000),
+		},
+		"Hashed": {
+			reason: "A hash of the diff should be returned if configured.",
+			args:   args{diff: "-a\n+b", o: []DiffOption{WithDiffHash()}},
+			want:   "sha256:6ac335e92334b6391c57349670abe0d50b9c383341937db2fb387c76c7d62b30 (5 bytes)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SafeDiff(tc.args.diff, tc.args.o...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSafeDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
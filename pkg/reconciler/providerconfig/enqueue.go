@@ -0,0 +1,28 @@
+package providerconfig
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+)
+
+// EnqueueRequestForProviderConfig returns an event handler that enqueues a
+// request for the provider config a provider config usage records a usage of.
+// Controllers built around the Reconciler must watch provider config usages
+// using this handler, so that provider configs are reconciled as their usages
+// come and go.
+func EnqueueRequestForProviderConfig() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
+		pcu, ok := o.(resource.ProviderConfigUsage)
+		if !ok {
+			return nil
+		}
+		ref := pcu.GetProviderConfigReference()
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}}}
+	})
+}
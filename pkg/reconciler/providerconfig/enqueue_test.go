@@ -0,0 +1,65 @@
+package providerconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+)
+
+func TestEnqueueRequestForProviderConfig(t *testing.T) {
+	usage := func(ref prv1.Reference) client.Object {
+		pcu := &fake.ProviderConfigUsage{}
+		pcu.SetNamespace("mrns")
+		pcu.SetProviderConfigReference(ref)
+		return pcu
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      client.Object
+		want   []reconcile.Request
+	}{
+		"NotAUsage": {
+			reason: "Nothing should be enqueued for objects that aren't provider config usages.",
+			o:      &fake.Managed{},
+		},
+		"ClusterScoped": {
+			reason: "The cluster scoped provider config the usage references should be enqueued.",
+			o:      usage(prv1.Reference{Name: "default"}),
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "default"}}},
+		},
+		"Namespaced": {
+			reason: "The namespaced provider config the usage references should be enqueued.",
+			o:      usage(prv1.Reference{Name: "default", Namespace: "pcns"}),
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "pcns", Name: "default"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+
+			EnqueueRequestForProviderConfig().Delete(context.Background(), event.DeleteEvent{Object: tc.o}, q)
+
+			var got []reconcile.Request
+			for q.Len() > 0 {
+				r, _ := q.Get()
+				got = append(got, r)
+				q.Done(r)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEnqueueRequestForProviderConfig(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
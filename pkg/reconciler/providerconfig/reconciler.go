@@ -0,0 +1,219 @@
+// Package providerconfig implements the reconciliation of provider configs,
+// blocking their deletion while they are in use by managed resources.
+package providerconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/logging"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+)
+
+const (
+	// Finalizer is the string that is used as finalizer on provider configs
+	// that are in use.
+	Finalizer = "in-use.krateo.io"
+
+	shortWait = 30 * time.Second
+	timeout   = 2 * time.Minute
+)
+
+// Error strings.
+const (
+	errGetPC           = "cannot get provider config"
+	errListPCUs        = "cannot list provider config usages"
+	errDeletePCU       = "cannot delete provider config usage"
+	errUpdateStatus    = "cannot update provider config status"
+	errAddFinalizer    = "cannot add provider config finalizer"
+	errRemoveFinalizer = "cannot remove provider config finalizer"
+	errFmtInUse        = "provider config is in use by %d managed resources"
+)
+
+// Event reasons.
+var reasonAccount = event.RegisterReason("UsageAccounting", "The usages of the provider config could not be accounted for, or are blocking its deletion.")
+
+// ControllerName returns the recommended name for controllers that use this
+// package to reconcile a particular kind of provider config.
+func ControllerName(kind string) string {
+	return "config/" + strings.ToLower(kind)
+}
+
+// The ProviderConfigKinds this reconciler uses.
+type ProviderConfigKinds struct {
+	Config    schema.GroupVersionKind
+	Usage     schema.GroupVersionKind
+	UsageList schema.GroupVersionKind
+}
+
+// A Reconciler reconciles provider configs by accounting for their current
+// usage. A provider config cannot be deleted until it has no users; this is
+// enforced using a finalizer. The Reconciler relies on being requeued when
+// usages change; see EnqueueRequestForProviderConfig.
+type Reconciler struct {
+	client client.Client
+
+	newConfig    func() resource.ProviderConfig
+	newUsageList func() resource.ProviderConfigUsageList
+
+	finalizer resource.Finalizer
+
+	log    logging.Logger
+	record event.Recorder
+}
+
+// A ReconcilerOption configures a Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(l logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = l
+	}
+}
+
+// WithRecorder specifies how the Reconciler should record events.
+func WithRecorder(er event.Recorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.record = er
+	}
+}
+
+// WithFinalizer specifies how the Reconciler should add and remove
+// finalizers to and from provider configs.
+func WithFinalizer(f resource.Finalizer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.finalizer = f
+	}
+}
+
+// NewReconciler returns a Reconciler of provider configs. It panics if asked
+// to reconcile a kind that is not registered with the supplied manager's
+// runtime.Scheme.
+func NewReconciler(m manager.Manager, of ProviderConfigKinds, o ...ReconcilerOption) *Reconciler {
+	nc := func() resource.ProviderConfig {
+		return resource.MustCreateObject(of.Config, m.GetScheme()).(resource.ProviderConfig)
+	}
+	nul := func() resource.ProviderConfigUsageList {
+		return resource.MustCreateObject(of.UsageList, m.GetScheme()).(resource.ProviderConfigUsageList)
+	}
+
+	// Panic early if we've been asked to reconcile a resource kind that has not
+	// been registered with our controller manager's scheme.
+	_, _ = nc(), nul()
+
+	r := &Reconciler{
+		client:       m.GetClient(),
+		newConfig:    nc,
+		newUsageList: nul,
+		finalizer:    resource.NewAPIFinalizer(m.GetClient(), Finalizer),
+		log:          logging.NewNopLogger(),
+		record:       event.NewNopRecorder(),
+	}
+
+	for _, ro := range o {
+		ro(r)
+	}
+
+	return r
+}
+
+// Reconcile a provider config by accounting for the managed resources that
+// are using it, and ensuring it cannot be deleted until it is no longer used.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pc := r.newConfig()
+	if err := r.client.Get(ctx, req.NamespacedName, pc); err != nil {
+		// In case object is not found, most likely the object was deleted and
+		// then disappeared while the event was in the processing queue. We
+		// don't need to take any action in that case.
+		log.Debug(errGetPC, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+
+	log = log.WithValues(
+		"uid", pc.GetUID(),
+		"version", pc.GetResourceVersion(),
+		"name", pc.GetName(),
+	)
+
+	l := r.newUsageList()
+	sel := client.MatchingLabels{
+		resource.LabelKeyProviderConfigName:      pc.GetName(),
+		resource.LabelKeyProviderConfigNamespace: pc.GetNamespace(),
+	}
+	if err := r.client.List(ctx, l, sel); err != nil {
+		log.Debug(errListPCUs, "error", err)
+		r.record.Event(pc, event.Warning(reasonAccount, errors.Wrap(err, errListPCUs)))
+		return reconcile.Result{RequeueAfter: shortWait}, nil
+	}
+
+	users := int64(len(l.GetItems()))
+	for _, pcu := range l.GetItems() {
+		if metav1.GetControllerOf(pcu) == nil {
+			// Usages should always have a controller reference. If this one
+			// has none it's probably been stripped off (e.g. by a backup
+			// restore). We can safely delete it - it's either stale, or will
+			// be recreated next time the relevant managed resource connects.
+			if err := r.client.Delete(ctx, pcu); resource.IgnoreNotFound(err) != nil {
+				log.Debug(errDeletePCU, "error", err)
+				r.record.Event(pc, event.Warning(reasonAccount, errors.Wrap(err, errDeletePCU)))
+				return reconcile.Result{RequeueAfter: shortWait}, nil
+			}
+			users--
+		}
+	}
+	log = log.WithValues("usages", users)
+
+	if meta.WasDeleted(pc) {
+		if users > 0 {
+			msg := fmt.Sprintf(errFmtInUse, users)
+			log.Debug("Blocking deletion of provider config", "reason", msg)
+			r.record.Event(pc, event.Warning(reasonAccount, errors.New(msg)))
+
+			// Our usages are watched using EnqueueRequestForProviderConfig,
+			// so we'll be requeued when they go.
+			pc.SetUsers(users)
+			pc.SetConditions(prv1.Deleting().WithMessage(msg))
+			return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, pc), errUpdateStatus)
+		}
+
+		// We're being deleted and we have no users. Remove the finalizer so
+		// that we can be deleted.
+		if err := r.finalizer.RemoveFinalizer(ctx, pc); err != nil {
+			log.Debug(errRemoveFinalizer, "error", err)
+			r.record.Event(pc, event.Warning(reasonAccount, errors.Wrap(err, errRemoveFinalizer)))
+			return reconcile.Result{RequeueAfter: shortWait}, nil
+		}
+
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	if err := r.finalizer.AddFinalizer(ctx, pc); err != nil {
+		log.Debug(errAddFinalizer, "error", err)
+		r.record.Event(pc, event.Warning(reasonAccount, errors.Wrap(err, errAddFinalizer)))
+		return reconcile.Result{RequeueAfter: shortWait}, nil
+	}
+
+	// There's no need to requeue explicitly - our usages are watched using
+	// EnqueueRequestForProviderConfig.
+	pc.SetUsers(users)
+	return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, pc), errUpdateStatus)
+}
@@ -0,0 +1,240 @@
+package providerconfig
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/event"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+var _ reconcile.Reconciler = &Reconciler{}
+
+type ProviderConfigUsageList struct {
+	metav1.ListMeta
+	Items []*fake.ProviderConfigUsage
+}
+
+func (l *ProviderConfigUsageList) GetObjectKind() schema.ObjectKind {
+	return schema.EmptyObjectKind
+}
+
+func (l *ProviderConfigUsageList) DeepCopyObject() runtime.Object {
+	out := &ProviderConfigUsageList{}
+	j, err := json.Marshal(l)
+	if err != nil {
+		panic(err)
+	}
+	_ = json.Unmarshal(j, out)
+	return out
+}
+
+func (l *ProviderConfigUsageList) GetItems() []resource.ProviderConfigUsage {
+	out := make([]resource.ProviderConfigUsage, len(l.Items))
+	for i := range l.Items {
+		out[i] = l.Items[i]
+	}
+	return out
+}
+
+func TestReconciler(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+	ctrl := true
+
+	kinds := ProviderConfigKinds{
+		Config:    fake.GVK(&fake.ProviderConfig{}),
+		UsageList: fake.GVK(&ProviderConfigUsageList{}),
+	}
+	scheme := fake.SchemeWith(&fake.ProviderConfig{}, &ProviderConfigUsageList{})
+
+	usages := func(n int, controlled bool) test.ObjectListFn {
+		return func(o client.ObjectList) error {
+			l := o.(*ProviderConfigUsageList)
+			for i := 0; i < n; i++ {
+				pcu := &fake.ProviderConfigUsage{}
+				if controlled {
+					pcu.SetOwnerReferences([]metav1.OwnerReference{{UID: "mr", Controller: &ctrl}})
+				}
+				l.Items = append(l.Items, pcu)
+			}
+			return nil
+		}
+	}
+
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		o      []ReconcilerOption
+		want   want
+	}{
+		"GetProviderConfigError": {
+			reason: "We should return any error encountered getting the provider config.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, errGetPC)},
+		},
+		"ProviderConfigNotFound": {
+			reason: "We should return without requeueing if the provider config no longer exists.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, ""))},
+			want:   want{result: reconcile.Result{}},
+		},
+		"ListUsagesError": {
+			reason: "We should requeue after a short wait if we encounter an error listing usages.",
+			c: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(errBoom),
+			},
+			want: want{result: reconcile.Result{RequeueAfter: shortWait}},
+		},
+		"DeleteUncontrolledUsageError": {
+			reason: "We should requeue after a short wait if we encounter an error deleting an uncontrolled usage.",
+			c: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil),
+				MockList:   test.NewMockListFn(nil, usages(1, false)),
+				MockDelete: test.NewMockDeleteFn(errBoom),
+			},
+			want: want{result: reconcile.Result{RequeueAfter: shortWait}},
+		},
+		"BlockDeleteWhileInUse": {
+			reason: "We should record the number of users and block deletion while the provider config is in use.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetDeletionTimestamp(&now)
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil, usages(2, true)),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+					want := &fake.ProviderConfig{}
+					want.SetDeletionTimestamp(&now)
+					want.SetUsers(2)
+					want.SetConditions(prv1.Deleting().WithMessage("provider config is in use by 2 managed resources"))
+					if diff := cmp.Diff(want, o, test.EquateConditions()); diff != "" {
+						t.Errorf("Status().Update(...): -want, +got:\n%s", diff)
+					}
+					return nil
+				}),
+			},
+			want: want{result: reconcile.Result{Requeue: false}},
+		},
+		"RemoveFinalizerError": {
+			reason: "We should requeue after a short wait if we encounter an error removing our finalizer.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetDeletionTimestamp(&now)
+					return nil
+				}),
+				MockList:   test.NewMockListFn(nil, usages(1, false)),
+				MockDelete: test.NewMockDeleteFn(nil),
+			},
+			o: []ReconcilerOption{
+				WithFinalizer(resource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ resource.Object) error { return errBoom }}),
+			},
+			want: want{result: reconcile.Result{RequeueAfter: shortWait}},
+		},
+		"RemoveFinalizerSuccess": {
+			reason: "We should remove our finalizer when a provider config with no users is deleted.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetDeletionTimestamp(&now)
+					return nil
+				}),
+				MockList: test.NewMockListFn(nil),
+			},
+			o: []ReconcilerOption{
+				WithFinalizer(resource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }}),
+			},
+			want: want{result: reconcile.Result{Requeue: false}},
+		},
+		"AddFinalizerError": {
+			reason: "We should requeue after a short wait if we encounter an error adding our finalizer.",
+			c: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(nil),
+			},
+			o: []ReconcilerOption{
+				WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return errBoom }}),
+			},
+			want: want{result: reconcile.Result{RequeueAfter: shortWait}},
+		},
+		"ListUsagesOfNamespacedProviderConfig": {
+			reason: "We should only count the usages of the provider config in our namespace.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetName("default")
+					o.SetNamespace("coolns")
+					return nil
+				}),
+				MockList: func(_ context.Context, _ client.ObjectList, opts ...client.ListOption) error {
+					want := []client.ListOption{client.MatchingLabels{
+						resource.LabelKeyProviderConfigName:      "default",
+						resource.LabelKeyProviderConfigNamespace: "coolns",
+					}}
+					if diff := cmp.Diff(want, opts); diff != "" {
+						t.Errorf("List(...): -want options, +got:\n%s", diff)
+					}
+					return nil
+				},
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			},
+			o: []ReconcilerOption{
+				WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }}),
+			},
+			want: want{result: reconcile.Result{Requeue: false}},
+		},
+		"UpdateUsers": {
+			reason: "We should record the number of users of the provider config.",
+			c: &test.MockClient{
+				MockGet:  test.NewMockGetFn(nil),
+				MockList: test.NewMockListFn(nil, usages(3, true)),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+					if got := o.(*fake.ProviderConfig).GetUsers(); got != 3 {
+						t.Errorf("Status().Update(...): want 3 users, got %d", got)
+					}
+					return nil
+				}),
+			},
+			o: []ReconcilerOption{
+				WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error { return nil }}),
+			},
+			want: want{result: reconcile.Result{Requeue: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewReconciler(&fake.Manager{Client: tc.c, Scheme: scheme}, kinds, tc.o...)
+			got, err := r.Reconcile(context.Background(), reconcile.Request{})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEventReasonsRegistered(t *testing.T) {
+	if err := event.DefaultReasons.Validate(event.Normal(reasonAccount, "")); err != nil {
+		t.Errorf("Validate(...): %v", err)
+	}
+}
@@ -6,18 +6,28 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/audit"
 	"github.com/krateoplatformops/provider-runtime/pkg/errors"
 	"github.com/krateoplatformops/provider-runtime/pkg/event"
 	"github.com/krateoplatformops/provider-runtime/pkg/logging"
 	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ratelimiter"
+	"github.com/krateoplatformops/provider-runtime/pkg/reference"
 	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	"github.com/krateoplatformops/provider-runtime/pkg/tracing"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -30,6 +40,7 @@ const (
 	reconcileTimeout     = 1 * time.Minute
 
 	defaultpollInterval = 1 * time.Minute
+	defaultDeadlineLead = 1 * time.Minute
 	defaultGracePeriod  = 30 * time.Second
 )
 
@@ -44,6 +55,8 @@ const (
 	errReconcileUpdate          = "update failed"
 	errReconcileDelete          = "delete failed"
 	errExternalResourceNotExist = "external resource does not exist"
+	errDeleteExpired            = "cannot delete expired managed resource"
+	errFmtExternalNameChanged   = "external name cannot be changed from %q to %q after the external resource was created"
 )
 
 // Event reasons.
@@ -65,9 +78,46 @@ const (
 	reasonUpdated event.Reason = "UpdatedExternalResource"
 	reasonPending event.Reason = "PendingExternalResource"
 
-	reasonReconciliationPaused event.Reason = "ReconciliationPaused"
+	reasonReconciliationPaused    event.Reason = "ReconciliationPaused"
+	reasonInUseByReferencers      event.Reason = "InUseByReferencers"
+	reasonWaitingForDependencies  event.Reason = "WaitingForDependencies"
+	reasonDependencyCycle         event.Reason = "DependencyCycle"
+	reasonExpired                 event.Reason = "Expired"
+	reasonInvalidManagementPolicy event.Reason = "InvalidManagementPolicy"
+	reasonExternalNameChanged     event.Reason = "ExternalNameChanged"
 )
 
+func init() {
+	for r, d := range map[event.Reason]string{
+		reasonCannotConnect:       "The provider could not connect to the external system.",
+		reasonCannotDisconnect:    "The provider could not disconnect from the external system.",
+		reasonCannotInitialize:    "The managed resource could not be initialized.",
+		reasonCannotResolveRefs:   "The references of the managed resource could not be resolved.",
+		reasonCannotObserve:       "The external resource could not be observed.",
+		reasonCannotCreate:        "The external resource could not be created.",
+		reasonCannotDelete:        "The external resource could not be deleted.",
+		reasonCannotPublish:       "The connection details of the external resource could not be published.",
+		reasonCannotUnpublish:     "The connection details of the external resource could not be unpublished.",
+		reasonCannotUpdate:        "The external resource could not be updated.",
+		reasonCannotUpdateManaged: "The managed resource could not be updated.",
+
+		reasonDeleted: "The external resource was deleted.",
+		reasonCreated: "The external resource was created.",
+		reasonUpdated: "The external resource was updated.",
+		reasonPending: "The external resource is being created.",
+
+		reasonReconciliationPaused:    "Reconciliation of the managed resource is paused.",
+		reasonInUseByReferencers:      "The managed resource cannot be deleted while other resources reference it.",
+		reasonWaitingForDependencies:  "The managed resource is waiting for its dependencies to become ready.",
+		reasonDependencyCycle:         "The dependencies of the managed resource form a cycle.",
+		reasonExpired:                 "The managed resource outlived its time to live and was deleted.",
+		reasonInvalidManagementPolicy: "The management policy of the managed resource is not valid.",
+		reasonExternalNameChanged:     "The external name of the managed resource was changed after creation.",
+	} {
+		event.RegisterReason(r, d)
+	}
+}
+
 // ControllerName returns the recommended name for controllers that use this
 // package to reconcile a particular kind of managed resource.
 func ControllerName(kind string) string {
@@ -89,6 +139,96 @@ func (fn CriticalAnnotationUpdateFn) UpdateCriticalAnnotations(ctx context.Conte
 	return fn(ctx, o)
 }
 
+// An Initializer may initialize a managed resource before it is
+// reconciled with its external resource.
+type Initializer interface {
+	Initialize(ctx context.Context, mg resource.Managed) error
+}
+
+// An InitializerChain chains multiple managed initializers.
+type InitializerChain []Initializer
+
+// Initialize calls each Initializer serially. It returns the first
+// error it encounters, if any.
+func (cc InitializerChain) Initialize(ctx context.Context, mg resource.Managed) error {
+	for _, c := range cc {
+		if err := c.Initialize(ctx, mg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An InitializerFn is a function that satisfies the Initializer
+// interface.
+type InitializerFn func(ctx context.Context, mg resource.Managed) error
+
+// Initialize calls InitializerFn function.
+func (m InitializerFn) Initialize(ctx context.Context, mg resource.Managed) error {
+	return m(ctx, mg)
+}
+
+// A ReferenceResolver resolves references to other managed resources.
+type ReferenceResolver interface {
+	// ResolveReferences resolves all fields in the supplied managed resource
+	// that are references to other managed resources by updating corresponding
+	// fields, for example setting a spec field to the external name of a
+	// referenced resource.
+	ResolveReferences(ctx context.Context, mg resource.Managed) error
+}
+
+// A ReferenceResolverFn is a function that satisfies the ReferenceResolver
+// interface.
+type ReferenceResolverFn func(context.Context, resource.Managed) error
+
+// ResolveReferences calls ReferenceResolverFn function.
+func (m ReferenceResolverFn) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	return m(ctx, mg)
+}
+
+// A DependencyWaiter determines whether the objects a managed resource
+// depends on are ready.
+type DependencyWaiter interface {
+	// WaitingFor returns the names of the objects the supplied managed
+	// resource depends on that are not yet ready.
+	WaitingFor(ctx context.Context, mg resource.Managed) ([]string, error)
+}
+
+// A DependencyWaiterFn is a function that satisfies the DependencyWaiter
+// interface.
+type DependencyWaiterFn func(ctx context.Context, mg resource.Managed) ([]string, error)
+
+// WaitingFor calls DependencyWaiterFn function.
+func (fn DependencyWaiterFn) WaitingFor(ctx context.Context, mg resource.Managed) ([]string, error) {
+	return fn(ctx, mg)
+}
+
+// A ReferencedByFinalizer delays the deletion of a managed resource while
+// other managed resources reference it.
+type ReferencedByFinalizer interface {
+	resource.Finalizer
+
+	// ReferencedBy returns the names of the managed resources that reference
+	// the supplied managed resource.
+	ReferencedBy(ctx context.Context, o client.Object) ([]string, error)
+}
+
+// A NopReferencedByFinalizer never delays the deletion of a managed resource.
+type NopReferencedByFinalizer struct {
+	resource.Finalizer
+}
+
+// NewNopReferencedByFinalizer returns a ReferencedByFinalizer that does
+// nothing.
+func NewNopReferencedByFinalizer() NopReferencedByFinalizer {
+	return NopReferencedByFinalizer{Finalizer: resource.NewNopFinalizer()}
+}
+
+// ReferencedBy always returns no referencers.
+func (NopReferencedByFinalizer) ReferencedBy(_ context.Context, _ client.Object) ([]string, error) {
+	return nil, nil
+}
+
 // An ExternalConnecter produces a new ExternalClient given the supplied
 // Managed resource.
 type ExternalConnecter interface {
@@ -299,6 +439,13 @@ type ExternalObservation struct {
 	// finding where the observed diverges from the desired state.
 	// The string should be a cmp.Diff that details the difference.
 	Diff string
+
+	// Deadlines are times at which something the external resource depends
+	// on expires, for example a certificate, token, or lease. The Provider
+	// Runtime reconciles the managed resource shortly before the earliest
+	// deadline, rather than waiting for the poll interval, so that it can be
+	// renewed in time.
+	Deadlines []time.Time
 }
 
 // A Reconciler reconciles managed resources by creating and managing the
@@ -308,11 +455,20 @@ type ExternalObservation struct {
 type Reconciler struct {
 	client     client.Client
 	newManaged func() resource.Managed
-
-	pollInterval        time.Duration
-	pollIntervalHook    PollIntervalHook
-	timeout             time.Duration
-	creationGracePeriod time.Duration
+	kind       schema.GroupVersionKind
+
+	pollInterval           time.Duration
+	pollIntervalHook       PollIntervalHook
+	deadlineLead           time.Duration
+	timeout                time.Duration
+	creationGracePeriod    time.Duration
+	referenceRetryInterval time.Duration
+	createAnnotationsTTL   time.Duration
+	immutableExternalName  bool
+	skipUnchangedStatus    bool
+	providerName           string
+	providerVersion        string
+	diffOptions            []DiffOption
 
 	// The below structs embed the set of interfaces used to implement the
 	// managed resource reconciler. We do this primarily for readability, so
@@ -321,19 +477,31 @@ type Reconciler struct {
 	external mrExternal
 	managed  mrManaged
 
-	log    logging.Logger
-	record event.Recorder
+	referencedBy ReferencedByFinalizer
+
+	log      logging.Logger
+	record   event.Recorder
+	tracer   trace.Tracer
+	audit    audit.Logger
+	feedback ratelimiter.Feedback
+	policies *RateLimitPolicies
 }
 
 type mrManaged struct {
+	Initializer
 	CriticalAnnotationUpdater
 	resource.Finalizer
+	ReferenceResolver
+	DependencyWaiter
 }
 
 func defaultMRManaged(m manager.Manager) mrManaged {
 	return mrManaged{
+		Initializer:               InitializerChain{NewExternalNameTemplate(m.GetClient())},
 		CriticalAnnotationUpdater: NewRetryingCriticalAnnotationUpdater(m.GetClient()),
 		Finalizer:                 resource.NewAPIFinalizer(m.GetClient(), FinalizerName),
+		ReferenceResolver:         NewAPISimpleReferenceResolver(m.GetClient()),
+		DependencyWaiter:          NewAPIDependencyWaiter(m.GetClient()),
 	}
 }
 
@@ -380,6 +548,18 @@ func WithPollInterval(after time.Duration) ReconcilerOption {
 	}
 }
 
+// WithReferenceRetryInterval specifies how long the Reconciler should wait
+// before retrying reference resolution when a referenced resource does not
+// exist yet. Such failures are expected while dependencies are being created,
+// so retrying after a fixed, typically short, interval avoids the growing
+// backoff used for other errors. The generic error backoff is used if the
+// interval is zero, which is the default.
+func WithReferenceRetryInterval(after time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.referenceRetryInterval = after
+	}
+}
+
 // WithPollIntervalHook adds a hook that can be used to configure the
 // delay before an up-to-date resource is reconciled again after a successful
 // reconcile. If this option is passed multiple times, only the latest hook
@@ -401,6 +581,15 @@ func WithPollJitterHook(jitter time.Duration) ReconcilerOption {
 	})
 }
 
+// WithDeadlineLead configures how long before the earliest deadline reported
+// by an ExternalObservation the managed resource is reconciled again. The
+// default is one minute.
+func WithDeadlineLead(d time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.deadlineLead = d
+	}
+}
+
 // WithCreationGracePeriod configures an optional period during which we will
 // wait for the external API to report that a newly created external resource
 // exists. This allows us to tolerate eventually consistent APIs that do not
@@ -412,6 +601,46 @@ func WithCreationGracePeriod(d time.Duration) ReconcilerOption {
 	}
 }
 
+// WithExternalCreateAnnotationCleanup configures the Reconciler to remove the
+// annotations that track creation of an external resource once the managed
+// resource has been Ready for the supplied duration. Creation tracking
+// annotations are kept indefinitely by default.
+func WithExternalCreateAnnotationCleanup(after time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.createAnnotationsTTL = after
+	}
+}
+
+// WithImmutableExternalName configures the Reconciler to record the external
+// name with which an external resource was created, and to revert any later
+// changes to the external name. Renaming a managed resource's external
+// resource usually orphans it, or causes a duplicate to be created.
+func WithImmutableExternalName() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.immutableExternalName = true
+	}
+}
+
+// WithUnchangedStatusSkipping configures the Reconciler to skip updating the
+// status of a managed resource whose external resource is up to date when its
+// status is semantically unchanged, which reduces API server writes. Note that
+// the last sync attempt and successful sync times are not refreshed when the
+// status update is skipped.
+func WithUnchangedStatusSkipping() ReconcilerOption {
+	return func(r *Reconciler) {
+		r.skipUnchangedStatus = true
+	}
+}
+
+// WithDiffLogging specifies how the Reconciler prepares the diffs returned by
+// the ExternalClient's Observe method to be logged. Diffs are always redacted,
+// and by default are truncated to 4096 bytes. See SafeDiff.
+func WithDiffLogging(o ...DiffOption) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.diffOptions = o
+	}
+}
+
 // WithExternalConnecter specifies how the Reconciler should connect to the API
 // used to sync and delete external resources.
 func WithExternalConnecter(c ExternalConnecter) ReconcilerOption {
@@ -428,6 +657,27 @@ func WithExternalConnectDisconnecter(c ExternalConnectDisconnecter) ReconcilerOp
 	}
 }
 
+// WithInitializers specifies how the Reconciler should initialize a managed
+// resource before it is reconciled with its external resource. By default
+// its external name is rendered from its external name template annotation,
+// if any.
+func WithInitializers(i ...Initializer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.managed.Initializer = InitializerChain(i)
+	}
+}
+
+// WithProviderIdentity specifies that the Reconciler should record the name
+// and version of the provider on the managed resources it reconciles, using
+// the managed-by and provider-version annotations, and to annotate the events
+// it records with the version. It runs after any configured Initializers.
+func WithProviderIdentity(name, version string) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.providerName = name
+		r.providerVersion = version
+	}
+}
+
 // WithCriticalAnnotationUpdater specifies how the Reconciler should update a
 // managed resource's critical annotations. Implementations typically contain
 // some kind of retry logic to increase the likelihood that critical annotations
@@ -446,6 +696,32 @@ func WithFinalizer(f resource.Finalizer) ReconcilerOption {
 	}
 }
 
+// WithReferenceResolver specifies how the Reconciler should resolve any
+// inter-resource references it encounters while reconciling managed resources.
+func WithReferenceResolver(rr ReferenceResolver) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.managed.ReferenceResolver = rr
+	}
+}
+
+// WithDependencyWaiter specifies how the Reconciler should determine whether
+// the objects a managed resource depends on are ready before creating its
+// external resource.
+func WithDependencyWaiter(w DependencyWaiter) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.managed.DependencyWaiter = w
+	}
+}
+
+// WithReferencedByFinalizer specifies how the Reconciler should delay the
+// deletion of managed resources that are referenced by other managed
+// resources. Deletion is not delayed by default.
+func WithReferencedByFinalizer(f ReferencedByFinalizer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.referencedBy = f
+	}
+}
+
 // WithLogger specifies how the Reconciler should log messages.
 func WithLogger(l logging.Logger) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -453,6 +729,45 @@ func WithLogger(l logging.Logger) ReconcilerOption {
 	}
 }
 
+// WithTracer specifies how the Reconciler should trace reconciles. Each
+// reconcile is traced as a span, with child spans for each call to the
+// ExternalClient and each status update. The context passed to the
+// ExternalClient carries its span, so that provider SDK calls may be traced
+// as its children. Reconciles are not traced by default.
+func WithTracer(t trace.Tracer) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.tracer = t
+	}
+}
+
+// WithAuditLogger specifies how the Reconciler should audit the calls it makes
+// to the ExternalClient. Each call is recorded with its outcome and duration.
+// Calls are not audited by default.
+func WithAuditLogger(l audit.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.audit = l
+	}
+}
+
+// WithRateLimiterFeedback specifies a rate limiter, typically an
+// ratelimiter.AdaptiveRateLimiter, to which the Reconciler should report the
+// outcome of the calls it makes to the ExternalClient, so that it can adapt
+// its rate to the health of the external system.
+func WithRateLimiterFeedback(f ratelimiter.Feedback) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.feedback = f
+	}
+}
+
+// WithRateLimitPolicies specifies where the Reconciler should record the rate
+// limiting policies of the managed resources it reads. The Reconciler records
+// them in its own RateLimitPolicies by default; see RateLimiter.
+func WithRateLimitPolicies(p *RateLimitPolicies) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.policies = p
+	}
+}
+
 // WithRecorder specifies how the Reconciler should record events.
 func WithRecorder(er event.Recorder) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -479,26 +794,129 @@ func NewReconciler(m manager.Manager, of resource.ManagedKind, o ...ReconcilerOp
 	r := &Reconciler{
 		client:              m.GetClient(),
 		newManaged:          nm,
+		kind:                schema.GroupVersionKind(of),
 		pollInterval:        defaultpollInterval,
 		pollIntervalHook:    defaultPollIntervalHook,
+		deadlineLead:        defaultDeadlineLead,
 		creationGracePeriod: defaultGracePeriod,
 		timeout:             reconcileTimeout,
 		managed:             defaultMRManaged(m),
 		external:            defaultMRExternal(),
+		referencedBy:        NewNopReferencedByFinalizer(),
 		log:                 logging.NewNopLogger(),
 		record:              event.NewNopRecorder(),
+		tracer:              tracing.NewNopTracer(),
+		audit:               audit.NewNopLogger(),
+		policies:            NewRateLimitPolicies(),
 	}
 
 	for _, ro := range o {
 		ro(r)
 	}
 
+	// The provider identity is added once all options have run, so that
+	// WithInitializers doesn't replace it.
+	if r.providerName != "" || r.providerVersion != "" {
+		r.managed.Initializer = InitializerChain{r.managed.Initializer, NewProviderIdentity(r.client, r.providerName, r.providerVersion)}
+	}
+
 	return r
 }
 
+// RateLimiter wraps the supplied rate limiter so that it honors the rate
+// limiting policies of the managed resources this Reconciler reads: their
+// priority, and their reconcile policy. It should be used as the rate limiter
+// of the controller that runs this Reconciler.
+func (r *Reconciler) RateLimiter(l workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimiter[reconcile.Request] {
+	return NewPolicyRateLimiter(r.policies, NewPriorityRateLimiter(r.policies, l), r.pollInterval)
+}
+
 // Reconcile a managed resource with an external resource.
-func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) { //nolint:gocyclo // See note below.
-	log := r.log.WithValues("request", req)
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := r.tracer.Start(ctx, tracing.SpanReconcile, trace.WithAttributes(
+		tracing.AttributeKeyNamespace.String(req.Namespace),
+		tracing.AttributeKeyName.String(req.Name),
+	))
+	result, err := r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// updateStatus updates the status of the supplied managed resource, tracing
+// the update.
+func (r *Reconciler) updateStatus(ctx context.Context, managed resource.Managed) error {
+	ctx, span := r.tracer.Start(ctx, tracing.SpanUpdateStatus)
+	err := r.client.Status().Update(ctx, managed)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// setConditions sets the supplied conditions on the supplied managed resource,
+// recording its current generation as the generation at which they were
+// observed. If they include a Synced condition the outcome of the reconcile is recorded
+// in the status of managed resources that support it: a successful reconcile
+// records the generation that was reconciled and resets the failure count,
+// while a failed reconcile increments it.
+func setConditions(managed resource.Managed, c ...prv1.Condition) {
+	resource.SetObservedConditions(managed, c...)
+
+	for _, cond := range c {
+		if cond.Type != prv1.TypeSynced {
+			continue
+		}
+		switch cond.Reason {
+		case prv1.ReasonReconcileSuccess:
+			if o, ok := managed.(resource.GenerationObserver); ok {
+				o.SetObservedGeneration(managed.GetGeneration())
+			}
+			if fc, ok := managed.(resource.FailureCounter); ok {
+				fc.SetFailures(0)
+			}
+		case prv1.ReasonReconcileError:
+			if fc, ok := managed.(resource.FailureCounter); ok {
+				fc.SetFailures(fc.GetFailures() + 1)
+			}
+		}
+	}
+}
+
+// externalCall starts tracing a call to the ExternalClient, in a span named
+// after the operation. The returned function ends the span and records the
+// call and its outcome with the audit logger and the rate limiter feedback,
+// if any.
+func (r *Reconciler) externalCall(ctx context.Context, managed resource.Managed, reconcileID types.UID, op audit.Operation) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := r.tracer.Start(ctx, string(op))
+	return ctx, func(err error) {
+		tracing.EndSpan(span, err)
+
+		rec := audit.NewRecord(op, start, err)
+		rec.ReconcileID = string(reconcileID)
+		rec.Group, rec.Version, rec.Kind = r.kind.Group, r.kind.Version, r.kind.Kind
+		rec.Namespace, rec.Name = managed.GetNamespace(), managed.GetName()
+		rec.ExternalName = meta.GetExternalName(managed)
+		r.audit.Log(rec)
+
+		if r.feedback != nil {
+			r.feedback.Record(err)
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) { //nolint:gocyclo // See note below.
+	// Our logs, events, traces, and audit records are annotated with this
+	// ID, so that they can be correlated. It's passed to the ExternalClient
+	// in the context, so that providers can include it in their own
+	// artifacts.
+	reconcileID, ok := ReconcileIDFromContext(ctx)
+	if !ok {
+		reconcileID = uuid.NewUUID()
+		ctx = WithReconcileID(ctx, reconcileID)
+	}
+
+	// Errors returned by external clients may include credentials, which
+	// must not be logged, even at debug level.
+	log := logging.NewRedactingLogger(r.log).WithValues("request", req, "reconcile-id", reconcileID)
 	log.Debug("Reconciling")
 
 	ctx, cancel := context.WithTimeout(ctx, r.timeout+reconcileGracePeriod)
@@ -512,25 +930,160 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// There's no need to requeue if we no longer exist. Otherwise we'll be
 		// requeued implicitly because we return an error.
 		log.Debug("Cannot get managed resource", "error", err)
+		if kerrors.IsNotFound(err) {
+			r.policies.Forget(req)
+		}
 		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetManaged)
 	}
+	r.policies.Record(req, managed)
 
-	record := r.record.WithAnnotations("external-name", meta.GetExternalName(managed))
+	// Keep the managed resource as we observed it so that we can tell whether
+	// its status has changed, if we're asked to.
+	var observed resource.Managed
+	if r.skipUnchangedStatus {
+		observed = managed.DeepCopyObject().(resource.Managed)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.AttributeKeyReconcileID.String(string(reconcileID)),
+		tracing.AttributeKeyExternalName.String(meta.GetExternalName(managed)),
+	)
+
+	record := r.record.WithAnnotations(
+		event.AnnotationKeyReconcileID, string(reconcileID),
+		event.AnnotationKeyExternalName, meta.GetExternalName(managed),
+	)
+	if r.providerVersion != "" {
+		record = record.WithAnnotations(event.AnnotationKeyProviderVersion, r.providerVersion)
+	}
 	log = log.WithValues(
 		"uid", managed.GetUID(),
 		"version", managed.GetResourceVersion(),
 		"external-name", meta.GetExternalName(managed),
 	)
 
+	// Log the managed resource as verbosely as it asks to be logged.
+	if level, err := meta.GetLogLevel(managed); err != nil {
+		log.Warn("Ignoring invalid log level annotation", "annotation", meta.AnnotationKeyLogLevel, "error", err)
+	} else {
+		log = logging.WithLevel(log, level)
+	}
+
+	// A managed resource's reconcile policy may override how often we poll
+	// its external resource.
+	pollInterval := r.pollInterval
+	if d := reconcilePolicyPollInterval(managed); d > 0 {
+		pollInterval = d
+	}
+
 	// Check the pause annotation and return if it has the value "true"
 	// after logging, publishing an event and updating the SYNC status condition
 	if meta.IsPaused(managed) {
-		log.Debug("Reconciliation is paused via the pause annotation", "annotation", meta.AnnotationKeyReconciliationPaused, "value", "true")
-		record.Event(managed, event.Normal(reasonReconciliationPaused, "Reconciliation is paused via the pause annotation"))
-		managed.SetConditions(prv1.ReconcilePaused())
+		msg := "Reconciliation is paused via the pause annotation"
+		paused := prv1.ReconcilePaused()
+		if reason := meta.GetPausedReason(managed); reason != "" {
+			msg += ": " + reason
+			paused = paused.WithMessage(reason)
+		}
+		log.Debug("Reconciliation is paused via the pause annotation", "annotation", meta.AnnotationKeyReconciliationPaused, "value", "true", "reason", meta.GetPausedReason(managed))
+		record.Event(managed, event.Normal(reasonReconciliationPaused, msg))
+		setConditions(managed, paused)
 		// if the pause annotation is removed, we will have a chance to reconcile again and resume
 		// and if status update fails, we will reconcile again to retry to update the status
-		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		return reconcile.Result{}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+	}
+
+	// Record when we attempted to sync the managed resource, and later when we
+	// succeeded, so that tooling can tell how stale it is. These times are
+	// persisted by our status updates.
+	syncTime := metav1.Now()
+	resource.RecordSyncAttempt(managed, syncTime)
+
+	// An unknown management policy is most likely a typo. We refuse to proceed
+	// rather than guess which actions the policy was meant to allow, unless
+	// the managed resource is being deleted, in which case its external
+	// resource is orphaned. The annotation is ignored if the managed resource
+	// specifies management policies in its spec.
+	if err := meta.CheckManagementPolicy(managed); err != nil && !meta.WasDeleted(managed) {
+		log.Debug("Cannot determine managed resource management policy", "error", err)
+		record.Event(managed, event.Warning(reasonInvalidManagementPolicy, err))
+		setConditions(managed, prv1.ReconcileError(err))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+	}
+
+	// If managed resource has a TTL and it has expired we delete it. This only
+	// deletes the managed resource; its external resource is deleted or
+	// orphaned according to its deletion policy when we're requeued to
+	// finalize it. A managed resource that is being deleted no longer
+	// expires, so an invalid TTL mustn't prevent it from being finalized.
+	var expiry time.Time
+	if !meta.WasDeleted(managed) {
+		var err error
+		if expiry, err = meta.GetExpiry(managed); err != nil {
+			log.Debug("Cannot determine managed resource expiry", "error", err)
+			record.Event(managed, event.Warning(reasonCannotInitialize, err))
+			setConditions(managed, prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+	}
+	if !expiry.IsZero() && !time.Now().Before(expiry) {
+		log.Debug("Managed resource has expired", "annotation", meta.AnnotationKeyTTL, "expiry", expiry)
+		if err := r.client.Delete(ctx, managed); resource.IgnoreNotFound(err) != nil {
+			log.Debug("Cannot delete expired managed resource", "error", err)
+			setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errDeleteExpired)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+		record.Event(managed, event.Normal(reasonExpired, "Deleting managed resource because its TTL has expired"))
+		// We'll be requeued implicitly when our deletion timestamp is set.
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	// If a sync was requested we clear the request before proceeding with a
+	// full reconcile, so that the same request doesn't trigger another. The
+	// update will fail if we're operating on a stale version of our resource.
+	if meta.IsSyncRequested(managed) && !meta.WasDeleted(managed) {
+		log.Debug("Sync requested via the sync-now annotation", "annotation", meta.AnnotationKeySyncNow, "value", managed.GetAnnotations()[meta.AnnotationKeySyncNow])
+		meta.RemoveAnnotations(managed, meta.AnnotationKeySyncNow)
+		if err := r.client.Update(ctx, managed); err != nil {
+			log.Debug(errUpdateManagedAnnotations, "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			record.Event(managed, event.Warning(reasonCannotUpdateManaged, errors.Wrap(err, errUpdateManagedAnnotations)))
+			setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errUpdateManagedAnnotations)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+
+		// The update replaced our managed resource with the API server's copy,
+		// whose status doesn't include our sync attempt yet.
+		resource.RecordSyncAttempt(managed, syncTime)
+	}
+
+	// If managed resource has a deletion timestamp but other managed resources
+	// still reference it we delay its deletion, regardless of its deletion
+	// policy, so as not to break them. We'll be requeued with backoff until
+	// they no longer reference it.
+	if meta.WasDeleted(managed) {
+		referencers, err := r.referencedBy.ReferencedBy(ctx, managed)
+		if err != nil {
+			log.Debug("Cannot determine managed resource referencers", "error", err)
+			setConditions(managed, prv1.Deleting(), prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+		if len(referencers) > 0 {
+			log.Debug("Managed resource is in use by referencers", "referencers", referencers)
+			record.Event(managed, event.Normal(reasonInUseByReferencers, "Waiting for referencers to stop referencing managed resource before deleting it"))
+			setConditions(managed, prv1.Deleting(), prv1.InUseByReferencers(referencers...))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+		if err := r.referencedBy.RemoveFinalizer(ctx, managed); err != nil {
+			log.Debug("Cannot remove managed resource referenced-by finalizer", "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			setConditions(managed, prv1.Deleting(), prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
 	}
 
 	// If managed resource has a deletion timestamp and and a deletion policy of
@@ -548,8 +1101,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			if kerrors.IsConflict(err) {
 				return reconcile.Result{Requeue: true}, nil
 			}
-			managed.SetConditions(prv1.Deleting(), prv1.ReconcileError(err))
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.Deleting(), prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 
 		// We've successfully unpublished our managed resource's connection
@@ -560,6 +1113,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{Requeue: false}, nil
 	}
 
+	if !meta.WasDeleted(managed) {
+		if err := r.managed.Initialize(ctx, managed); err != nil {
+			// If this is the first time we encounter this issue we'll be
+			// requeued implicitly when we update our status with the new error
+			// condition. If not, we requeue explicitly, which will trigger
+			// backoff.
+			log.Debug("Cannot initialize managed resource", "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			record.Event(managed, event.Warning(reasonCannotInitialize, err))
+			setConditions(managed, prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+	}
+
 	// If we started but never completed creation of an external resource we
 	// may have lost critical information. For example if we didn't persist
 	// an updated external name we've leaked a resource. The safest thing to
@@ -567,11 +1136,102 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	if meta.ExternalCreateIncomplete(managed) {
 		log.Debug(errCreateIncomplete)
 		record.Event(managed, event.Warning(reasonCannotInitialize, errors.New(errCreateIncomplete)))
-		managed.SetConditions(prv1.Creating(), prv1.ReconcileError(errors.New(errCreateIncomplete)))
-		return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.Creating(), prv1.ReconcileError(errors.New(errCreateIncomplete)))
+		return reconcile.Result{Requeue: false}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+	}
+
+	// The external name of a managed resource must not change once its
+	// external resource has been created. We revert it if it has.
+	if r.immutableExternalName && !meta.WasDeleted(managed) && meta.ExternalNameChanged(managed) {
+		created := meta.GetExternalNameCreated(managed)
+		log.Debug("Reverting change to immutable external name", "external-name-created", created)
+		record.Event(managed, event.Warning(reasonExternalNameChanged, errors.Errorf(errFmtExternalNameChanged, created, meta.GetExternalName(managed))))
+		meta.SetExternalName(managed, created)
+		if err := r.client.Update(ctx, managed); err != nil {
+			log.Debug(errUpdateManagedAnnotations, "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			record.Event(managed, event.Warning(reasonCannotUpdateManaged, errors.Wrap(err, errUpdateManagedAnnotations)))
+			setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errUpdateManagedAnnotations)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+	}
+
+	// Once a managed resource has been Ready for a while we no longer need
+	// the annotations that track creation of its external resource, which
+	// would otherwise remain on the managed resource forever. We do this
+	// before observing the external resource so that the update doesn't
+	// clobber any newly observed status.
+	if !meta.WasDeleted(managed) && r.createAnnotationsTTL > 0 && meta.HasExternalCreateAnnotations(managed) && readyFor(managed, r.createAnnotationsTTL) {
+		log.Debug("Removing external create annotations from stable managed resource")
+		meta.RemoveExternalCreateAnnotations(managed)
+		if err := r.client.Update(ctx, managed); err != nil {
+			log.Debug(errUpdateManagedAnnotations, "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			record.Event(managed, event.Warning(reasonCannotUpdateManaged, errors.Wrap(err, errUpdateManagedAnnotations)))
+			setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errUpdateManagedAnnotations)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+	}
+
+	// We resolve any references before observing our external resource because
+	// in some rare examples we need a spec field to make the observe call, and
+	// that spec field could be set by a reference.
+	//
+	// We don't resolve references when deleting because it's not unlikely that
+	// the resources we reference are also being deleted, and would thus block
+	// resolution due to being unready or non-existent. It is relatively safe to
+	// skip resolution on delete because we will have already resolved any
+	// references when the resource was created.
+	if !meta.WasDeleted(managed) {
+		if err := r.managed.ResolveReferences(externalCtx, managed); err != nil {
+			// If any of our referenced resources are not yet ready (or if we
+			// encountered an error resolving them) we want to try again. If
+			// this is the first time we encounter this situation we'll be
+			// requeued implicitly due to the status update. If not, we want
+			// requeue explicitly, which will trigger backoff.
+			log.Debug("Cannot resolve managed resource references", "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			record.Event(managed, event.Warning(reasonCannotResolveRefs, err))
+			if u, ok := reference.GetUnresolvedReferences(err); ok {
+				setConditions(managed, u.Condition())
+			}
+			setConditions(managed, prv1.ReconcileError(err))
+			if r.referenceRetryInterval > 0 && reference.IsNotFound(err) {
+				// A referenced resource doesn't exist yet. We'll retry after
+				// the dependency retry interval rather than backing off.
+				return reconcile.Result{RequeueAfter: r.referenceRetryInterval}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+			}
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+
+		// Clear any unresolved references recorded by a previous reconcile.
+		if managed.GetCondition(prv1.TypeReferencesResolved).Status == metav1.ConditionFalse {
+			setConditions(managed, prv1.ReferencesResolved())
+		}
+	}
+
+	// Each operation on our external resource is subject to our overall
+	// timeout, and may be subject to a shorter timeout set by the managed
+	// resource's timeouts annotation.
+	timeouts, err := meta.GetTimeouts(managed)
+	if err != nil {
+		log.Debug("Cannot determine external resource operation timeouts", "error", err)
+		record.Event(managed, event.Warning(reasonCannotInitialize, err))
+		setConditions(managed, prv1.ReconcileError(err))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
-	external, err := r.external.Connect(externalCtx, managed)
+	connectCtx, connectCancel := withOperationTimeout(externalCtx, timeouts[meta.OperationConnect])
+	defer connectCancel()
+	connectCtx, connectDone := r.externalCall(connectCtx, managed, reconcileID, audit.OperationConnect)
+	external, err := r.external.Connect(connectCtx, managed)
+	connectDone(err)
 	if err != nil {
 		// We'll usually hit this case if our Provider or its secret are missing
 		// or invalid. If this is first time we encounter this issue we'll be
@@ -583,8 +1243,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			return reconcile.Result{Requeue: true}, nil
 		}
 		record.Event(managed, event.Warning(reasonCannotConnect, err))
-		managed.SetConditions(prv1.ReconcileError(errors.Wrap(err, errReconcileConnect)))
-		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errReconcileConnect)))
+		return requeueAfterError(err), errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 	defer func() {
 		if err := r.external.Disconnect(ctx); err != nil {
@@ -593,7 +1253,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		}
 	}()
 
-	observation, err := external.Observe(externalCtx, managed)
+	observeCtx, observeCancel := withOperationTimeout(externalCtx, timeouts[meta.OperationObserve])
+	defer observeCancel()
+	observeCtx, observeDone := r.externalCall(observeCtx, managed, reconcileID, audit.OperationObserve)
+	observation, err := external.Observe(observeCtx, managed)
+	observeDone(err)
 	if err != nil {
 		// We'll usually hit this case if our Provider credentials are invalid
 		// or insufficient for observing the external resource type we're
@@ -606,16 +1270,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			return reconcile.Result{Requeue: true}, nil
 		}
 		record.Event(managed, event.Warning(reasonCannotObserve, err))
-		managed.SetConditions(prv1.ReconcileError(errors.Wrap(err, errReconcileObserve)))
-		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errReconcileObserve)))
+		return requeueAfterError(err), errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
 	// In the observe-only mode, !observation.ResourceExists will be an error
 	// case, and we will explicitly return this information to the user.
 	if !observation.ResourceExists && meta.ShouldOnlyObserve(managed) {
 		record.Event(managed, event.Warning(reasonCannotObserve, errors.New(errExternalResourceNotExist)))
-		managed.SetConditions(prv1.ReconcileError(errors.Wrap(errors.New(errExternalResourceNotExist), errReconcileObserve)))
-		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileError(errors.Wrap(errors.New(errExternalResourceNotExist), errReconcileObserve)))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
 	// If this resource has a non-zero creation grace period we want to wait
@@ -635,7 +1299,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// We'll only reach this point if deletion policy is not orphan, so we
 		// are safe to call external deletion if external resource exists.
 		if observation.ResourceExists && meta.ShouldDelete(managed) {
-			if err := external.Delete(externalCtx, managed); err != nil {
+			deleteCtx, deleteCancel := withOperationTimeout(externalCtx, timeouts[meta.OperationDelete])
+			defer deleteCancel()
+			deleteCtx, deleteDone := r.externalCall(deleteCtx, managed, reconcileID, audit.OperationDelete)
+			err := external.Delete(deleteCtx, managed)
+			deleteDone(err)
+			if err != nil {
 				// We'll hit this condition if we can't delete our external
 				// resource, for example if our provider credentials don't have
 				// access to delete it. If this is the first time we encounter
@@ -644,8 +1313,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				// explicitly, which will trigger backoff.
 				log.Debug("Cannot delete external resource", "error", err)
 				record.Event(managed, event.Warning(reasonCannotDelete, err))
-				managed.SetConditions(prv1.Deleting(), prv1.ReconcileError(errors.Wrap(err, errReconcileDelete)))
-				return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+				setConditions(managed, prv1.Deleting(), prv1.ReconcileError(errors.Wrap(err, errReconcileDelete)))
+				return requeueAfterError(err), errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 			}
 
 			// We've successfully requested deletion of our external resource.
@@ -657,8 +1326,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			// block and try again.
 			log.Debug("Successfully requested deletion of external resource")
 			record.Event(managed, event.Normal(reasonDeleted, "Successfully requested deletion of external resource"))
-			managed.SetConditions(prv1.Deleting(), prv1.ReconcileSuccess())
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.Deleting(), prv1.ReconcileSuccess())
+			resource.RecordSuccessfulSync(managed, syncTime)
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 
 		if err := r.managed.RemoveFinalizer(ctx, managed); err != nil {
@@ -670,8 +1340,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			if kerrors.IsConflict(err) {
 				return reconcile.Result{Requeue: true}, nil
 			}
-			managed.SetConditions(prv1.Deleting(), prv1.ReconcileError(err))
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.Deleting(), prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 
 		// We've successfully deleted our external resource (if necessary) and
@@ -690,11 +1360,49 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		if kerrors.IsConflict(err) {
 			return reconcile.Result{Requeue: true}, nil
 		}
-		managed.SetConditions(prv1.ReconcileError(err))
-		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileError(err))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+	}
+
+	if err := r.referencedBy.AddFinalizer(ctx, managed); err != nil {
+		log.Debug("Cannot add referenced-by finalizer", "error", err)
+		if kerrors.IsConflict(err) {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		setConditions(managed, prv1.ReconcileError(err))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
 	if !observation.ResourceExists && meta.ShouldCreate(managed) {
+		// We don't create the external resource until the objects it
+		// depends on are ready. We'll be requeued with backoff until they
+		// are.
+		waiting, err := r.managed.WaitingFor(externalCtx, managed)
+		if IsDependencyCycle(err) {
+			// Waiting would deadlock, so there's no point requeueing. We'll
+			// be reconciled again when the managed resource changes.
+			log.Debug("Cannot wait for dependencies", "error", err)
+			record.Event(managed, event.Warning(reasonDependencyCycle, err))
+			setConditions(managed, prv1.Creating(), prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: false}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+		if err != nil {
+			log.Debug("Cannot determine whether dependencies are ready", "error", err)
+			if kerrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			setConditions(managed, prv1.Creating(), prv1.ReconcileError(err))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+		if len(waiting) > 0 {
+			msg := "Waiting for dependencies to become ready: " + strings.Join(waiting, ", ")
+			log.Debug("Waiting for dependencies to become ready", "dependencies", waiting)
+			record.Event(managed, event.Normal(reasonWaitingForDependencies, msg))
+			setConditions(managed, prv1.Creating().WithMessage(msg), prv1.ReconcileSuccess())
+			resource.RecordSuccessfulSync(managed, syncTime)
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+		}
+
 		// We write this annotation for two reasons. Firstly, it helps
 		// us to detect the case in which we fail to persist critical
 		// information (like the external name) that may be set by the
@@ -709,11 +1417,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				return reconcile.Result{Requeue: true}, nil
 			}
 			record.Event(managed, event.Warning(reasonCannotUpdateManaged, errors.Wrap(err, errUpdateManaged)))
-			managed.SetConditions(prv1.Creating(), prv1.ReconcileError(errors.Wrap(err, errUpdateManaged)))
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.Creating(), prv1.ReconcileError(errors.Wrap(err, errUpdateManaged)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 
-		err = external.Create(externalCtx, managed)
+		createCtx, createCancel := withOperationTimeout(externalCtx, timeouts[meta.OperationCreate])
+		defer createCancel()
+		createCtx, createDone := r.externalCall(createCtx, managed, reconcileID, audit.OperationCreate)
+		err = external.Create(createCtx, managed)
+		createDone(err)
 		if err != nil {
 			// We'll hit this condition if we can't create our external
 			// resource, for example if our provider credentials don't have
@@ -744,13 +1456,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				// create failed.
 			}
 
-			managed.SetConditions(prv1.Creating(), prv1.ReconcileError(errors.Wrap(err, errReconcileCreate)))
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.Creating(), prv1.ReconcileError(errors.Wrap(err, errReconcileCreate)))
+			return requeueAfterError(err), errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 
 		// In some cases our external-name may be set by Create above.
 		log = log.WithValues("external-name", meta.GetExternalName(managed))
-		record = r.record.WithAnnotations("external-name", meta.GetExternalName(managed))
+		record = record.WithAnnotations(event.AnnotationKeyExternalName, meta.GetExternalName(managed))
 
 		// We handle annotations specially here because it's critical
 		// that they are persisted to the API server. If we don't remove
@@ -763,11 +1475,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// Create implementations are advised not to alter status, but
 		// we may revisit this in future.
 		meta.SetExternalCreateSucceeded(managed, time.Now())
+		if r.immutableExternalName && meta.GetExternalName(managed) != "" {
+			meta.SetExternalNameCreated(managed, meta.GetExternalName(managed))
+		}
 		if err := r.managed.UpdateCriticalAnnotations(ctx, managed); err != nil {
 			log.Debug(errUpdateManagedAnnotations, "error", err)
 			record.Event(managed, event.Warning(reasonCannotUpdateManaged, errors.Wrap(err, errUpdateManagedAnnotations)))
-			managed.SetConditions(prv1.Creating(), prv1.ReconcileError(errors.Wrap(err, errUpdateManagedAnnotations)))
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.Creating(), prv1.ReconcileError(errors.Wrap(err, errUpdateManagedAnnotations)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 
 		// We've successfully created our external resource. In many cases the
@@ -776,11 +1491,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// ready for use.
 		log.Debug("Successfully requested creation of external resource")
 		record.Event(managed, event.Normal(reasonCreated, "Successfully requested creation of external resource"))
-		managed.SetConditions(prv1.Creating(), prv1.ReconcileSuccess())
-		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.Creating(), prv1.ReconcileSuccess())
+		resource.RecordSuccessfulSync(managed, syncTime)
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
-	if observation.ResourceLateInitialized {
+	// The managed resource's management policies may forbid us from
+	// persisting its late initialized spec.
+	if observation.ResourceLateInitialized && meta.ShouldLateInitialize(managed) {
 		// Note that this update may reset any pending updates to the status of
 		// the managed resource from when it was observed above. This is because
 		// the API server replies to the update with its unchanged view of the
@@ -791,8 +1509,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		if err := r.client.Update(ctx, managed); err != nil {
 			log.Debug(errUpdateManaged, "error", err)
 			record.Event(managed, event.Warning(reasonCannotUpdateManaged, err))
-			managed.SetConditions(prv1.ReconcileError(errors.Wrap(err, errUpdateManaged)))
-			return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+			setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errUpdateManaged)))
+			return reconcile.Result{Requeue: true}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 		}
 	}
 
@@ -803,25 +1521,39 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// after the specified poll interval in order to observe it and react
 		// accordingly.
 		// https://github.com/crossplane/crossplane/issues/289
-		reconcileAfter := r.pollIntervalHook(managed, r.pollInterval)
+		reconcileAfter := r.pollAfter(managed, pollInterval, expiry, observation)
 		log.Debug("External resource is up to date", "requeue-after", time.Now().Add(reconcileAfter))
-		managed.SetConditions(prv1.ReconcileSuccess())
-		return reconcile.Result{RequeueAfter: reconcileAfter}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileSuccess())
+		resource.RecordSuccessfulSync(managed, syncTime)
+		if r.skipUnchangedStatus && statusUnchanged(observed, managed) {
+			log.Debug("Skipping update of unchanged managed resource status")
+			return reconcile.Result{RequeueAfter: reconcileAfter}, nil
+		}
+		return reconcile.Result{RequeueAfter: reconcileAfter}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
-	if observation.Diff != "" {
-		log.Debug("External resource differs from desired state", "diff", observation.Diff)
+	if observation.Diff != "" && log.Enabled(logging.LevelDebug) {
+		log.Debug("External resource differs from desired state", "diff", SafeDiff(observation.Diff, r.diffOptions...))
 	}
 
 	// skip the update if the management policy is set to ignore updates
 	if !meta.ShouldUpdate(managed) {
-		reconcileAfter := r.pollIntervalHook(managed, r.pollInterval)
+		reconcileAfter := r.pollAfter(managed, pollInterval, expiry, observation)
 		log.Debug("Skipping update due to managementPolicies. Reconciliation succeeded", "requeue-after", time.Now().Add(reconcileAfter))
-		managed.SetConditions(prv1.ReconcileSuccess())
-		return reconcile.Result{RequeueAfter: reconcileAfter}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileSuccess())
+		resource.RecordSuccessfulSync(managed, syncTime)
+		if r.skipUnchangedStatus && statusUnchanged(observed, managed) {
+			log.Debug("Skipping update of unchanged managed resource status")
+			return reconcile.Result{RequeueAfter: reconcileAfter}, nil
+		}
+		return reconcile.Result{RequeueAfter: reconcileAfter}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
-	err = external.Update(externalCtx, managed)
+	updateCtx, updateCancel := withOperationTimeout(externalCtx, timeouts[meta.OperationUpdate])
+	defer updateCancel()
+	updateCtx, updateDone := r.externalCall(updateCtx, managed, reconcileID, audit.OperationUpdate)
+	err = external.Update(updateCtx, managed)
+	updateDone(err)
 	if err != nil {
 		// We'll hit this condition if we can't update our external resource,
 		// for example if our provider credentials don't have access to update
@@ -830,8 +1562,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// condition. If not, we requeue explicitly, which will trigger backoff.
 		log.Debug("Cannot update external resource")
 		record.Event(managed, event.Warning(reasonCannotUpdate, err))
-		managed.SetConditions(prv1.ReconcileError(errors.Wrap(err, errReconcileUpdate)))
-		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+		setConditions(managed, prv1.ReconcileError(errors.Wrap(err, errReconcileUpdate)))
+		return requeueAfterError(err), errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
 	}
 
 	// We've successfully updated our external resource. Per the below issue
@@ -839,9 +1571,132 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// changes, so we requeue a speculative reconcile after the specified poll
 	// interval in order to observe it and react accordingly.
 	// https://github.com/crossplane/crossplane/issues/289
-	reconcileAfter := r.pollIntervalHook(managed, r.pollInterval)
+	reconcileAfter := r.pollAfter(managed, pollInterval, expiry, observation)
 	log.Debug("Successfully requested update of external resource", "requeue-after", time.Now().Add(reconcileAfter))
 	record.Event(managed, event.Normal(reasonUpdated, "Successfully requested update of external resource"))
-	managed.SetConditions(prv1.ReconcileSuccess())
-	return reconcile.Result{RequeueAfter: reconcileAfter}, errors.Wrap(r.client.Status().Update(ctx, managed), errUpdateManagedStatus)
+	setConditions(managed, prv1.ReconcileSuccess())
+	resource.RecordSuccessfulSync(managed, syncTime)
+	return reconcile.Result{RequeueAfter: reconcileAfter}, errors.Wrap(r.updateStatus(ctx, managed), errUpdateManagedStatus)
+}
+
+// pollAfter returns how long to wait before speculatively reconciling the
+// supplied up to date managed resource again. This is the poll interval as
+// processed by the poll interval hook, unless the managed resource expires or
+// the supplied observation reports a deadline sooner.
+func (r *Reconciler) pollAfter(managed resource.Managed, pollInterval time.Duration, expiry time.Time, o ExternalObservation) time.Duration {
+	now := time.Now()
+	after := RequeueBeforeDeadlines(r.pollIntervalHook(managed, pollInterval), r.deadlineLead, now, o.Deadlines...)
+	return requeueBeforeExpiry(after, expiry, now)
+}
+
+// RequeueBeforeDeadlines returns the supplied requeue interval, or the time
+// until the supplied lead time before the earliest of the supplied deadlines
+// if it is sooner. A deadline whose lead time has already begun is requeued
+// when it passes, rather than immediately, so that a deadline that isn't
+// renewed doesn't cause a hot loop. Deadlines that have passed and zero
+// deadlines are ignored. The returned interval is always positive, since a
+// zero interval would not requeue at all.
+func RequeueBeforeDeadlines(after, lead time.Duration, now time.Time, deadlines ...time.Time) time.Duration {
+	for _, d := range deadlines {
+		if d.IsZero() || !now.Before(d) {
+			continue
+		}
+		at := d.Add(-lead)
+		if !now.Before(at) {
+			at = d
+		}
+		after = min(after, max(at.Sub(now), time.Second))
+	}
+	return after
+}
+
+// requeueBeforeExpiry returns the supplied requeue interval, or the time until
+// the supplied expiry if it is sooner, so that expired managed resources are
+// deleted promptly. A zero expiry never expires. The returned interval is
+// always positive, since a zero interval would not requeue at all.
+func requeueBeforeExpiry(after time.Duration, expiry, now time.Time) time.Duration {
+	if expiry.IsZero() {
+		return after
+	}
+	if until := expiry.Sub(now); until < after {
+		return max(until, time.Second)
+	}
+	return after
+}
+
+// withOperationTimeout returns a context that is cancelled after the supplied
+// timeout, or when the supplied context is. A zero timeout means the returned
+// context is subject only to the supplied context's deadline.
+func withOperationTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// requeueAfterError returns the result of a reconcile that failed because an
+// external operation returned the supplied error. If the error suggests how
+// long to wait before retrying, for example because the external system
+// throttled the provider, the request is requeued after that delay rather
+// than after the rate limiter's backoff.
+func requeueAfterError(err error) reconcile.Result {
+	if d, ok := ratelimiter.RetryAfter(err); ok && d > 0 {
+		return reconcile.Result{RequeueAfter: d}
+	}
+	return reconcile.Result{Requeue: true}
+}
+
+type reconcileIDKey struct{}
+
+// WithReconcileID returns a copy of the supplied context that carries the
+// supplied reconcile ID. A Reconciler uses the ID carried by the context it's
+// passed, if any, rather than generating one.
+func WithReconcileID(ctx context.Context, id types.UID) context.Context {
+	return context.WithValue(ctx, reconcileIDKey{}, id)
+}
+
+// ReconcileIDFromContext returns the ID of the reconcile the supplied context
+// belongs to, and whether it carries one. The contexts a Reconciler passes to
+// an ExternalClient always carry one.
+func ReconcileIDFromContext(ctx context.Context) (types.UID, bool) {
+	id, ok := ctx.Value(reconcileIDKey{}).(types.UID)
+	return id, ok && id != ""
+}
+
+// readyFor returns true if the supplied managed resource has been Ready for at
+// least the supplied duration.
+func readyFor(mg resource.Managed, d time.Duration) bool {
+	c := mg.GetCondition(prv1.TypeReady)
+	return c.Status == metav1.ConditionTrue && time.Since(c.LastTransitionTime.Time) >= d
+}
+
+// statusUnchanged returns true if the supplied managed resource is
+// semantically unchanged from when it was observed, ignoring the times at
+// which it was synced. Sync times are only ignored if they were already set.
+func statusUnchanged(observed, current resource.Managed) bool {
+	oh, ok := observed.(resource.ConditionsHasher)
+	if ch, cok := current.(resource.ConditionsHasher); ok && cok && oh.ConditionsHash() != ch.ConditionsHash() {
+		return false
+	}
+
+	c := current.DeepCopyObject().(resource.Managed)
+	if ost, ok := observed.(resource.SyncTimer); ok {
+		cst := c.(resource.SyncTimer)
+		if ost.GetLastSyncAttemptTime() == nil || ost.GetLastSuccessfulSyncTime() == nil {
+			return false
+		}
+		cst.SetLastSyncAttemptTime(*ost.GetLastSyncAttemptTime())
+		cst.SetLastSuccessfulSyncTime(*ost.GetLastSuccessfulSyncTime())
+	}
+	return equality.Semantic.DeepEqual(observed, c)
+}
+
+// reconcilePolicyPollInterval returns the poll interval of the supplied
+// managed resource's reconcile policy, or zero if it doesn't specify one.
+func reconcilePolicyPollInterval(mg resource.Managed) time.Duration {
+	h, ok := mg.(resource.ReconcilePolicyHolder)
+	if !ok || h.GetReconcilePolicy() == nil || h.GetReconcilePolicy().PollInterval == nil {
+		return 0
+	}
+	return h.GetReconcilePolicy().PollInterval.Duration
 }
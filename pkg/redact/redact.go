@@ -0,0 +1,78 @@
+// Package redact scrubs secrets from strings, such as event messages,
+// condition messages, and log lines.
+package redact
+
+import (
+	"regexp"
+)
+
+// Placeholder replaces redacted secrets.
+const Placeholder = "[REDACTED]"
+
+// A Rule replaces matches of a pattern with a replacement. The replacement
+// may refer to submatches of the pattern as described by
+// regexp.Regexp.Expand.
+type Rule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// DefaultRules redact bearer tokens, credentials embedded in URLs, private
+// keys, common access key and token formats, and the values of password,
+// secret, token, and API key parameters.
+var DefaultRules = []Rule{
+	{
+		Pattern:     regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+		Replacement: Placeholder,
+	},
+	{
+		Pattern:     regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`),
+		Replacement: "${1}" + Placeholder,
+	},
+	{
+		Pattern:     regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.\-]*://)[^/\s:@]+:[^/\s@]+@`),
+		Replacement: "${1}" + Placeholder + "@",
+	},
+	{
+		Pattern:     regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+		Replacement: Placeholder,
+	},
+	{
+		Pattern:     regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+		Replacement: Placeholder,
+	},
+	{
+		Pattern:     regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api[_-]?key)["']?\s*[:=]\s*["']?)[^\s"',;&]+`),
+		Replacement: "${1}" + Placeholder,
+	},
+}
+
+// A Redactor scrubs secrets from strings.
+type Redactor struct {
+	rules []Rule
+}
+
+// New returns a Redactor that applies the supplied rules in order. It applies
+// the DefaultRules if no rules are supplied.
+func New(rules ...Rule) *Redactor {
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+	return &Redactor{rules: rules}
+}
+
+// Redact returns the supplied string with any secrets scrubbed.
+func (r *Redactor) Redact(s string) string {
+	for _, rule := range r.rules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+	return s
+}
+
+var defaultRedactor = New()
+
+// Redact returns the supplied string with any secrets matched by the
+// DefaultRules scrubbed.
+func Redact(s string) string {
+	return defaultRedactor.Redact(s)
+}
@@ -0,0 +1,131 @@
+package reference
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// A ResolutionCache caches the managed resources read while successfully
+// resolving references, so that resources with many references don't read
+// each referenced resource on every reconcile. Cached resources expire after
+// a TTL, and may be invalidated when they change. A ResolutionCache is safe
+// for concurrent use, and is intended to be shared by the APIResolvers of a
+// controller.
+type ResolutionCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[resolutionCacheKey]resolutionCacheEntry
+}
+
+type resolutionCacheKey struct {
+	kind string
+	nn   types.NamespacedName
+}
+
+type resolutionCacheEntry struct {
+	obj     runtime.Object
+	version string
+	expires time.Time
+}
+
+// NewResolutionCache returns a ResolutionCache whose entries expire after the
+// supplied TTL.
+func NewResolutionCache(ttl time.Duration) *ResolutionCache {
+	return &ResolutionCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[resolutionCacheKey]resolutionCacheEntry{},
+	}
+}
+
+// WithResolutionCache configures an APIResolver to read referenced managed
+// resources through the supplied cache.
+func WithResolutionCache(c *ResolutionCache) APIResolverOption {
+	return func(r *APIResolver) {
+		r.cache = c
+	}
+}
+
+// Invalidate any cached resource with the same namespace and name as the
+// supplied object, unless the cached resource has the same resource version.
+func (c *ResolutionCache) Invalidate(o client.Object) {
+	nn := types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if k.nn == nn && e.version != o.GetResourceVersion() {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// EventHandler returns an event handler that invalidates cached resources
+// when they are updated or deleted. It should be used to watch the kinds of
+// resource that are referenced. It never enqueues any requests.
+func (c *ResolutionCache) EventHandler() handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			c.Invalidate(e.ObjectNew)
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			// A deleted resource is never the same version as a cached one.
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			for k := range c.entries {
+				if k.nn.Namespace == e.Object.GetNamespace() && k.nn.Name == e.Object.GetName() {
+					delete(c.entries, k)
+				}
+			}
+		},
+	}
+}
+
+// key returns the key of the resource of the supplied object's kind with the
+// supplied namespace and name. Typed resources and unstructured resources of
+// different kinds are cached separately. The key must be computed before the
+// object is read, since reading may set its kind.
+func (c *ResolutionCache) key(nn types.NamespacedName, o client.Object) resolutionCacheKey {
+	return resolutionCacheKey{kind: fmt.Sprintf("%T %s", o, o.GetObjectKind().GroupVersionKind()), nn: nn}
+}
+
+// get the cached resource with the supplied key into the supplied object. It
+// returns false if there is no unexpired cached resource.
+func (c *ResolutionCache) get(k resolutionCacheKey, into client.Object) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	if !ok {
+		return false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, k)
+		return false
+	}
+	reflect.ValueOf(into).Elem().Set(reflect.ValueOf(e.obj.DeepCopyObject()).Elem())
+	return true
+}
+
+// add the supplied resource to the cache with the supplied key.
+func (c *ResolutionCache) add(k resolutionCacheKey, o client.Object) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[k] = resolutionCacheEntry{
+		obj:     o.DeepCopyObject(),
+		version: o.GetResourceVersion(),
+		expires: c.now().Add(c.ttl),
+	}
+}
@@ -0,0 +1,125 @@
+package reference
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestResolutionCache(t *testing.T) {
+	now := time.Now()
+	value := "coolv"
+
+	type step struct {
+		// before is called before resolving.
+		before func(c *ResolutionCache)
+		value  string
+		gets   int
+	}
+
+	cases := map[string]struct {
+		reason string
+		steps  []step
+	}{
+		"CacheHit": {
+			reason: "A successfully resolved reference should not be read again before its TTL expires.",
+			steps: []step{
+				{value: value, gets: 1},
+				{value: value, gets: 1},
+			},
+		},
+		"Expired": {
+			reason: "A reference should be read again once its TTL expires.",
+			steps: []step{
+				{value: value, gets: 1},
+				{before: func(c *ResolutionCache) { c.now = func() time.Time { return now.Add(time.Hour) } }, value: value, gets: 2},
+			},
+		},
+		"InvalidatedByNewVersion": {
+			reason: "A reference should be read again once the referenced resource changes.",
+			steps: []step{
+				{value: value, gets: 1},
+				{before: func(c *ResolutionCache) {
+					c.Invalidate(&fake.Managed{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool", ResourceVersion: "2"}})
+				}, value: value, gets: 2},
+			},
+		},
+		"NotInvalidatedBySameVersion": {
+			reason: "A reference should not be read again if the referenced resource has not changed.",
+			steps: []step{
+				{value: value, gets: 1},
+				{before: func(c *ResolutionCache) {
+					c.Invalidate(&fake.Managed{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool", ResourceVersion: "1"}})
+				}, value: value, gets: 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gets := 0
+			c := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				gets++
+				obj.SetNamespace(key.Namespace)
+				obj.SetName(key.Name)
+				obj.SetResourceVersion("1")
+				meta.SetExternalName(obj, value)
+				return nil
+			}}
+			cache := NewResolutionCache(time.Minute)
+			cache.now = func() time.Time { return now }
+
+			for i, s := range tc.steps {
+				if s.before != nil {
+					s.before(cache)
+				}
+				r := NewAPIResolver(c, managed("from", "", ""), WithResolutionCache(cache))
+				rsp, err := r.Resolve(context.Background(), ResolutionRequest{
+					Reference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool"}},
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+				})
+				if err != nil {
+					t.Fatalf("\n%s\nstep %d: Resolve(...): unexpected error: %v", tc.reason, i, err)
+				}
+				if diff := cmp.Diff(s.value, rsp.ResolvedValue); diff != "" {
+					t.Errorf("\n%s\nstep %d: Resolve(...): -want value, +got value:\n%s", tc.reason, i, diff)
+				}
+				if diff := cmp.Diff(s.gets, gets); diff != "" {
+					t.Errorf("\n%s\nstep %d: Resolve(...): -want gets, +got gets:\n%s", tc.reason, i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestResolutionCacheUnresolved(t *testing.T) {
+	gets := 0
+	c := &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+		gets++
+		return nil
+	}}
+	cache := NewResolutionCache(time.Minute)
+
+	for i := 0; i < 2; i++ {
+		r := NewAPIResolver(c, managed("from", "", ""), WithResolutionCache(cache))
+		_, _ = r.Resolve(context.Background(), ResolutionRequest{
+			Reference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool"}},
+			To:        To{Managed: &fake.Managed{}},
+			Extract:   ExternalName(),
+		})
+	}
+
+	if diff := cmp.Diff(2, gets); diff != "" {
+		t.Errorf("Resolve(...): references that could not be resolved should not be cached: -want gets, +got gets:\n%s", diff)
+	}
+}
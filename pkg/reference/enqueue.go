@@ -0,0 +1,74 @@
+package reference
+
+import (
+	"context"
+
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// IndexFieldReferences is the name of the field index that records which
+// objects each managed resource references.
+const IndexFieldReferences = "krateo.io/references"
+
+// Error strings.
+const (
+	errIndexReferences = "cannot index references"
+)
+
+// ReferenceIndexKey returns the key under which a reference to the object of
+// the supplied kind with the supplied namespace and name is indexed. The
+// namespace is empty for cluster scoped objects.
+func ReferenceIndexKey(gk schema.GroupKind, nn types.NamespacedName) string {
+	return gk.String() + "/" + nn.String()
+}
+
+// A ReferencesFn returns the index keys of the objects the supplied object
+// references, as returned by ReferenceIndexKey.
+type ReferencesFn func(o client.Object) []string
+
+// IndexReferences indexes the objects referenced by objects of the supplied
+// kind, as returned by the supplied ReferencesFn, under IndexFieldReferences.
+// It should be called once per referencing kind before the manager starts.
+func IndexReferences(ctx context.Context, fi client.FieldIndexer, o client.Object, fn ReferencesFn) error {
+	return errors.Wrap(fi.IndexField(ctx, o, IndexFieldReferences, client.IndexerFunc(fn)), errIndexReferences)
+}
+
+// EnqueueRequestForReferencers returns an event handler that enqueues a
+// request for each managed resource that references the object an event
+// concerns. The handler must watch objects of the supplied referenced kind.
+// Referencers are listed from the supplied reader using the supplied list
+// kind, which must be indexed using IndexReferences.
+func EnqueueRequestForReferencers(c client.Reader, referencers client.ObjectList, referenced schema.GroupKind) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		key := ReferenceIndexKey(referenced, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()})
+
+		l := referencers.DeepCopyObject().(client.ObjectList)
+		if err := c.List(ctx, l, client.MatchingFields{IndexFieldReferences: key}); err != nil {
+			// There's no way to surface this error, and the referencers will
+			// be reconciled again at their next poll in any case.
+			return nil
+		}
+
+		items, err := kmeta.ExtractList(l)
+		if err != nil {
+			return nil
+		}
+
+		reqs := make([]reconcile.Request, 0, len(items))
+		for _, i := range items {
+			m, err := kmeta.Accessor(i)
+			if err != nil {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()}})
+		}
+		return reqs
+	})
+}
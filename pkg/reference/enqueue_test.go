@@ -0,0 +1,62 @@
+package reference
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestEnqueueRequestForReferencers(t *testing.T) {
+	secrets := schema.GroupKind{Kind: "Secret"}
+
+	// Config maps reference the secret named by their "secret" key.
+	refs := func(o client.Object) []string {
+		cm := o.(*corev1.ConfigMap)
+		if cm.Data["secret"] == "" {
+			return nil
+		}
+		return []string{ReferenceIndexKey(secrets, types.NamespacedName{Namespace: cm.GetNamespace(), Name: cm.Data["secret"]})}
+	}
+	referencer := func(name, secret string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: name},
+			Data:       map[string]string{"secret": secret},
+		}
+	}
+
+	c := clientfake.NewClientBuilder().
+		WithObjects(referencer("a", "creds"), referencer("b", "creds"), referencer("c", "other")).
+		WithIndex(&corev1.ConfigMap{}, IndexFieldReferences, client.IndexerFunc(refs)).
+		Build()
+
+	h := EnqueueRequestForReferencers(c, &corev1.ConfigMapList{}, secrets)
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	changed := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "creds"}}
+	h.Update(context.Background(), event.UpdateEvent{ObjectOld: changed, ObjectNew: changed}, q)
+
+	got := []string{}
+	for q.Len() > 0 {
+		req, _ := q.Get()
+		got = append(got, req.String())
+		q.Done(req)
+	}
+	sort.Strings(got)
+
+	want := []string{"coolns/a", "coolns/b"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EnqueueRequestForReferencers(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,109 @@
+package reference
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errEmptyFieldPath      = "empty field path"
+	errUnterminatedBracket = "unterminated '[' in field path"
+)
+
+// Path returns an ExtractValueFn that extracts the value at the supplied field
+// path of the resolved managed resource, which may be typed or unstructured.
+// Path segments are separated by periods. Array elements are selected by index
+// and map keys containing periods by key, using brackets. For example:
+//
+//	status.atProvider.id
+//	status.atProvider.endpoints[0].address
+//	metadata.annotations[krateo.io/external-name]
+//
+// Strings are extracted as-is, and other scalar values are formatted as
+// strings. An empty value is extracted if the path does not exist, is invalid,
+// or does not refer to a scalar value.
+func Path(path string) ExtractValueFn {
+	segments, err := parseFieldPath(path)
+	return FromUnstructured(func(u *unstructured.Unstructured) string {
+		if err != nil {
+			return ""
+		}
+		return valueAt(u.Object, segments)
+	})
+}
+
+// A fieldPathSegment is either a map key or, if index is non-negative, an array
+// index.
+type fieldPathSegment struct {
+	key   string
+	index int
+}
+
+func parseFieldPath(path string) ([]fieldPathSegment, error) {
+	if path == "" {
+		return nil, errors.New(errEmptyFieldPath)
+	}
+
+	var segments []fieldPathSegment
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			continue
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil, errors.New(errUnterminatedBracket)
+			}
+			inner := path[1:end]
+			path = path[end+1:]
+			if i, err := strconv.Atoi(inner); err == nil && i >= 0 {
+				segments = append(segments, fieldPathSegment{index: i})
+				continue
+			}
+			segments = append(segments, fieldPathSegment{key: inner, index: -1})
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, fieldPathSegment{key: path[:end], index: -1})
+			path = path[end:]
+		}
+	}
+	return segments, nil
+}
+
+func valueAt(v any, segments []fieldPathSegment) string {
+	for _, s := range segments {
+		switch t := v.(type) {
+		case map[string]any:
+			if s.index >= 0 {
+				return ""
+			}
+			v = t[s.key]
+		case []any:
+			if s.index < 0 || s.index >= len(t) {
+				return ""
+			}
+			v = t[s.index]
+		default:
+			return ""
+		}
+	}
+
+	switch t := v.(type) {
+	case string:
+		return t
+	case bool, int64, float64:
+		return fmt.Sprint(t)
+	default:
+		return ""
+	}
+}
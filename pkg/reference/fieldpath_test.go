@@ -0,0 +1,98 @@
+package reference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+func TestPath(t *testing.T) {
+	u := &Unstructured{}
+	u.Object = map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				meta.AnnotationKeyExternalName: "external",
+			},
+		},
+		"status": map[string]any{
+			"atProvider": map[string]any{
+				"id":      "cool-id",
+				"port":    int64(443),
+				"enabled": true,
+				"endpoints": []any{
+					map[string]any{"address": "10.0.0.1"},
+					map[string]any{"address": "10.0.0.2"},
+				},
+			},
+		},
+	}
+	typed := &typedManaged{ObjectMeta: metav1.ObjectMeta{Name: "typed"}}
+
+	cases := map[string]struct {
+		reason string
+		path   string
+		typed  bool
+		want   string
+	}{
+		"String": {
+			reason: "A string value should be extracted as-is.",
+			path:   "status.atProvider.id",
+			want:   "cool-id",
+		},
+		"Integer": {
+			reason: "An integer value should be formatted as a string.",
+			path:   "status.atProvider.port",
+			want:   "443",
+		},
+		"Boolean": {
+			reason: "A boolean value should be formatted as a string.",
+			path:   "status.atProvider.enabled",
+			want:   "true",
+		},
+		"Index": {
+			reason: "Array elements should be selected by index.",
+			path:   "status.atProvider.endpoints[1].address",
+			want:   "10.0.0.2",
+		},
+		"BracketedKey": {
+			reason: "Map keys containing periods should be selected using brackets.",
+			path:   "metadata.annotations[" + meta.AnnotationKeyExternalName + "]",
+			want:   "external",
+		},
+		"NotScalar": {
+			reason: "An empty value should be extracted if the path refers to an object.",
+			path:   "status.atProvider",
+		},
+		"NotFound": {
+			reason: "An empty value should be extracted if the path does not exist.",
+			path:   "status.atProvider.endpoints[5].address",
+		},
+		"Invalid": {
+			reason: "An empty value should be extracted if the path is invalid.",
+			path:   "status.atProvider.endpoints[0",
+		},
+		"Typed": {
+			reason: "Values should be extracted from typed managed resources.",
+			path:   "metadata.name",
+			typed:  true,
+			want:   "typed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			if tc.typed {
+				got = Path(tc.path)(typed)
+			} else {
+				got = Path(tc.path)(u)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPath(%q): -want, +got:\n%s", tc.reason, tc.path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,533 @@
+// Package reference contains utilities for working with cross-resource
+// references.
+package reference
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+)
+
+// Error strings.
+const (
+	errGetManaged   = "cannot get referenced resource"
+	errGetConfigMap = "cannot get referenced configmap"
+	errGetSecret    = "cannot get referenced secret"
+	errListManaged  = "cannot list resources that match selector"
+	errNoMatches    = "no resources matched selector"
+	errNoValue      = "referenced field was empty (referenced resource may not yet be ready)"
+
+	errFmtCrossNamespace = "reference to %s/%s from namespace %q is denied by cross namespace policy"
+	errFmtKeyNotFound    = "key %s not found in referenced %s %s/%s"
+
+	errFmtControllerMismatch = "referenced resource %s/%s is not controlled by the controller of the referencing resource"
+
+	errFmtResolveIndex = "cannot resolve reference at index %d"
+)
+
+type errNotFound struct{ error }
+
+func (e errNotFound) NotFound() bool {
+	return true
+}
+
+// IsNotFound returns true if the supplied error, which may be wrapped,
+// indicates that a referenced resource does not exist (yet), either because
+// it was not found or because no resources matched a selector.
+func IsNotFound(err error) bool {
+	if kerrors.IsNotFound(err) {
+		return true
+	}
+	var nf interface{ NotFound() bool }
+	return errors.As(err, &nf)
+}
+
+type errDenied struct{ error }
+
+func (e errDenied) Denied() bool {
+	return true
+}
+
+// IsDenied returns true if the supplied error, which may be wrapped,
+// indicates that a reference is denied by policy, for example because it
+// crosses namespaces.
+func IsDenied(err error) bool {
+	var d interface{ Denied() bool }
+	return errors.As(err, &d)
+}
+
+// There are many equivalents of FromPtrValue and ToPtrValue throughout
+// providers. We duplicate them here to reduce the number of packages API
+// types have to import to support references.
+
+// FromPtrValue adapts a string pointer field for use as a CurrentValue.
+func FromPtrValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// ToPtrValue adapts a ResolvedValue for use as a string pointer field.
+func ToPtrValue(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+// To indicates the kind of managed resource a reference is to.
+type To struct {
+	Managed resource.Managed
+	List    resource.ManagedList
+}
+
+// An ExtractValueFn specifies how to extract a value from the resolved managed
+// resource.
+type ExtractValueFn func(resource.Managed) string
+
+// ExternalName extracts the resolved managed resource's external name from its
+// external name annotation.
+func ExternalName() ExtractValueFn {
+	return func(mg resource.Managed) string {
+		return meta.GetExternalName(mg)
+	}
+}
+
+// A ResolutionRequest requests that a reference to a particular kind of
+// managed resource be resolved. Alternatively it may request that a value be
+// resolved from a key of a ConfigMap or Secret, in which case To and Extract
+// are not used. The Default value is resolved if no reference was provided,
+// or if resolution of an optional reference fails for a reason other than
+// being denied by policy.
+type ResolutionRequest struct {
+	CurrentValue    string
+	Reference       *prv1.ResolvableReference
+	Selector        *prv1.Selector
+	ConfigMapKeyRef *prv1.ResolvableKeySelector
+	SecretKeyRef    *prv1.ResolvableKeySelector
+	To              To
+	Extract         ExtractValueFn
+	Default         string
+}
+
+// IsOptional returns true if the resolution policy of the supplied
+// ResolutionRequest's reference, value reference, or selector is optional.
+func (rr *ResolutionRequest) IsOptional() bool {
+	switch {
+	case rr.Reference != nil:
+		return rr.Reference.Policy.IsResolutionPolicyOptional()
+	case rr.ConfigMapKeyRef != nil:
+		return rr.ConfigMapKeyRef.Policy.IsResolutionPolicyOptional()
+	case rr.SecretKeyRef != nil:
+		return rr.SecretKeyRef.Policy.IsResolutionPolicyOptional()
+	case rr.Selector != nil:
+		return rr.Selector.Policy.IsResolutionPolicyOptional()
+	}
+	return false
+}
+
+// IsNoOp returns true if the supplied ResolutionRequest cannot or should not be
+// processed.
+func (rr *ResolutionRequest) IsNoOp() bool {
+	isAlways := false
+	switch {
+	case rr.Reference != nil:
+		isAlways = rr.Reference.Policy.IsResolvePolicyAlways()
+	case rr.Selector != nil:
+		isAlways = rr.Selector.Policy.IsResolvePolicyAlways()
+	case rr.ConfigMapKeyRef != nil:
+		isAlways = rr.ConfigMapKeyRef.Policy.IsResolvePolicyAlways()
+	case rr.SecretKeyRef != nil:
+		isAlways = rr.SecretKeyRef.Policy.IsResolvePolicyAlways()
+	}
+
+	// We don't resolve values that are already set (if reference resolution
+	// policy is not set to Always); we effectively cache resolved values. The
+	// CR author can invalidate the cache and trigger a new resolution by
+	// explicitly clearing the resolved value.
+	if rr.CurrentValue != "" && !isAlways {
+		return true
+	}
+
+	// We can't resolve anything if neither a reference, a value reference,
+	// nor a selector were provided.
+	return rr.Reference == nil && rr.Selector == nil && rr.ConfigMapKeyRef == nil && rr.SecretKeyRef == nil
+}
+
+// A ResolutionResponse returns the result of a reference resolution. The
+// returned values are always safe to set if resolution was successful.
+type ResolutionResponse struct {
+	ResolvedValue     string
+	ResolvedReference *prv1.ResolvableReference
+}
+
+// Validate this ResolutionResponse.
+func (rr ResolutionResponse) Validate() error {
+	if rr.ResolvedValue == "" {
+		return errors.New(errNoValue)
+	}
+
+	return nil
+}
+
+// A MultiResolutionRequest requests that several references to a particular
+// kind of managed resource be resolved.
+type MultiResolutionRequest struct {
+	CurrentValues []string
+	References    []prv1.ResolvableReference
+	Selector      *prv1.Selector
+	To            To
+	Extract       ExtractValueFn
+}
+
+// IsNoOp returns true if the supplied MultiResolutionRequest cannot or should
+// not be processed.
+func (rr *MultiResolutionRequest) IsNoOp() bool {
+	isAlways := false
+	if rr.Selector != nil {
+		isAlways = rr.Selector.Policy.IsResolvePolicyAlways()
+	}
+
+	// We don't resolve values that are already set (if reference resolution
+	// policy is not set to Always); we effectively cache resolved values. The
+	// CR author can invalidate the cache and trigger a new resolution by
+	// explicitly clearing the resolved values. This is a little unintuitive
+	// for the APIMultiResolver but mimics the APIResolver implementation.
+	if len(rr.CurrentValues) > 0 && !isAlways {
+		return true
+	}
+
+	// We can't resolve anything if neither a reference nor a selector were
+	// provided.
+	return len(rr.References) == 0 && rr.Selector == nil
+}
+
+// A MultiResolutionResponse returns the result of several reference
+// resolutions. ResolvedValues, ResolvedReferences, and Errors are ordered
+// consistently with one another, and with the references of the request when
+// it was resolved by reference. The value of a reference that could not be
+// resolved is empty, and its error is non-nil.
+type MultiResolutionResponse struct {
+	ResolvedValues     []string
+	ResolvedReferences []prv1.ResolvableReference
+	Errors             []error
+}
+
+// Validate this MultiResolutionResponse.
+func (rr MultiResolutionResponse) Validate() error {
+	if len(rr.ResolvedValues) == 0 {
+		return errNotFound{errors.New(errNoMatches)}
+	}
+
+	errs := make([]error, 0, len(rr.ResolvedValues))
+	for i, v := range rr.ResolvedValues {
+		if v == "" {
+			errs = append(errs, errors.Wrapf(errors.New(errNoValue), errFmtResolveIndex, i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Err returns an error joining the errors of each reference that could not be
+// resolved, or nil if all were resolved.
+func (rr MultiResolutionResponse) Err() error {
+	errs := make([]error, 0, len(rr.Errors))
+	for i, err := range rr.Errors {
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, errFmtResolveIndex, i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// An APIResolver selects and resolves references to managed resources in the
+// Kubernetes API server.
+type APIResolver struct {
+	client         client.Reader
+	from           resource.Managed
+	crossNamespace prv1.CrossNamespacePolicy
+	cache          *ResolutionCache
+}
+
+// An APIResolverOption configures an APIResolver.
+type APIResolverOption func(*APIResolver)
+
+// WithCrossNamespacePolicy configures whether an APIResolver allows
+// references to managed resources in a namespace other than that of the
+// referencing resource. Cross namespace references are allowed by default.
+// When they are denied no reference may cross namespaces, regardless of its
+// own policy.
+func WithCrossNamespacePolicy(p prv1.CrossNamespacePolicy) APIResolverOption {
+	return func(r *APIResolver) {
+		r.crossNamespace = p
+	}
+}
+
+// NewAPIResolver returns a Resolver that selects and resolves references from
+// the supplied managed resource to other managed resources in the Kubernetes
+// API server.
+func NewAPIResolver(c client.Reader, from resource.Managed, o ...APIResolverOption) *APIResolver {
+	r := &APIResolver{client: c, from: from, crossNamespace: prv1.CrossNamespacePolicyAllow}
+	for _, fn := range o {
+		fn(r)
+	}
+	return r
+}
+
+// Resolve the supplied ResolutionRequest. The returned ResolutionResponse
+// always contains valid values unless an error was returned. A reference
+// takes precedence over a ConfigMap or Secret key reference, which takes
+// precedence over a selector. A selector resolves to the first matching
+// candidate ordered by namespace and name, so that the same candidate is
+// selected on every reconcile. If the selector requires that controllers
+// match, a reference must also be to a managed resource that is controlled by
+// the controller of the referencing resource. The request's Default value is
+// resolved if no value would otherwise be resolved, or if an optional
+// reference could not be resolved, in which case no error is returned. A
+// reference that is denied by policy is never resolved to the Default value,
+// even if it is optional; its denial is returned as an error that satisfies
+// IsDenied.
+func (r *APIResolver) Resolve(ctx context.Context, req ResolutionRequest) (ResolutionResponse, error) {
+	rsp, err := r.resolve(ctx, req)
+	if err != nil {
+		if !req.IsOptional() || IsDenied(err) {
+			return rsp, err
+		}
+		return ResolutionResponse{ResolvedValue: req.Default, ResolvedReference: req.Reference}, nil
+	}
+	if rsp.ResolvedValue == "" {
+		rsp.ResolvedValue = req.Default
+	}
+	return rsp, nil
+}
+
+func (r *APIResolver) resolve(ctx context.Context, req ResolutionRequest) (ResolutionResponse, error) {
+	// Return early if from is being deleted, or the request is a no-op.
+	if meta.WasDeleted(r.from) || req.IsNoOp() {
+		return ResolutionResponse{ResolvedValue: req.CurrentValue, ResolvedReference: req.Reference}, nil
+	}
+
+	// The reference is already set - resolve it.
+	if req.Reference != nil {
+		nn := r.namespacedName(req.Reference.Reference)
+		if err := r.checkCrossNamespace(nn, req.Reference.Policy); err != nil {
+			return ResolutionResponse{}, err
+		}
+		var k resolutionCacheKey
+		cached := false
+		if r.cache != nil {
+			k = r.cache.key(nn, req.To.Managed)
+			cached = r.cache.get(k, req.To.Managed)
+		}
+		if !cached {
+			if err := r.client.Get(ctx, nn, req.To.Managed); err != nil {
+				return ResolutionResponse{}, errors.Wrap(err, errGetManaged)
+			}
+		}
+
+		// A selector that requires controllers to match constrains the
+		// references it selected too, so we verify that the reference still
+		// satisfies it.
+		if ControllersMustMatch(req.Selector) && !meta.HaveSameController(r.from, req.To.Managed) {
+			return ResolutionResponse{}, errors.Errorf(errFmtControllerMismatch, nn.Namespace, nn.Name)
+		}
+
+		rsp := ResolutionResponse{ResolvedValue: req.Extract(req.To.Managed), ResolvedReference: req.Reference}
+		if err := rsp.Validate(); err != nil {
+			return rsp, err
+		}
+		// Only successful resolutions are cached, so that we keep reading
+		// referenced resources until they're ready.
+		if r.cache != nil && !cached {
+			r.cache.add(k, req.To.Managed)
+		}
+		return rsp, nil
+	}
+
+	// A ConfigMap or Secret key reference is set - resolve its value.
+	if req.ConfigMapKeyRef != nil || req.SecretKeyRef != nil {
+		v, err := r.resolveValue(ctx, req)
+		if err != nil {
+			return ResolutionResponse{}, err
+		}
+		rsp := ResolutionResponse{ResolvedValue: v}
+		return rsp, rsp.Validate()
+	}
+
+	// The reference was not set, but a selector was. Select a reference.
+	if err := r.client.List(ctx, req.To.List, r.listOptions(req.Selector)...); err != nil {
+		return ResolutionResponse{}, errors.Wrap(err, errListManaged)
+	}
+
+	items := req.To.List.GetItems()
+	sortByNamespacedName(items)
+
+	for _, to := range items {
+		if ControllersMustMatch(req.Selector) && !meta.HaveSameController(r.from, to) {
+			continue
+		}
+
+		rsp := ResolutionResponse{
+			ResolvedValue:     req.Extract(to),
+			ResolvedReference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: to.GetName(), Namespace: to.GetNamespace()}},
+		}
+		return rsp, rsp.Validate()
+	}
+
+	// We couldn't resolve anything.
+	return ResolutionResponse{}, errNotFound{errors.New(errNoMatches)}
+}
+
+// ResolveMultiple resolves the supplied MultiResolutionRequest. References are
+// resolved individually, preserving their order. The returned response
+// includes every reference that could be resolved even if others could not,
+// in which case an error joining the error of each unresolved reference is
+// also returned. A selector resolves to every matching candidate, ordered by
+// namespace and name.
+func (r *APIResolver) ResolveMultiple(ctx context.Context, req MultiResolutionRequest) (MultiResolutionResponse, error) {
+	// Return early if from is being deleted, or the request is a no-op.
+	if meta.WasDeleted(r.from) || req.IsNoOp() {
+		return MultiResolutionResponse{ResolvedValues: req.CurrentValues, ResolvedReferences: req.References}, nil
+	}
+
+	// The references are already set - resolve them.
+	if len(req.References) > 0 {
+		rsp := MultiResolutionResponse{
+			ResolvedValues:     make([]string, len(req.References)),
+			ResolvedReferences: req.References,
+			Errors:             make([]error, len(req.References)),
+		}
+		for i := range req.References {
+			single, err := r.Resolve(ctx, ResolutionRequest{
+				Reference: &req.References[i],
+				Selector:  req.Selector,
+				To:        req.To,
+				Extract:   req.Extract,
+			})
+			rsp.ResolvedValues[i] = single.ResolvedValue
+			rsp.Errors[i] = err
+		}
+		return rsp, rsp.Err()
+	}
+
+	// No references were set, but a selector was. Select and resolve
+	// references.
+	if err := r.client.List(ctx, req.To.List, r.listOptions(req.Selector)...); err != nil {
+		return MultiResolutionResponse{}, errors.Wrap(err, errListManaged)
+	}
+
+	items := req.To.List.GetItems()
+	sortByNamespacedName(items)
+
+	rsp := MultiResolutionResponse{}
+	for _, to := range items {
+		if ControllersMustMatch(req.Selector) && !meta.HaveSameController(r.from, to) {
+			continue
+		}
+
+		rsp.ResolvedValues = append(rsp.ResolvedValues, req.Extract(to))
+		rsp.ResolvedReferences = append(rsp.ResolvedReferences, prv1.ResolvableReference{Reference: prv1.Reference{Name: to.GetName(), Namespace: to.GetNamespace()}})
+	}
+
+	return rsp, rsp.Validate()
+}
+
+// resolveValue resolves the value of the ConfigMap or Secret key referenced by
+// the supplied ResolutionRequest.
+func (r *APIResolver) resolveValue(ctx context.Context, req ResolutionRequest) (string, error) {
+	if ref := req.ConfigMapKeyRef; ref != nil {
+		nn := r.namespacedName(ref.Reference)
+		if err := r.checkCrossNamespace(nn, ref.Policy); err != nil {
+			return "", err
+		}
+		cm := &corev1.ConfigMap{}
+		if err := r.client.Get(ctx, nn, cm); err != nil {
+			return "", errors.Wrap(err, errGetConfigMap)
+		}
+		if v, ok := cm.Data[ref.Key]; ok {
+			return v, nil
+		}
+		if v, ok := cm.BinaryData[ref.Key]; ok {
+			return string(v), nil
+		}
+		return "", errors.Errorf(errFmtKeyNotFound, ref.Key, "configmap", nn.Namespace, nn.Name)
+	}
+
+	ref := req.SecretKeyRef
+	nn := r.namespacedName(ref.Reference)
+	if err := r.checkCrossNamespace(nn, ref.Policy); err != nil {
+		return "", err
+	}
+	s := &corev1.Secret{}
+	if err := r.client.Get(ctx, nn, s); err != nil {
+		return "", errors.Wrap(err, errGetSecret)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf(errFmtKeyNotFound, ref.Key, "secret", nn.Namespace, nn.Name)
+	}
+	return string(v), nil
+}
+
+// namespacedName returns the namespace and name of the object the supplied
+// reference refers to. The namespace of the referencing resource is used if
+// the reference doesn't specify one.
+func (r *APIResolver) namespacedName(ref prv1.Reference) types.NamespacedName {
+	nn := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if nn.Namespace == "" {
+		nn.Namespace = r.from.GetNamespace()
+	}
+	return nn
+}
+
+// checkCrossNamespace returns an error if the supplied referenced object is in
+// a namespace other than that of the referencing resource, and either this
+// resolver or the supplied reference policy denies cross namespace
+// references.
+func (r *APIResolver) checkCrossNamespace(nn types.NamespacedName, p *prv1.Policy) error {
+	if nn.Namespace == r.from.GetNamespace() {
+		return nil
+	}
+	if r.crossNamespace != prv1.CrossNamespacePolicyDeny && !p.IsCrossNamespacePolicyDeny() {
+		return nil
+	}
+	return errDenied{errors.Errorf(errFmtCrossNamespace, nn.Namespace, nn.Name, r.from.GetNamespace())}
+}
+
+func sortByNamespacedName(items []resource.Managed) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].GetNamespace() != items[j].GetNamespace() {
+			return items[i].GetNamespace() < items[j].GetNamespace()
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+}
+
+func (r *APIResolver) listOptions(s *prv1.Selector) []client.ListOption {
+	opts := []client.ListOption{client.MatchingLabels(s.MatchLabels)}
+	if ns := r.from.GetNamespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	return opts
+}
+
+// ControllersMustMatch returns true if the supplied Selector requires that a
+// reference be to a managed resource whose controller reference matches the
+// referencing resource.
+func ControllersMustMatch(s *prv1.Selector) bool {
+	if s == nil {
+		return false
+	}
+	return s.MatchControllerRef != nil && *s.MatchControllerRef
+}
@@ -0,0 +1,606 @@
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/ptr"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+// FakeManagedList is a mock that implements ManagedList interface.
+type FakeManagedList struct {
+	client.ObjectList
+	Items []resource.Managed
+}
+
+// GetItems returns the list of managed resources.
+func (fml *FakeManagedList) GetItems() []resource.Managed {
+	return fml.Items
+}
+
+func managed(name, value string, controller types.UID) *fake.Managed {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "coolns"}}
+	meta.SetExternalName(mg, value)
+	if controller != "" {
+		mg.SetOwnerReferences([]metav1.OwnerReference{{UID: controller, Controller: ptr.To(true)}})
+	}
+	return mg
+}
+
+func TestResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+	value := "coolv"
+	ref := &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool"}}
+
+	controlled := managed("from", "", "owner")
+	crossRef := &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool", Namespace: "otherns"}}
+	optionalRef := &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool"}, Policy: &prv1.Policy{
+		Resolution: ptr.To(prv1.ResolutionPolicyOptional),
+	}}
+	alwaysRef := &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool"}, Policy: &prv1.Policy{
+		Resolve: ptr.To(prv1.ResolvePolicyAlways),
+	}}
+	deniedRef := &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool", Namespace: "otherns"}, Policy: &prv1.Policy{
+		CrossNamespace: ptr.To(prv1.CrossNamespacePolicyDeny),
+	}}
+	optionalDeniedRef := &prv1.ResolvableReference{Reference: prv1.Reference{Name: "cool", Namespace: "otherns"}, Policy: &prv1.Policy{
+		Resolution:     ptr.To(prv1.ResolutionPolicyOptional),
+		CrossNamespace: ptr.To(prv1.CrossNamespacePolicyDeny),
+	}}
+
+	type args struct {
+		from resource.Managed
+		o    []APIResolverOption
+		req  ResolutionRequest
+	}
+	type want struct {
+		rsp ResolutionResponse
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		args   args
+		want   want
+	}{
+		"FromDeleted": {
+			reason: "Should return early if the referencer is being deleted",
+			args: args{
+				from: &fake.Managed{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			},
+		},
+		"AlreadyResolved": {
+			reason: "Should return early if the current value is non-zero",
+			args: args{
+				from: &fake.Managed{},
+				req:  ResolutionRequest{CurrentValue: value, Reference: ref},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value, ResolvedReference: ref}},
+		},
+		"AlwaysResolveReference": {
+			reason: "The reference should be resolved even if a value is set when the resolve policy is Always",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					CurrentValue: "oldv",
+					Reference:    alwaysRef,
+					To:           To{Managed: &fake.Managed{}},
+					Extract:      func(resource.Managed) string { return value },
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value, ResolvedReference: alwaysRef}},
+		},
+		"Unresolvable": {
+			reason: "Should return early if neither a reference or selector were provided",
+			args: args{
+				from: &fake.Managed{},
+			},
+		},
+		"DefaultWhenAbsent": {
+			reason: "The default value should be resolved if neither a reference or selector were provided",
+			args: args{
+				from: &fake.Managed{},
+				req:  ResolutionRequest{Default: "defaultv"},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: "defaultv"}},
+		},
+		"DefaultWhenOptionalFails": {
+			reason: "The default value should be resolved without error if an optional reference cannot be resolved",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Reference: optionalRef,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+					Default:   "defaultv",
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: "defaultv", ResolvedReference: optionalRef}},
+		},
+		"NoDefaultWhenRequiredFails": {
+			reason: "The default value should not be resolved if a required reference cannot be resolved",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Reference: ref,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+					Default:   "defaultv",
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetManaged)},
+		},
+		"GetError": {
+			reason: "Should return errors encountered while getting the referenced resource",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Reference: ref,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetManaged)},
+		},
+		"ResolvedNoValue": {
+			reason: "Should return an error if the extract function returns the empty string",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Reference: ref,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   func(resource.Managed) string { return "" },
+				},
+			},
+			want: want{
+				rsp: ResolutionResponse{ResolvedReference: ref},
+				err: errors.New(errNoValue),
+			},
+		},
+		"SuccessfulResolve": {
+			reason: "No error should be returned when the value is successfully extracted",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Reference: ref,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   func(resource.Managed) string { return value },
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value, ResolvedReference: ref}},
+		},
+		"CrossNamespaceAllowed": {
+			reason: "References to other namespaces should be resolved by default",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			args: args{
+				from: managed("from", "", ""),
+				req: ResolutionRequest{
+					Reference: crossRef,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   func(resource.Managed) string { return value },
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value, ResolvedReference: crossRef}},
+		},
+		"CrossNamespaceDeniedByPolicy": {
+			reason: "Should return an error if the reference policy denies references to other namespaces",
+			args: args{
+				from: managed("from", "", ""),
+				req: ResolutionRequest{
+					Reference: deniedRef,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+				},
+			},
+			want: want{err: errDenied{errors.Errorf(errFmtCrossNamespace, "otherns", "cool", "coolns")}},
+		},
+		"OptionalCrossNamespaceDenied": {
+			reason: "Should return an error rather than the default value if an optional reference is denied by policy",
+			args: args{
+				from: managed("from", "", ""),
+				req: ResolutionRequest{
+					Reference: optionalDeniedRef,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+					Default:   "defaultv",
+				},
+			},
+			want: want{err: errDenied{errors.Errorf(errFmtCrossNamespace, "otherns", "cool", "coolns")}},
+		},
+		"CrossNamespaceDeniedByResolver": {
+			reason: "Should return an error if the resolver denies references to other namespaces",
+			args: args{
+				from: managed("from", "", ""),
+				o:    []APIResolverOption{WithCrossNamespacePolicy(prv1.CrossNamespacePolicyDeny)},
+				req: ResolutionRequest{
+					Reference: crossRef,
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   ExternalName(),
+				},
+			},
+			want: want{err: errDenied{errors.Errorf(errFmtCrossNamespace, "otherns", "cool", "coolns")}},
+		},
+		"ConfigMapKeyRef": {
+			reason: "The value of a referenced ConfigMap key should be resolved",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.ConfigMap).Data = map[string]string{"cool": value}
+				return nil
+			})},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					ConfigMapKeyRef: &prv1.ResolvableKeySelector{ResolvableReference: prv1.ResolvableReference{Reference: prv1.Reference{Name: "cm"}}, Key: "cool"},
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value}},
+		},
+		"ConfigMapKeyNotFound": {
+			reason: "Should return an error if the referenced ConfigMap key does not exist",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			args: args{
+				from: managed("from", "", ""),
+				req: ResolutionRequest{
+					ConfigMapKeyRef: &prv1.ResolvableKeySelector{ResolvableReference: prv1.ResolvableReference{Reference: prv1.Reference{Name: "cm"}}, Key: "cool"},
+				},
+			},
+			want: want{err: errors.Errorf(errFmtKeyNotFound, "cool", "configmap", "coolns", "cm")},
+		},
+		"SecretKeyRefGetError": {
+			reason: "Should return errors encountered while getting the referenced Secret",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					SecretKeyRef: &prv1.ResolvableKeySelector{ResolvableReference: prv1.ResolvableReference{Reference: prv1.Reference{Name: "s"}}, Key: "cool"},
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errGetSecret)},
+		},
+		"SecretKeyRef": {
+			reason: "The value of a referenced Secret key should be resolved",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"cool": []byte(value)}
+				return nil
+			})},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					SecretKeyRef: &prv1.ResolvableKeySelector{ResolvableReference: prv1.ResolvableReference{Reference: prv1.Reference{Name: "s"}}, Key: "cool"},
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value}},
+		},
+		"ReferenceControllerMismatch": {
+			reason: "Should return an error if a reference is to a resource with a different controller when controllers must match",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.SetOwnerReferences([]metav1.OwnerReference{{UID: "other", Controller: ptr.To(true)}})
+				return nil
+			})},
+			args: args{
+				from: controlled,
+				req: ResolutionRequest{
+					Reference: ref,
+					Selector:  &prv1.Selector{MatchControllerRef: ptr.To(true)},
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   func(resource.Managed) string { return value },
+				},
+			},
+			want: want{err: errors.Errorf(errFmtControllerMismatch, "coolns", "cool")},
+		},
+		"ReferenceControllerMatch": {
+			reason: "A reference to a resource with the same controller should be resolved when controllers must match",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.SetOwnerReferences([]metav1.OwnerReference{{UID: "owner", Controller: ptr.To(true)}})
+				return nil
+			})},
+			args: args{
+				from: controlled,
+				req: ResolutionRequest{
+					Reference: ref,
+					Selector:  &prv1.Selector{MatchControllerRef: ptr.To(true)},
+					To:        To{Managed: &fake.Managed{}},
+					Extract:   func(resource.Managed) string { return value },
+				},
+			},
+			want: want{rsp: ResolutionResponse{ResolvedValue: value, ResolvedReference: ref}},
+		},
+		"ListError": {
+			reason: "Should return errors encountered while listing potential referenced resources",
+			c:      &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Selector: &prv1.Selector{},
+					To:       To{List: &FakeManagedList{}},
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errListManaged)},
+		},
+		"NoMatches": {
+			reason: "Should return an error when no managed resources match the selector",
+			c:      &test.MockClient{MockList: test.NewMockListFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Selector: &prv1.Selector{},
+					To:       To{List: &FakeManagedList{}},
+				},
+			},
+			want: want{err: errNotFound{errors.New(errNoMatches)}},
+		},
+		"SuccessfulSelect": {
+			reason: "The first candidate ordered by name should be selected",
+			c:      &test.MockClient{MockList: test.NewMockListFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					Selector: &prv1.Selector{MatchLabels: map[string]string{"cool": "true"}},
+					To: To{List: &FakeManagedList{Items: []resource.Managed{
+						managed("b", "bv", ""),
+						managed("a", "av", ""),
+					}}},
+					Extract: ExternalName(),
+				},
+			},
+			want: want{rsp: ResolutionResponse{
+				ResolvedValue:     "av",
+				ResolvedReference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "a", Namespace: "coolns"}},
+			}},
+		},
+		"AlwaysResolveSelector": {
+			reason: "The selector should be resolved even if a value is set when the resolve policy is Always",
+			c:      &test.MockClient{MockList: test.NewMockListFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: ResolutionRequest{
+					CurrentValue: "oldv",
+					Selector: &prv1.Selector{Policy: &prv1.Policy{
+						Resolve: ptr.To(prv1.ResolvePolicyAlways),
+					}},
+					To:      To{List: &FakeManagedList{Items: []resource.Managed{managed("a", "av", "")}}},
+					Extract: ExternalName(),
+				},
+			},
+			want: want{rsp: ResolutionResponse{
+				ResolvedValue:     "av",
+				ResolvedReference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "a", Namespace: "coolns"}},
+			}},
+		},
+		"SuccessfulSelectMatchControllerRef": {
+			reason: "Only candidates with the same controller should be selected when controllers must match",
+			c:      &test.MockClient{MockList: test.NewMockListFn(nil)},
+			args: args{
+				from: controlled,
+				req: ResolutionRequest{
+					Selector: &prv1.Selector{MatchControllerRef: ptr.To(true)},
+					To: To{List: &FakeManagedList{Items: []resource.Managed{
+						managed("a", "av", "other"),
+						managed("b", "bv", "owner"),
+					}}},
+					Extract: ExternalName(),
+				},
+			},
+			want: want{rsp: ResolutionResponse{
+				ResolvedValue:     "bv",
+				ResolvedReference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "b", Namespace: "coolns"}},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewAPIResolver(tc.c, tc.args.from, tc.args.o...)
+			got, err := r.Resolve(context.Background(), tc.args.req)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rsp, got); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResolveMultiple(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+	refs := []prv1.ResolvableReference{{Reference: prv1.Reference{Name: "a"}}, {Reference: prv1.Reference{Name: "missing"}}, {Reference: prv1.Reference{Name: "b"}}}
+
+	// getter returns a client that gets managed resources with an external
+	// name matching their name, except for the missing resource.
+	getter := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name == "missing" {
+			return errBoom
+		}
+		meta.SetExternalName(obj, key.Name+"v")
+		return nil
+	}}
+
+	type args struct {
+		from resource.Managed
+		req  MultiResolutionRequest
+	}
+	type want struct {
+		rsp MultiResolutionResponse
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		args   args
+		want   want
+	}{
+		"FromDeleted": {
+			reason: "Should return early if the referencer is being deleted",
+			args: args{
+				from: &fake.Managed{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			},
+		},
+		"AlreadyResolved": {
+			reason: "Should return early if the current values are set",
+			args: args{
+				from: &fake.Managed{},
+				req:  MultiResolutionRequest{CurrentValues: []string{"av"}, References: refs[:1]},
+			},
+			want: want{rsp: MultiResolutionResponse{ResolvedValues: []string{"av"}, ResolvedReferences: refs[:1]}},
+		},
+		"MixedResolution": {
+			reason: "Resolved and unresolved references should be returned in order, with per-item errors",
+			c:      getter,
+			args: args{
+				from: &fake.Managed{},
+				req: MultiResolutionRequest{
+					References: refs,
+					To:         To{Managed: &fake.Managed{}},
+					Extract:    ExternalName(),
+				},
+			},
+			want: want{
+				rsp: MultiResolutionResponse{
+					ResolvedValues:     []string{"av", "", "bv"},
+					ResolvedReferences: refs,
+					Errors:             []error{nil, errors.Wrap(errBoom, errGetManaged), nil},
+				},
+				err: errors.Join(errors.Wrapf(errors.Wrap(errBoom, errGetManaged), errFmtResolveIndex, 1)),
+			},
+		},
+		"ListError": {
+			reason: "Should return errors encountered while listing potential referenced resources",
+			c:      &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			args: args{
+				from: &fake.Managed{},
+				req: MultiResolutionRequest{
+					Selector: &prv1.Selector{},
+					To:       To{List: &FakeManagedList{}},
+				},
+			},
+			want: want{err: errors.Wrap(errBoom, errListManaged)},
+		},
+		"NoMatches": {
+			reason: "Should return an error when no managed resources match the selector",
+			c:      &test.MockClient{MockList: test.NewMockListFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: MultiResolutionRequest{
+					Selector: &prv1.Selector{},
+					To:       To{List: &FakeManagedList{}},
+				},
+			},
+			want: want{err: errNotFound{errors.New(errNoMatches)}},
+		},
+		"SuccessfulSelect": {
+			reason: "All matching candidates should be selected, ordered by name",
+			c:      &test.MockClient{MockList: test.NewMockListFn(nil)},
+			args: args{
+				from: &fake.Managed{},
+				req: MultiResolutionRequest{
+					Selector: &prv1.Selector{},
+					To: To{List: &FakeManagedList{Items: []resource.Managed{
+						managed("b", "bv", ""),
+						managed("a", "av", ""),
+					}}},
+					Extract: ExternalName(),
+				},
+			},
+			want: want{rsp: MultiResolutionResponse{
+				ResolvedValues: []string{"av", "bv"},
+				ResolvedReferences: []prv1.ResolvableReference{
+					{Reference: prv1.Reference{Name: "a", Namespace: "coolns"}},
+					{Reference: prv1.Reference{Name: "b", Namespace: "coolns"}},
+				},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewAPIResolver(tc.c, tc.args.from)
+			got, err := r.ResolveMultiple(context.Background(), tc.args.req)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveMultiple(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rsp, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveMultiple(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"APINotFound": {
+			err:  errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: "buckets"}, "cool"), errGetManaged),
+			want: true,
+		},
+		"NoMatches": {
+			err:  errors.Join(errors.Wrapf(errNotFound{errors.New(errNoMatches)}, errFmtResolveIndex, 0)),
+			want: true,
+		},
+		"OtherError": {
+			err:  errors.New(errNoValue),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsNotFound(tc.err)); diff != "" {
+				t.Errorf("IsNotFound(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsDenied(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"CrossNamespace": {
+			err:  errors.Wrap(errDenied{errors.Errorf(errFmtCrossNamespace, "other", "bucket", "default")}, errGetManaged),
+			want: true,
+		},
+		"NotFound": {
+			err:  errNotFound{errors.New(errNoMatches)},
+			want: false,
+		},
+		"OtherError": {
+			err:  errors.New(errNoValue),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsDenied(tc.err)); diff != "" {
+				t.Errorf("IsDenied(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package reference
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+)
+
+// FinalizerReferencedBy is the finalizer added to resources whose deletion
+// should be delayed while other resources reference them.
+const FinalizerReferencedBy = "referenced-by.krateo.io"
+
+// Error strings.
+const (
+	errListReferencers = "cannot list referencers"
+	errFmtInUse        = "in use by %s"
+)
+
+type errInUse struct{ error }
+
+func (e errInUse) InUse() bool {
+	return true
+}
+
+// IsInUse returns true if the supplied error indicates that a resource could
+// not be finalized because it is referenced by other resources.
+func IsInUse(err error) bool {
+	_, ok := err.(interface { //nolint: errorlint // Skip errorlint for interface type
+		InUse() bool
+	})
+	return ok
+}
+
+// A ReferencedByFinalizer adds and removes the referenced-by finalizer to and
+// from a resource. The finalizer is not removed while other resources
+// reference the resource, which delays its deletion and avoids breaking the
+// resources that depend on it. Referencers are listed using the supplied list
+// kind, which must be indexed using IndexReferences.
+type ReferencedByFinalizer struct {
+	client      client.Client
+	finalizer   resource.Finalizer
+	referencers client.ObjectList
+	referenced  schema.GroupKind
+}
+
+// NewReferencedByFinalizer returns a new ReferencedByFinalizer for resources
+// of the supplied referenced kind.
+func NewReferencedByFinalizer(c client.Client, referencers client.ObjectList, referenced schema.GroupKind) *ReferencedByFinalizer {
+	return &ReferencedByFinalizer{
+		client:      c,
+		finalizer:   resource.NewAPIFinalizer(c, FinalizerReferencedBy),
+		referencers: referencers,
+		referenced:  referenced,
+	}
+}
+
+// ReferencedBy returns the names of the resources that reference the supplied
+// resource, sorted by namespace and name. The names of namespaced referencers
+// are prefixed with their namespace.
+func (f *ReferencedByFinalizer) ReferencedBy(ctx context.Context, o client.Object) ([]string, error) {
+	key := ReferenceIndexKey(f.referenced, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()})
+
+	l := f.referencers.DeepCopyObject().(client.ObjectList)
+	if err := f.client.List(ctx, l, client.MatchingFields{IndexFieldReferences: key}); err != nil {
+		return nil, errors.Wrap(err, errListReferencers)
+	}
+
+	items, err := kmeta.ExtractList(l)
+	if err != nil {
+		return nil, errors.Wrap(err, errListReferencers)
+	}
+
+	names := make([]string, 0, len(items))
+	for _, i := range items {
+		m, err := kmeta.Accessor(i)
+		if err != nil {
+			return nil, errors.Wrap(err, errListReferencers)
+		}
+		if m.GetUID() != "" && m.GetUID() == o.GetUID() {
+			// A resource that references itself doesn't block its own
+			// deletion.
+			continue
+		}
+		name := m.GetName()
+		if m.GetNamespace() != "" {
+			name = m.GetNamespace() + "/" + name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// AddFinalizer to the supplied resource.
+func (f *ReferencedByFinalizer) AddFinalizer(ctx context.Context, obj resource.Object) error {
+	return f.finalizer.AddFinalizer(ctx, obj)
+}
+
+// RemoveFinalizer from the supplied resource, unless it is referenced by
+// other resources. If it is, an error satisfying IsInUse is returned and, if
+// the resource has conditions, an InUseByReferencers condition listing the
+// referencers is set.
+func (f *ReferencedByFinalizer) RemoveFinalizer(ctx context.Context, obj resource.Object) error {
+	names, err := f.ReferencedBy(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		if c, ok := obj.(resource.Conditioned); ok {
+			c.SetConditions(prv1.InUseByReferencers(names...))
+		}
+		return errInUse{errors.Errorf(errFmtInUse, strings.Join(names, ", "))}
+	}
+	return f.finalizer.RemoveFinalizer(ctx, obj)
+}
@@ -0,0 +1,107 @@
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestReferencedByFinalizer(t *testing.T) {
+	secrets := schema.GroupKind{Kind: "Secret"}
+
+	// Config maps reference the secret named by their "secret" key.
+	refs := func(o client.Object) []string {
+		cm := o.(*corev1.ConfigMap)
+		if cm.Data["secret"] == "" {
+			return nil
+		}
+		return []string{ReferenceIndexKey(secrets, types.NamespacedName{Namespace: cm.GetNamespace(), Name: cm.Data["secret"]})}
+	}
+	referencer := func(name, secret string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: name},
+			Data:       map[string]string{"secret": secret},
+		}
+	}
+	referenced := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "coolns",
+			Name:       name,
+			Finalizers: []string{FinalizerReferencedBy},
+		}}
+	}
+
+	type want struct {
+		finalizers []string
+		conditions []prv1.Condition
+		inUse      bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		obj    resource.Object
+		want   want
+	}{
+		"InUse": {
+			reason: "The finalizer should not be removed while the resource is referenced, and its referencers should be listed in a condition.",
+			obj: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "coolns",
+				Name:       "creds",
+				Finalizers: []string{FinalizerReferencedBy},
+			}},
+			want: want{
+				finalizers: []string{FinalizerReferencedBy},
+				conditions: []prv1.Condition{prv1.InUseByReferencers("coolns/a", "coolns/b")},
+				inUse:      true,
+			},
+		},
+		"NotInUse": {
+			reason: "The finalizer should be removed once the resource is no longer referenced.",
+			obj:    referenced("unused"),
+			want:   want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := clientfake.NewClientBuilder().
+				WithObjects(referencer("b", "creds"), referencer("a", "creds"), referencer("c", "other"), referenced("unused")).
+				WithIndex(&corev1.ConfigMap{}, IndexFieldReferences, client.IndexerFunc(refs)).
+				Build()
+
+			f := NewReferencedByFinalizer(c, &corev1.ConfigMapList{}, secrets)
+			err := f.RemoveFinalizer(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want.inUse, IsInUse(err)); diff != "" {
+				t.Errorf("\n%s\nIsInUse(RemoveFinalizer(...)): -want, +got:\n%s\nerror: %v", tc.reason, diff, err)
+			}
+			if !tc.want.inUse && err != nil {
+				t.Errorf("\n%s\nRemoveFinalizer(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.finalizers, tc.obj.GetFinalizers(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nRemoveFinalizer(...): -want finalizers, +got finalizers:\n%s", tc.reason, diff)
+			}
+			if cd, ok := tc.obj.(resource.Conditioned); ok {
+				got := []prv1.Condition{}
+				if cond := cd.GetCondition(prv1.TypeInUseByReferencers); cond.Type != "" {
+					got = append(got, cond)
+				}
+				if diff := cmp.Diff(tc.want.conditions, got, test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\nRemoveFinalizer(...): -want conditions, +got conditions:\n%s", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package reference
+
+import (
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errFmtUnresolvedReferences = "cannot resolve %d reference(s)"
+)
+
+// An UnresolvedReference is a reference field that could not be resolved.
+type UnresolvedReference struct {
+	// Field is the path of the reference field, for example
+	// spec.forProvider.networkRef.
+	Field string
+
+	// Err is the error encountered while resolving the reference.
+	Err error
+}
+
+// String returns the field path and the reason the reference could not be
+// resolved.
+func (u UnresolvedReference) String() string {
+	return u.Field + ": " + u.Err.Error()
+}
+
+// UnresolvedReferences records the reference fields of a managed resource
+// that could not be resolved, in the order they were recorded. It is intended
+// to be used by ResolveReferences methods that resolve several references:
+//
+//	u := reference.UnresolvedReferences{}
+//	rsp, err := r.Resolve(ctx, networkReq)
+//	u.Record("spec.forProvider.networkRef", err)
+//	...
+//	return u.Err()
+type UnresolvedReferences []UnresolvedReference
+
+// Record the supplied reference field as unresolved if the supplied error is
+// non-nil.
+func (u *UnresolvedReferences) Record(field string, err error) {
+	if err == nil {
+		return
+	}
+	*u = append(*u, UnresolvedReference{Field: field, Err: err})
+}
+
+// Err returns an error that joins the errors of all unresolved references and
+// from which the unresolved references can be retrieved using
+// GetUnresolvedReferences. It returns nil if all references were resolved.
+func (u UnresolvedReferences) Err() error {
+	if len(u) == 0 {
+		return nil
+	}
+	errs := make([]error, len(u))
+	for i := range u {
+		errs[i] = errors.Wrap(u[i].Err, u[i].Field)
+	}
+	return errUnresolved{
+		error:      errors.Wrapf(errors.Join(errs...), errFmtUnresolvedReferences, len(u)),
+		unresolved: u,
+	}
+}
+
+// Condition returns a ReferencesResolved condition that lists the unresolved
+// reference fields, if any.
+func (u UnresolvedReferences) Condition() prv1.Condition {
+	if len(u) == 0 {
+		return prv1.ReferencesResolved()
+	}
+	s := make([]string, len(u))
+	for i := range u {
+		s[i] = u[i].String()
+	}
+	return prv1.ReferencesUnresolved(s...)
+}
+
+type errUnresolved struct {
+	error
+	unresolved UnresolvedReferences
+}
+
+func (e errUnresolved) Unwrap() error {
+	return e.error
+}
+
+// GetUnresolvedReferences returns the unresolved references recorded by the
+// supplied error, which may be wrapped, and true if it was returned by
+// UnresolvedReferences.Err.
+func GetUnresolvedReferences(err error) (UnresolvedReferences, bool) {
+	var e errUnresolved
+	if !errors.As(err, &e) {
+		return nil, false
+	}
+	return e.unresolved, true
+}
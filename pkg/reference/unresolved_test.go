@@ -0,0 +1,71 @@
+package reference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestUnresolvedReferences(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err        error
+		condition  prv1.Condition
+		unresolved UnresolvedReferences
+	}
+
+	cases := map[string]struct {
+		reason string
+		record map[string]error
+		want   want
+	}{
+		"AllResolved": {
+			reason: "No error should be returned and references should be resolved if no errors were recorded.",
+			record: map[string]error{"spec.aRef": nil},
+			want: want{
+				condition: prv1.ReferencesResolved(),
+			},
+		},
+		"SomeUnresolved": {
+			reason: "Each unresolved reference field should be listed along with why it could not be resolved.",
+			record: map[string]error{"spec.aRef": errBoom, "spec.bRef": nil},
+			want: want{
+				err: errUnresolved{
+					error:      errors.Wrapf(errors.Join(errors.Wrap(errBoom, "spec.aRef")), errFmtUnresolvedReferences, 1),
+					unresolved: UnresolvedReferences{{Field: "spec.aRef", Err: errBoom}},
+				},
+				condition:  prv1.ReferencesUnresolved("spec.aRef: boom"),
+				unresolved: UnresolvedReferences{{Field: "spec.aRef", Err: errBoom}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := UnresolvedReferences{}
+			for _, f := range []string{"spec.aRef", "spec.bRef"} {
+				if err, ok := tc.record[f]; ok {
+					u.Record(f, err)
+				}
+			}
+
+			err := u.Err()
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nErr(): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, u.Condition(), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCondition(): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			got, _ := GetUnresolvedReferences(errors.Wrap(err, "wrapped"))
+			if diff := cmp.Diff(tc.want.unresolved, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetUnresolvedReferences(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package reference
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+)
+
+// ToGroupVersionKind returns a To that references managed resources of the
+// supplied kind. Referenced resources are read as unstructured objects, so the
+// kind need not be registered with the client's scheme. This allows providers
+// to reference resources from other providers without importing their types.
+func ToGroupVersionKind(gvk schema.GroupVersionKind) To {
+	u := &Unstructured{}
+	u.SetGroupVersionKind(gvk)
+
+	l := &UnstructuredList{}
+	l.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	return To{Managed: u, List: l}
+}
+
+// FromUnstructured returns an ExtractValueFn that extracts a value from the
+// unstructured content of the resolved managed resource. Typed managed
+// resources are converted to unstructured content before the supplied function
+// is called. An empty value is extracted if conversion fails.
+func FromUnstructured(fn func(u *unstructured.Unstructured) string) ExtractValueFn {
+	return func(mg resource.Managed) string {
+		if u, ok := mg.(runtime.Unstructured); ok {
+			return fn(&unstructured.Unstructured{Object: u.UnstructuredContent()})
+		}
+		j, err := json.Marshal(mg)
+		if err != nil {
+			return ""
+		}
+		u := &unstructured.Unstructured{}
+		if err := json.Unmarshal(j, &u.Object); err != nil {
+			return ""
+		}
+		return fn(u)
+	}
+}
+
+// An Unstructured managed resource of an arbitrary kind. Its conditions are
+// read from and written to status.conditions.
+type Unstructured struct {
+	unstructured.Unstructured
+}
+
+// GetCondition of this managed resource.
+func (u *Unstructured) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return u.conditionedStatus().GetCondition(ct)
+}
+
+// SetConditions of this managed resource.
+func (u *Unstructured) SetConditions(c ...prv1.Condition) {
+	cs := u.conditionedStatus()
+	cs.SetConditions(c...)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cs)
+	if err != nil {
+		return
+	}
+	if u.Object == nil {
+		u.Object = map[string]any{}
+	}
+	_ = unstructured.SetNestedField(u.Object, content["conditions"], "status", "conditions")
+}
+
+func (u *Unstructured) conditionedStatus() *prv1.ConditionedStatus {
+	cs := &prv1.ConditionedStatus{}
+	status, ok, err := unstructured.NestedMap(u.Object, "status")
+	if err != nil || !ok {
+		return cs
+	}
+	_ = runtime.DefaultUnstructuredConverter.FromUnstructured(status, cs)
+	return cs
+}
+
+// DeepCopyObject of this managed resource.
+func (u *Unstructured) DeepCopyObject() runtime.Object {
+	return &Unstructured{Unstructured: *u.Unstructured.DeepCopy()}
+}
+
+// An UnstructuredList of managed resources of an arbitrary kind.
+type UnstructuredList struct {
+	unstructured.UnstructuredList
+}
+
+// GetItems of this list.
+func (l *UnstructuredList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &Unstructured{Unstructured: l.Items[i]}
+	}
+	return items
+}
+
+// DeepCopyObject of this list.
+func (l *UnstructuredList) DeepCopyObject() runtime.Object {
+	return &UnstructuredList{UnstructuredList: *l.UnstructuredList.DeepCopy()}
+}
@@ -0,0 +1,124 @@
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+var (
+	_ resource.Managed     = &Unstructured{}
+	_ resource.ManagedList = &UnstructuredList{}
+	_ runtime.Unstructured = &Unstructured{}
+)
+
+func TestResolveUnstructured(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "other.example.org", Version: "v1", Kind: "Network"}
+
+	network := func(name, id string, labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetNamespace("coolns")
+		u.SetName(name)
+		u.SetLabels(labels)
+		_ = unstructured.SetNestedField(u.Object, id, "status", "id")
+		return u
+	}
+	c := clientfake.NewClientBuilder().
+		WithObjects(network("a", "id-a", map[string]string{"cool": "true"}), network("b", "id-b", nil)).
+		Build()
+
+	id := FromUnstructured(func(u *unstructured.Unstructured) string {
+		v, _, _ := unstructured.NestedString(u.Object, "status", "id")
+		return v
+	})
+	from := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns"}}
+
+	cases := map[string]struct {
+		reason string
+		req    ResolutionRequest
+		want   ResolutionResponse
+	}{
+		"Reference": {
+			reason: "A reference to a kind that is not registered with the scheme should be resolved.",
+			req: ResolutionRequest{
+				Reference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "b"}},
+				To:        ToGroupVersionKind(gvk),
+				Extract:   id,
+			},
+			want: ResolutionResponse{ResolvedValue: "id-b", ResolvedReference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "b"}}},
+		},
+		"Selector": {
+			reason: "A selector of a kind that is not registered with the scheme should be resolved.",
+			req: ResolutionRequest{
+				Selector: &prv1.Selector{MatchLabels: map[string]string{"cool": "true"}},
+				To:       ToGroupVersionKind(gvk),
+				Extract:  id,
+			},
+			want: ResolutionResponse{ResolvedValue: "id-a", ResolvedReference: &prv1.ResolvableReference{Reference: prv1.Reference{Name: "a", Namespace: "coolns"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewAPIResolver(c, from).Resolve(context.Background(), tc.req)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnstructuredConditions(t *testing.T) {
+	u := &Unstructured{}
+	u.SetConditions(prv1.Available())
+
+	if diff := cmp.Diff(prv1.Available(), u.GetCondition(prv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("GetCondition(...): -want, +got:\n%s", diff)
+	}
+	if _, ok, _ := unstructured.NestedSlice(u.Object, "status", "conditions"); !ok {
+		t.Errorf("SetConditions(...): want status.conditions to be set")
+	}
+}
+
+// typedManaged is a managed resource that is serialised like a real one.
+type typedManaged struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status prv1.ConditionedStatus `json:"status,omitempty"`
+}
+
+func (m *typedManaged) GetCondition(ct prv1.ConditionType) prv1.Condition {
+	return m.Status.GetCondition(ct)
+}
+
+func (m *typedManaged) SetConditions(c ...prv1.Condition) { m.Status.SetConditions(c...) }
+
+func (m *typedManaged) DeepCopyObject() runtime.Object {
+	out := *m
+	return &out
+}
+
+func TestFromUnstructured(t *testing.T) {
+	name := FromUnstructured(func(u *unstructured.Unstructured) string { return u.GetName() })
+	typed := &typedManaged{ObjectMeta: metav1.ObjectMeta{Name: "typed"}}
+
+	if diff := cmp.Diff("typed", name(typed)); diff != "" {
+		t.Errorf("FromUnstructured(...): -want, +got:\n%s", diff)
+	}
+}
@@ -2,17 +2,122 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/krateoplatformops/provider-runtime/pkg/errors"
 	"github.com/krateoplatformops/provider-runtime/pkg/meta"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Error strings.
 const (
+	errGetObject    = "cannot get object"
+	errCreateObject = "cannot create object"
 	errUpdateObject = "cannot update object"
+	errApplySecret  = "cannot apply connection secret"
+	errPatchObject  = "cannot patch object"
+	errMarshalPatch = "cannot marshal patch"
 )
 
+// An APIUpdatingApplicator applies changes to an object by either creating or
+// updating it in a Kubernetes API server.
+type APIUpdatingApplicator struct {
+	client client.Client
+}
+
+// NewAPIUpdatingApplicator returns an Applicator that applies changes to an
+// object by either creating or updating it in a Kubernetes API server.
+func NewAPIUpdatingApplicator(c client.Client) *APIUpdatingApplicator {
+	return &APIUpdatingApplicator{client: c}
+}
+
+// Apply changes to the supplied object. The object will be created if it does
+// not exist, or updated if it does.
+func (a *APIUpdatingApplicator) Apply(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+	if o.GetName() == "" && o.GetGenerateName() != "" {
+		return errors.Wrap(a.client.Create(ctx, o), errCreateObject)
+	}
+
+	current := o.DeepCopyObject().(client.Object)
+
+	err := a.client.Get(ctx, types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}, current)
+	if kerrors.IsNotFound(err) {
+		return errors.Wrap(a.client.Create(ctx, o), errCreateObject)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetObject)
+	}
+
+	for _, fn := range ao {
+		if err := fn(ctx, current, o); err != nil {
+			return err
+		}
+	}
+
+	// We must set the resource version of the desired object to that of the
+	// current or the update will always fail.
+	o.SetResourceVersion(current.GetResourceVersion())
+	return errors.Wrap(a.client.Update(ctx, o), errUpdateObject)
+}
+
+// An ApplyFn is a function that satisfies the Applicator interface.
+type ApplyFn func(context.Context, client.Object, ...ApplyOption) error
+
+// Apply changes to the supplied object.
+func (fn ApplyFn) Apply(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+	return fn(ctx, o, ao...)
+}
+
+// WithConflictRetries returns an Applicator that retries the supplied
+// Applicator up to n times if it returns a conflict error, backing off briefly
+// between attempts. The supplied Applicator is expected to read the current
+// object before each attempt, as an APIUpdatingApplicator does.
+func WithConflictRetries(a Applicator, n int) Applicator {
+	backoff := retry.DefaultRetry
+	backoff.Steps = n + 1
+	return ApplyFn(func(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+		return retry.RetryOnConflict(backoff, func() error {
+			return a.Apply(ctx, o, ao...)
+		})
+	})
+}
+
+// PublishConnection publishes the supplied connection details to the secret
+// referenced by the supplied owner, using the supplied Applicator. The secret
+// is only updated if its data, labels, or controller reference differ from
+// those it would be published with, and only if it is controlled by the
+// supplied owner. It returns true if the secret was
+// created or updated, and false if it did not need to be, or if the owner does
+// not reference a connection secret.
+func PublishConnection(ctx context.Context, a Applicator, o ConnectionSecretOwner, kind schema.GroupVersionKind, data ConnectionDetails) (bool, error) {
+	if o.GetWriteConnectionSecretToReference() == nil {
+		return false, nil
+	}
+
+	s := ConnectionSecretFor(o, kind, data)
+	err := a.Apply(ctx, s,
+		MustBeControllableBy(o.GetUID()),
+		AllowUpdateIf(func(current, desired runtime.Object) bool {
+			return !connectionSecretEqual(current.(*corev1.Secret), desired.(*corev1.Secret))
+		}),
+	)
+	if IsNotAllowed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errApplySecret)
+	}
+	return true, nil
+}
+
 // An APIFinalizer adds and removes finalizers to and from a resource.
 type APIFinalizer struct {
 	client    client.Client
@@ -54,6 +159,121 @@ func (a *APIFinalizer) RemoveFinalizer(ctx context.Context, obj Object) error {
 	return errors.Wrap(IgnoreNotFound(a.client.Update(ctx, obj)), errUpdateObject)
 }
 
+// An APIPatchingFinalizer adds and removes finalizers to and from a resource
+// using a JSON patch of its metadata.finalizers field. Unlike an APIFinalizer
+// it does not conflict with other controllers that concurrently update parts
+// of the resource other than its finalizers, except when it adds the first
+// finalizer of a resource.
+type APIPatchingFinalizer struct {
+	client         client.Client
+	finalizer      string
+	optimisticLock bool
+}
+
+// An APIPatchingFinalizerOption configures an APIPatchingFinalizer.
+type APIPatchingFinalizerOption func(*APIPatchingFinalizer)
+
+// WithOptimisticLock causes an APIPatchingFinalizer to fail to patch a
+// resource if its resource version has changed since it was read.
+func WithOptimisticLock() APIPatchingFinalizerOption {
+	return func(f *APIPatchingFinalizer) {
+		f.optimisticLock = true
+	}
+}
+
+// NewAPIPatchingFinalizer returns a new APIPatchingFinalizer.
+func NewAPIPatchingFinalizer(c client.Client, finalizer string, o ...APIPatchingFinalizerOption) *APIPatchingFinalizer {
+	f := &APIPatchingFinalizer{client: c, finalizer: finalizer}
+	for _, fn := range o {
+		fn(f)
+	}
+	return f
+}
+
+// A jsonPatchOp is a single JSON patch operation. Its value is always
+// marshalled, so that test operations may test for an empty value; remove
+// operations ignore it.
+type jsonPatchOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// AddFinalizer to the supplied resource.
+func (a *APIPatchingFinalizer) AddFinalizer(ctx context.Context, obj Object) error {
+	if meta.FinalizerExists(obj, a.finalizer) {
+		return nil
+	}
+
+	if len(obj.GetFinalizers()) == 0 {
+		// Adding the first finalizer replaces the finalizers field, which
+		// would drop any finalizers added since the resource was read. The
+		// resource version is always tested to make sure there are none.
+		ops := []jsonPatchOp{
+			testResourceVersion(obj),
+			{Op: "add", Path: "/metadata/finalizers", Value: []string{a.finalizer}},
+		}
+		return errors.Wrap(a.patch(ctx, obj, ops), errPatchObject)
+	}
+
+	ops := append(a.lock(obj), jsonPatchOp{Op: "add", Path: "/metadata/finalizers/-", Value: a.finalizer})
+	return errors.Wrap(a.patch(ctx, obj, ops), errPatchObject)
+}
+
+// RemoveFinalizer from the supplied resource.
+func (a *APIPatchingFinalizer) RemoveFinalizer(ctx context.Context, obj Object) error {
+	ops := a.lock(obj)
+	for i, f := range obj.GetFinalizers() {
+		if f != a.finalizer {
+			continue
+		}
+		// Test that the finalizer is still at the index we are removing, in
+		// case another controller removed a finalizer before it.
+		path := fmt.Sprintf("/metadata/finalizers/%d", i)
+		ops = append(ops,
+			jsonPatchOp{Op: "test", Path: path, Value: f},
+			jsonPatchOp{Op: "remove", Path: path},
+		)
+		return errors.Wrap(IgnoreNotFound(a.patch(ctx, obj, ops)), errPatchObject)
+	}
+	return nil
+}
+
+func (a *APIPatchingFinalizer) lock(obj Object) []jsonPatchOp {
+	if !a.optimisticLock {
+		return nil
+	}
+	return []jsonPatchOp{testResourceVersion(obj)}
+}
+
+func testResourceVersion(obj Object) jsonPatchOp {
+	return jsonPatchOp{Op: "test", Path: "/metadata/resourceVersion", Value: obj.GetResourceVersion()}
+}
+
+// patch the supplied resource. The API server rejects a JSON patch with a
+// failed test operation as invalid; such failures are returned as conflicts,
+// since they mean the resource changed since it was read.
+func (a *APIPatchingFinalizer) patch(ctx context.Context, obj Object, ops []jsonPatchOp) error {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return errors.Wrap(err, errMarshalPatch)
+	}
+	err = a.client.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, data))
+	if kerrors.IsInvalid(err) && hasTest(ops) {
+		return kerrors.NewConflict(schema.GroupResource{}, obj.GetName(), err)
+	}
+	return err
+}
+
+func hasTest(ops []jsonPatchOp) bool {
+	for _, op := range ops {
+		if op.Op == "test" {
+			return true
+		}
+	}
+	return false
+}
+
 // A FinalizerFns satisfy the Finalizer interface.
 type FinalizerFns struct {
 	AddFinalizerFn    func(ctx context.Context, obj Object) error
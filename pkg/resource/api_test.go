@@ -0,0 +1,506 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+var (
+	_ Applicator = &APIUpdatingApplicator{}
+	_ Finalizer  = &APIPatchingFinalizer{}
+)
+
+func TestAPIUpdatingApplicator(t *testing.T) {
+	errBoom := errors.New("boom")
+	desired := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "desired"}}
+	current := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "desired", ResourceVersion: "1"}}
+
+	type args struct {
+		ctx context.Context
+		o   client.Object
+		ao  []ApplyOption
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		args   args
+		want   error
+	}{
+		"GetError": {
+			reason: "An error should be returned if we can't get the object.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			args:   args{o: desired.DeepCopy()},
+			want:   errors.Wrap(errBoom, errGetObject),
+		},
+		"CreateError": {
+			reason: "An error should be returned if we can't create the object.",
+			c: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				MockCreate: test.NewMockCreateFn(errBoom),
+			},
+			args: args{o: desired.DeepCopy()},
+			want: errors.Wrap(errBoom, errCreateObject),
+		},
+		"ApplyOptionError": {
+			reason: "Any errors from an apply option should be returned.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			args: args{
+				o:  desired.DeepCopy(),
+				ao: []ApplyOption{func(_ context.Context, _, _ runtime.Object) error { return errBoom }},
+			},
+			want: errBoom,
+		},
+		"UpdateError": {
+			reason: "An error should be returned if we can't update the object.",
+			c: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			args: args{o: desired.DeepCopy()},
+			want: errors.Wrap(errBoom, errUpdateObject),
+		},
+		"Updated": {
+			reason: "No error should be returned if we successfully update the object, using the current resource version.",
+			c: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					*o.(*corev1.Secret) = *current
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil, func(o client.Object) error {
+					if diff := cmp.Diff(current.GetResourceVersion(), o.GetResourceVersion()); diff != "" {
+						t.Errorf("Update(...): -want resource version, +got:\n%s", diff)
+					}
+					return nil
+				}),
+			},
+			args: args{o: desired.DeepCopy()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := NewAPIUpdatingApplicator(tc.c)
+			err := a.Apply(tc.args.ctx, tc.args.o, tc.args.ao...)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nApply(...): -want error, +got error\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithConflictRetries(t *testing.T) {
+	errBoom := errors.New("boom")
+	errConflict := errors.Wrap(kerrors.NewConflict(schema.GroupResource{}, "cool", errBoom), errUpdateObject)
+
+	// failing returns an Applicator that returns the supplied errors in turn,
+	// then succeeds.
+	failing := func(calls *int, errs ...error) Applicator {
+		return ApplyFn(func(_ context.Context, _ client.Object, _ ...ApplyOption) error {
+			*calls++
+			if *calls <= len(errs) {
+				return errs[*calls-1]
+			}
+			return nil
+		})
+	}
+
+	type want struct {
+		calls int
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		retries int
+		errs    []error
+		want    want
+	}{
+		"Success": {
+			reason:  "Apply should be called once if it succeeds.",
+			retries: 3,
+			want:    want{calls: 1},
+		},
+		"OtherError": {
+			reason:  "Errors other than conflicts should not be retried.",
+			retries: 3,
+			errs:    []error{errBoom},
+			want:    want{calls: 1, err: errBoom},
+		},
+		"ConflictThenSuccess": {
+			reason:  "Conflicts should be retried until Apply succeeds.",
+			retries: 3,
+			errs:    []error{errConflict, errConflict},
+			want:    want{calls: 3},
+		},
+		"RetriesExhausted": {
+			reason:  "The conflict should be returned once retries are exhausted.",
+			retries: 1,
+			errs:    []error{errConflict, errConflict, errConflict},
+			want:    want{calls: 2, err: errConflict},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			a := WithConflictRetries(failing(&calls, tc.errs...), tc.retries)
+			err := a.Apply(context.Background(), &fake.Managed{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nApply(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nApply(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPublishConnection(t *testing.T) {
+	errBoom := errors.New("boom")
+	uid := types.UID("owner")
+	kind := fake.GVK(&fake.Managed{})
+
+	owner := func(ref *prv1.Reference) ConnectionSecretOwner {
+		mg := &fake.Managed{}
+		mg.SetUID(uid)
+		mg.SetNamespace("coolns")
+		mg.SetWriteConnectionSecretToReference(ref)
+		return mg
+	}
+
+	type args struct {
+		a    Applicator
+		o    ConnectionSecretOwner
+		data ConnectionDetails
+	}
+	type want struct {
+		published bool
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoReference": {
+			reason: "Nothing should be published if the owner does not reference a connection secret.",
+			args: args{
+				o: owner(nil),
+			},
+			want: want{published: false},
+		},
+		"ApplyError": {
+			reason: "Errors applying the connection secret should be returned.",
+			args: args{
+				a: ApplyFn(func(_ context.Context, _ client.Object, _ ...ApplyOption) error { return errBoom }),
+				o: owner(&prv1.Reference{Name: "conn"}),
+			},
+			want: want{err: errors.Wrap(errBoom, errApplySecret)},
+		},
+		"Unchanged": {
+			reason: "The connection secret should not be updated if its data is unchanged.",
+			args: args{
+				a: ApplyFn(func(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+					current := o.DeepCopyObject()
+					for _, fn := range ao {
+						if err := fn(ctx, current, o); err != nil {
+							return err
+						}
+					}
+					return nil
+				}),
+				o:    owner(&prv1.Reference{Name: "conn"}),
+				data: ConnectionDetails{"key": []byte("value")},
+			},
+			want: want{published: false},
+		},
+		"ControllerReferenceRemoved": {
+			reason: "The connection secret should be updated if its controller reference was removed.",
+			args: args{
+				a: ApplyFn(func(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+					current := o.DeepCopyObject().(*corev1.Secret)
+					current.SetOwnerReferences(nil)
+					for _, fn := range ao {
+						if err := fn(ctx, current, o); err != nil {
+							return err
+						}
+					}
+					return nil
+				}),
+				o:    owner(&prv1.Reference{Name: "conn"}),
+				data: ConnectionDetails{"key": []byte("value")},
+			},
+			want: want{published: true},
+		},
+		"LabelChanged": {
+			reason: "The connection secret should be updated if one of its labels was changed.",
+			args: args{
+				a: ApplyFn(func(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+					current := o.DeepCopyObject().(*corev1.Secret)
+					current.GetLabels()[LabelKeyOwnerKind] = "Other"
+					for _, fn := range ao {
+						if err := fn(ctx, current, o); err != nil {
+							return err
+						}
+					}
+					return nil
+				}),
+				o:    owner(&prv1.Reference{Name: "conn"}),
+				data: ConnectionDetails{"key": []byte("value")},
+			},
+			want: want{published: true},
+		},
+		"ExtraLabel": {
+			reason: "The connection secret should not be updated if it has labels other than those it is published with.",
+			args: args{
+				a: ApplyFn(func(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+					current := o.DeepCopyObject().(*corev1.Secret)
+					current.GetLabels()["cool"] = "true"
+					for _, fn := range ao {
+						if err := fn(ctx, current, o); err != nil {
+							return err
+						}
+					}
+					return nil
+				}),
+				o:    owner(&prv1.Reference{Name: "conn"}),
+				data: ConnectionDetails{"key": []byte("value")},
+			},
+			want: want{published: false},
+		},
+		"Published": {
+			reason: "The connection secret should be published to the owner's namespace if its data changed.",
+			args: args{
+				a: ApplyFn(func(ctx context.Context, o client.Object, ao ...ApplyOption) error {
+					if o.GetNamespace() != "coolns" {
+						t.Errorf("Apply(...): want namespace %q, got %q", "coolns", o.GetNamespace())
+					}
+					current := &corev1.Secret{Type: SecretTypeConnection}
+					for _, fn := range ao {
+						if err := fn(ctx, current, o); err != nil {
+							return err
+						}
+					}
+					return nil
+				}),
+				o:    owner(&prv1.Reference{Name: "conn"}),
+				data: ConnectionDetails{"key": []byte("value")},
+			},
+			want: want{published: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			published, err := PublishConnection(context.Background(), tc.args.a, tc.args.o, kind, tc.args.data)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want error, +got error\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.published, published); diff != "" {
+				t.Errorf("\n%s\nPublishConnection(...): -want, +got\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAPIPatchingFinalizer(t *testing.T) {
+	errBoom := errors.New("boom")
+	finalizer := "veryfinal"
+
+	// patched returns a client that records the JSON patch it was sent.
+	patched := func(got *string, err error) client.Client {
+		return &test.MockClient{MockPatch: func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+			if diff := cmp.Diff(types.JSONPatchType, p.Type()); diff != "" {
+				t.Errorf("Patch(...): -want type, +got:\n%s", diff)
+			}
+			data, _ := p.Data(obj)
+			*got = string(data)
+			return err
+		}}
+	}
+
+	withFinalizers := func(f ...string) Object {
+		mg := &fake.Managed{}
+		mg.SetResourceVersion("42")
+		mg.SetFinalizers(f)
+		return mg
+	}
+
+	type args struct {
+		opts []APIPatchingFinalizerOption
+		obj  Object
+		err  error
+	}
+	type want struct {
+		patch string
+		err   error
+	}
+
+	add := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Exists": {
+			reason: "No patch should be sent if the finalizer already exists.",
+			args:   args{obj: withFinalizers(finalizer)},
+		},
+		"NoFinalizers": {
+			reason: "The finalizers field should be added if the resource has no finalizers, after testing its resource version.",
+			args:   args{obj: withFinalizers()},
+			want:   want{patch: `[{"op":"test","path":"/metadata/resourceVersion","value":"42"},{"op":"add","path":"/metadata/finalizers","value":["veryfinal"]}]`},
+		},
+		"NoResourceVersion": {
+			reason: "An empty resource version should be tested rather than omitted.",
+			args:   args{obj: &fake.Managed{}},
+			want:   want{patch: `[{"op":"test","path":"/metadata/resourceVersion","value":""},{"op":"add","path":"/metadata/finalizers","value":["veryfinal"]}]`},
+		},
+		"TestFailed": {
+			reason: "A patch rejected because its test failed should be returned as a conflict.",
+			args:   args{obj: withFinalizers(), err: kerrors.NewInvalid(schema.GroupKind{}, "", nil)},
+			want: want{
+				patch: `[{"op":"test","path":"/metadata/resourceVersion","value":"42"},{"op":"add","path":"/metadata/finalizers","value":["veryfinal"]}]`,
+				err:   errors.Wrap(kerrors.NewConflict(schema.GroupResource{}, "", kerrors.NewInvalid(schema.GroupKind{}, "", nil)), errPatchObject),
+			},
+		},
+		"OtherFinalizers": {
+			reason: "The finalizer should be appended to any existing finalizers.",
+			args:   args{obj: withFinalizers("other")},
+			want:   want{patch: `[{"op":"add","path":"/metadata/finalizers/-","value":"veryfinal"}]`},
+		},
+		"OptimisticLock": {
+			reason: "The resource version should be tested when optimistic locking is enabled.",
+			args:   args{opts: []APIPatchingFinalizerOption{WithOptimisticLock()}, obj: withFinalizers("other")},
+			want:   want{patch: `[{"op":"test","path":"/metadata/resourceVersion","value":"42"},{"op":"add","path":"/metadata/finalizers/-","value":"veryfinal"}]`},
+		},
+		"PatchError": {
+			reason: "Errors patching the resource should be returned.",
+			args:   args{obj: withFinalizers("other"), err: errBoom},
+			want: want{
+				patch: `[{"op":"add","path":"/metadata/finalizers/-","value":"veryfinal"}]`,
+				err:   errors.Wrap(errBoom, errPatchObject),
+			},
+		},
+	}
+
+	for name, tc := range add {
+		t.Run("Add"+name, func(t *testing.T) {
+			var got string
+			f := NewAPIPatchingFinalizer(patched(&got, tc.args.err), finalizer, tc.args.opts...)
+			err := f.AddFinalizer(context.Background(), tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nAddFinalizer(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patch, got); diff != "" {
+				t.Errorf("\n%s\nAddFinalizer(...): -want patch, +got patch:\n%s", tc.reason, diff)
+			}
+		})
+	}
+
+	remove := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotExists": {
+			reason: "No patch should be sent if the finalizer does not exist.",
+			args:   args{obj: withFinalizers("other")},
+		},
+		"Exists": {
+			reason: "The finalizer should be removed from its index, after testing it is still there.",
+			args:   args{obj: withFinalizers("other", finalizer)},
+			want:   want{patch: `[{"op":"test","path":"/metadata/finalizers/1","value":"veryfinal"},{"op":"remove","path":"/metadata/finalizers/1","value":null}]`},
+		},
+		"NotFound": {
+			reason: "Not found errors should be ignored when removing the finalizer.",
+			args:   args{obj: withFinalizers(finalizer), err: kerrors.NewNotFound(schema.GroupResource{}, "")},
+			want:   want{patch: `[{"op":"test","path":"/metadata/finalizers/0","value":"veryfinal"},{"op":"remove","path":"/metadata/finalizers/0","value":null}]`},
+		},
+	}
+
+	for name, tc := range remove {
+		t.Run("Remove"+name, func(t *testing.T) {
+			var got string
+			f := NewAPIPatchingFinalizer(patched(&got, tc.args.err), finalizer, tc.args.opts...)
+			err := f.RemoveFinalizer(context.Background(), tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRemoveFinalizer(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patch, got); diff != "" {
+				t.Errorf("\n%s\nRemoveFinalizer(...): -want patch, +got patch:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAPIPatchingFinalizerConcurrentAdd(t *testing.T) {
+	ctx := context.Background()
+	c := clientfake.NewClientBuilder().
+		WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "coolns", Name: "cool"}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, p client.Patch, o ...client.PatchOption) error {
+				// The API server rejects JSON patches it can't apply, for
+				// example because a test failed, as unprocessable.
+				if err := c.Patch(ctx, obj, p, o...); err != nil {
+					return kerrors.NewGenericServerResponse(http.StatusUnprocessableEntity, "", schema.GroupResource{}, "", err.Error(), 0, false)
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	stale := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "coolns", Name: "cool"}, stale); err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+
+	// Another controller adds its finalizer after we read the resource.
+	other := stale.DeepCopy()
+	other.SetFinalizers([]string{"other"})
+	if err := c.Update(ctx, other); err != nil {
+		t.Fatalf("Update(...): %v", err)
+	}
+
+	err := NewAPIPatchingFinalizer(c, "veryfinal").AddFinalizer(ctx, stale)
+	if !kerrors.IsConflict(errors.Cause(err)) {
+		t.Errorf("AddFinalizer(...): want a conflict adding the first finalizer to a stale resource, got %v", err)
+	}
+
+	got := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "coolns", Name: "cool"}, got); err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"other"}, got.GetFinalizers()); diff != "" {
+		t.Errorf("AddFinalizer(...): -want finalizers, +got:\n%s", diff)
+	}
+
+	// Once the resource is read again the finalizer is appended.
+	if err := NewAPIPatchingFinalizer(c, "veryfinal").AddFinalizer(ctx, got); err != nil {
+		t.Fatalf("AddFinalizer(...): %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "coolns", Name: "cool"}, got); err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if diff := cmp.Diff([]string{"other", "veryfinal"}, got.GetFinalizers()); diff != "" {
+		t.Errorf("AddFinalizer(...): -want finalizers, +got:\n%s", diff)
+	}
+}
@@ -1,6 +1,10 @@
 package resource
 
-import rtv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+import (
+	"sort"
+
+	rtv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+)
 
 type Conditions []rtv1.Condition
 
@@ -10,9 +14,50 @@ func (cs Conditions) DeepCopy() Conditions {
 	return out
 }
 
-func (cs *Conditions) UpsertCondition(cond rtv1.Condition) {
+// SetObservedConditions sets the supplied conditions on the supplied managed
+// resource, recording its current generation as the generation at which they
+// were observed.
+func SetObservedConditions(mg Managed, c ...rtv1.Condition) {
+	for i := range c {
+		c[i].ObservedGeneration = mg.GetGeneration()
+	}
+	mg.SetConditions(c...)
+}
+
+// An UpsertOption configures how a condition is upserted.
+type UpsertOption func(*upsertOptions)
+
+type upsertOptions struct {
+	maxHistory int
+}
+
+// WithMaxHistory prunes the conditions to at most n conditions after the
+// condition is upserted, as described by Prune.
+func WithMaxHistory(n int) UpsertOption {
+	return func(o *upsertOptions) {
+		o.maxHistory = n
+	}
+}
+
+// UpsertCondition sets the supplied condition, replacing any existing
+// condition of the same type. The last transition time of an existing
+// condition is preserved unless its status changes.
+func (cs *Conditions) UpsertCondition(cond rtv1.Condition, o ...UpsertOption) {
+	uo := &upsertOptions{}
+	for _, fn := range o {
+		fn(uo)
+	}
+	defer func() {
+		if uo.maxHistory > 0 {
+			cs.Prune(uo.maxHistory)
+		}
+	}()
+
 	for idx, el := range *cs {
 		if el.Type == cond.Type {
+			if el.Status == cond.Status {
+				cond.LastTransitionTime = el.LastTransitionTime
+			}
 			(*cs)[idx] = cond
 			return
 		}
@@ -44,3 +89,39 @@ func (cs *Conditions) RemoveCondition(ct rtv1.ConditionType) {
 		}
 	}
 }
+
+// Prune removes the conditions that least recently transitioned until at most
+// n conditions remain. Ready and Synced conditions are never pruned. The
+// order of the remaining conditions is preserved.
+func (cs *Conditions) Prune(n int) {
+	if len(*cs) <= n {
+		return
+	}
+
+	candidates := make([]int, 0, len(*cs))
+	for idx, el := range *cs {
+		if el.Type == rtv1.TypeReady || el.Type == rtv1.TypeSynced {
+			continue
+		}
+		candidates = append(candidates, idx)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return (*cs)[candidates[i]].LastTransitionTime.Before(&(*cs)[candidates[j]].LastTransitionTime)
+	})
+
+	remove := map[int]bool{}
+	for _, idx := range candidates {
+		if len(*cs)-len(remove) <= n {
+			break
+		}
+		remove[idx] = true
+	}
+
+	out := make([]rtv1.Condition, 0, len(*cs)-len(remove))
+	for idx, el := range *cs {
+		if !remove[idx] {
+			out = append(out, el)
+		}
+	}
+	*cs = out
+}
@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rtv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestSetObservedConditions(t *testing.T) {
+	available, synced := rtv1.Available(), rtv1.ReconcileSuccess()
+
+	mg := &fake.Managed{}
+	mg.SetGeneration(3)
+	SetObservedConditions(mg, available, synced)
+
+	want := &fake.Managed{}
+	want.SetGeneration(3)
+	available.ObservedGeneration, synced.ObservedGeneration = 3, 3
+	want.SetConditions(available, synced)
+
+	if diff := cmp.Diff(want, mg, test.EquateConditions()); diff != "" {
+		t.Errorf("SetObservedConditions(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpsertCondition(t *testing.T) {
+	then := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(then.Add(time.Hour))
+
+	cond := func(ct rtv1.ConditionType, s metav1.ConditionStatus, msg string, ltt metav1.Time) rtv1.Condition {
+		return rtv1.Condition{Type: ct, Status: s, Message: msg, LastTransitionTime: ltt}
+	}
+
+	cases := map[string]struct {
+		reason string
+		cs     Conditions
+		c      rtv1.Condition
+		o      []UpsertOption
+		want   Conditions
+	}{
+		"New": {
+			reason: "A condition of a new type should be appended.",
+			cs:     Conditions{cond("A", metav1.ConditionTrue, "", then)},
+			c:      cond("B", metav1.ConditionTrue, "", now),
+			want:   Conditions{cond("A", metav1.ConditionTrue, "", then), cond("B", metav1.ConditionTrue, "", now)},
+		},
+		"MessageChanged": {
+			reason: "The last transition time should be preserved if only the message changes.",
+			cs:     Conditions{cond("A", metav1.ConditionFalse, "old", then)},
+			c:      cond("A", metav1.ConditionFalse, "new", now),
+			want:   Conditions{cond("A", metav1.ConditionFalse, "new", then)},
+		},
+		"StatusChanged": {
+			reason: "The last transition time should be updated if the status changes.",
+			cs:     Conditions{cond("A", metav1.ConditionFalse, "", then)},
+			c:      cond("A", metav1.ConditionTrue, "", now),
+			want:   Conditions{cond("A", metav1.ConditionTrue, "", now)},
+		},
+		"MaxHistory": {
+			reason: "The least recently transitioned conditions should be pruned.",
+			cs: Conditions{
+				cond(rtv1.TypeReady, metav1.ConditionTrue, "", then),
+				cond("A", metav1.ConditionTrue, "", then),
+				cond("B", metav1.ConditionTrue, "", now),
+			},
+			c: cond("C", metav1.ConditionTrue, "", now),
+			o: []UpsertOption{WithMaxHistory(3)},
+			want: Conditions{
+				cond(rtv1.TypeReady, metav1.ConditionTrue, "", then),
+				cond("B", metav1.ConditionTrue, "", now),
+				cond("C", metav1.ConditionTrue, "", now),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.cs.UpsertCondition(tc.c, tc.o...)
+			if diff := cmp.Diff(tc.want, tc.cs); diff != "" {
+				t.Errorf("\n%s\nUpsertCondition(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	then := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cond := func(ct rtv1.ConditionType, ago time.Duration) rtv1.Condition {
+		return rtv1.Condition{Type: ct, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(then.Add(-ago))}
+	}
+
+	cases := map[string]struct {
+		reason string
+		cs     Conditions
+		n      int
+		want   Conditions
+	}{
+		"UnderLimit": {
+			reason: "Conditions should not be pruned if there are no more than n.",
+			cs:     Conditions{cond("A", 0), cond("B", 0)},
+			n:      2,
+			want:   Conditions{cond("A", 0), cond("B", 0)},
+		},
+		"OverLimit": {
+			reason: "The least recently transitioned conditions should be pruned, preserving order.",
+			cs:     Conditions{cond("A", time.Minute), cond("B", time.Hour), cond("C", 0)},
+			n:      2,
+			want:   Conditions{cond("A", time.Minute), cond("C", 0)},
+		},
+		"NeverPruneReadyOrSynced": {
+			reason: "Ready and Synced conditions should never be pruned.",
+			cs:     Conditions{cond(rtv1.TypeReady, time.Hour), cond(rtv1.TypeSynced, time.Hour), cond("A", 0)},
+			n:      1,
+			want:   Conditions{cond(rtv1.TypeReady, time.Hour), cond(rtv1.TypeSynced, time.Hour)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.cs.Prune(tc.n)
+			if diff := cmp.Diff(tc.want, tc.cs); diff != "" {
+				t.Errorf("\n%s\nPrune(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errNoConfigMapReferenced = "no configmap referenced"
+	errFmtGetConfigMap       = "cannot get %s configmap"
+	errFmtCMKeyNotFound      = "key %s not found in %s/%s configmap"
+	errFmtDecodeConfigMap    = "cannot decode key %s of %s/%s configmap"
+	errFmtValidateConfigMap  = "invalid value of key %s of %s/%s configmap"
+)
+
+// A Validator can validate itself.
+type Validator interface {
+	// Validate returns an error describing why the receiver is invalid, or
+	// nil if it is valid.
+	Validate() error
+}
+
+// GetConfigMapString returns the value of the referenced configmap key. It
+// returns an error that satisfies IsKeyNotFound if the key does not exist,
+// unless the selector is optional, in which case an empty string is returned
+// if the configmap or key does not exist.
+func GetConfigMapString(ctx context.Context, k client.Client, ref *commonv1.ConfigMapKeySelector) (string, error) {
+	v, _, err := getConfigMapString(ctx, k, ref)
+	return v, err
+}
+
+// getConfigMapString returns the value of the referenced configmap key, and
+// whether it was found. Only optional selectors may return not found without
+// error.
+func getConfigMapString(ctx context.Context, k client.Client, ref *commonv1.ConfigMapKeySelector) (string, bool, error) {
+	if ref == nil {
+		return "", false, errors.New(errNoConfigMapReferenced)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := k.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+		if ref.Optional && kerrors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, errors.Wrapf(err, errFmtGetConfigMap, ref.Name)
+	}
+
+	v, ok := cm.Data[ref.Key]
+	if !ok && !ref.Optional {
+		return "", false, errKeyNotFound{errors.Errorf(errFmtCMKeyNotFound, ref.Key, ref.Namespace, ref.Name)}
+	}
+
+	return v, ok, nil
+}
+
+// GetConfigMapInto decodes the JSON or YAML value of the referenced configmap
+// key into the supplied object. Decoding is strict; fields that are unknown to
+// or duplicated within the supplied object result in an error. The supplied
+// object is validated after decoding if it satisfies the Validator interface.
+// It returns an error that satisfies IsKeyNotFound if the key does not exist,
+// unless the selector is optional, in which case the supplied object is left
+// unchanged and is not validated.
+func GetConfigMapInto(ctx context.Context, k client.Client, ref *commonv1.ConfigMapKeySelector, into any) error {
+	v, found, err := getConfigMapString(ctx, k, ref)
+	if err != nil || !found {
+		return err
+	}
+
+	// YAML is a superset of JSON, so this decodes both.
+	if err := yaml.UnmarshalStrict([]byte(v), into); err != nil {
+		return errors.Wrapf(err, errFmtDecodeConfigMap, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	if v, ok := into.(Validator); ok {
+		return errors.Wrapf(v.Validate(), errFmtValidateConfigMap, ref.Key, ref.Namespace, ref.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+type settings struct {
+	Endpoint string `json:"endpoint"`
+	Retries  int    `json:"retries"`
+}
+
+func (s *settings) Validate() error {
+	if s.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	return nil
+}
+
+func TestGetConfigMapInto(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := func(key string) *commonv1.ConfigMapKeySelector {
+		return &commonv1.ConfigMapKeySelector{Reference: commonv1.Reference{Name: "cool", Namespace: "coolns"}, Key: key}
+	}
+	optional := func(sel *commonv1.ConfigMapKeySelector) *commonv1.ConfigMapKeySelector {
+		sel.Optional = true
+		return sel
+	}
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*corev1.ConfigMap).Data = map[string]string{
+				"json":    `{"endpoint":"https://example.org","retries":3}`,
+				"yaml":    "endpoint: https://example.org\nretries: 3\n",
+				"unknown": "endpoint: https://example.org\ncolour: blue\n",
+				"invalid": "retries: 3\n",
+			}
+			return nil
+		}),
+	}
+
+	type want struct {
+		s   settings
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		ref    *commonv1.ConfigMapKeySelector
+		want   want
+	}{
+		"NoReference": {
+			reason: "An error should be returned if no configmap is referenced.",
+			want:   want{err: errors.New(errNoConfigMapReferenced)},
+		},
+		"GetError": {
+			reason: "An error should be returned if the configmap cannot be fetched.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			ref:    ref("json"),
+			want:   want{err: errors.Wrapf(errBoom, errFmtGetConfigMap, "cool")},
+		},
+		"KeyNotFound": {
+			reason: "An error should be returned if the key does not exist.",
+			c:      c,
+			ref:    ref("missing"),
+			want:   want{err: errKeyNotFound{errors.Errorf(errFmtCMKeyNotFound, "missing", "coolns", "cool")}},
+		},
+		"OptionalKeyNotFound": {
+			reason: "The object should be left unchanged if an optional key does not exist.",
+			c:      c,
+			ref:    optional(ref("missing")),
+		},
+		"OptionalNotFound": {
+			reason: "The object should be left unchanged if the configmap of an optional key does not exist.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cool"))},
+			ref:    optional(ref("json")),
+		},
+		"JSON": {
+			reason: "JSON values should be decoded.",
+			c:      c,
+			ref:    ref("json"),
+			want:   want{s: settings{Endpoint: "https://example.org", Retries: 3}},
+		},
+		"YAML": {
+			reason: "YAML values should be decoded.",
+			c:      c,
+			ref:    ref("yaml"),
+			want:   want{s: settings{Endpoint: "https://example.org", Retries: 3}},
+		},
+		"UnknownField": {
+			reason: "An error should be returned if the value contains unknown fields.",
+			c:      c,
+			ref:    ref("unknown"),
+			want: want{
+				s:   settings{Endpoint: "https://example.org"},
+				err: errors.Wrapf(errors.New(`error unmarshaling JSON: while decoding JSON: json: unknown field "colour"`), errFmtDecodeConfigMap, "unknown", "coolns", "cool"),
+			},
+		},
+		"Invalid": {
+			reason: "An error should be returned if the decoded value is invalid.",
+			c:      c,
+			ref:    ref("invalid"),
+			want: want{
+				s:   settings{Retries: 3},
+				err: errors.Wrapf(errors.New("endpoint is required"), errFmtValidateConfigMap, "invalid", "coolns", "cool"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := settings{}
+			err := GetConfigMapInto(context.Background(), tc.c, tc.ref, &got)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetConfigMapInto(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nGetConfigMapInto(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package resource
+
+import (
+	"context"
+	"os"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errNoCredentialsSources = "no credentials sources configured"
+	errExtractCredentials   = "cannot extract credentials from any source"
+	errNoEnvSelected        = "no environment variable selected"
+	errNoFsSelected         = "no filesystem path selected"
+	errNoSASelected         = "no service account selected"
+	errFmtRequestToken      = "cannot request token for %s/%s service account"
+	errFmtEnvNotSet         = "environment variable %s is not set"
+	errFmtReadFs            = "cannot read credentials from %s"
+	errFmtFromSource        = "%s credentials source"
+)
+
+// A CredentialsSource is a source from which credentials may be extracted.
+type CredentialsSource string
+
+// Credentials sources.
+const (
+	// CredentialsSourceSecret indicates that credentials were extracted from
+	// a Kubernetes secret.
+	CredentialsSourceSecret CredentialsSource = "Secret"
+
+	// CredentialsSourceEnvironment indicates that credentials were extracted
+	// from an environment variable.
+	CredentialsSourceEnvironment CredentialsSource = "Environment"
+
+	// CredentialsSourceFilesystem indicates that credentials were extracted
+	// from the filesystem.
+	CredentialsSourceFilesystem CredentialsSource = "Filesystem"
+
+	// CredentialsSourceServiceAccountToken indicates that credentials are a
+	// bound service account token requested using the TokenRequest API.
+	CredentialsSourceServiceAccountToken CredentialsSource = "ServiceAccountToken"
+
+	// CredentialsSourceInjectedIdentity indicates that no credentials were
+	// extracted, and that the provider should use the identity injected into
+	// its runtime environment (e.g. a cloud workload identity).
+	CredentialsSourceInjectedIdentity CredentialsSource = "InjectedIdentity"
+)
+
+// Credentials extracted from a CredentialsSource.
+type Credentials struct {
+	// Data of the credentials. Data is empty for injected identities.
+	Data []byte
+
+	// Source from which the credentials were extracted.
+	Source CredentialsSource
+
+	// Origin identifies where within the source the credentials were found,
+	// for example a secret's namespace, name, and key, or a filesystem path.
+	Origin string
+
+	// ExpiresAt is the time at which the credentials expire. It is zero if
+	// the credentials do not expire, or their expiry is unknown.
+	ExpiresAt time.Time
+}
+
+// A CredentialsExtractor extracts credentials from a single source.
+type CredentialsExtractor interface {
+	ExtractCredentials(ctx context.Context) (Credentials, error)
+}
+
+// A CredentialsExtractorFn is a function that satisfies the
+// CredentialsExtractor interface.
+type CredentialsExtractorFn func(ctx context.Context) (Credentials, error)
+
+// ExtractCredentials calls the CredentialsExtractorFn.
+func (fn CredentialsExtractorFn) ExtractCredentials(ctx context.Context) (Credentials, error) {
+	return fn(ctx)
+}
+
+// ExtractCredentials tries each of the supplied sources in order, returning
+// the credentials extracted from the first source that succeeds. An error
+// describing why each source failed is returned if no source succeeds.
+func ExtractCredentials(ctx context.Context, sources ...CredentialsExtractor) (Credentials, error) {
+	if len(sources) == 0 {
+		return Credentials{}, errors.New(errNoCredentialsSources)
+	}
+
+	errs := make([]error, 0, len(sources))
+	for _, s := range sources {
+		c, err := s.ExtractCredentials(ctx)
+		if err == nil {
+			return c, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return Credentials{}, errors.Wrap(errors.Join(errs...), errExtractCredentials)
+}
+
+// CredentialsFromSelectors returns an extractor for each of the configured
+// selectors, in the order secret, environment, filesystem, and service account
+// token.
+func CredentialsFromSelectors(k client.Client, s commonv1.CredentialSelectors) []CredentialsExtractor {
+	out := make([]CredentialsExtractor, 0, 4)
+	if s.SecretRef != nil {
+		out = append(out, SecretCredentials(k, s.SecretRef))
+	}
+	if s.Env != nil {
+		out = append(out, EnvCredentials(s.Env))
+	}
+	if s.Fs != nil {
+		out = append(out, FsCredentials(s.Fs))
+	}
+	if s.ServiceAccountToken != nil {
+		out = append(out, ServiceAccountTokenCredentials(k, s.ServiceAccountToken))
+	}
+	return out
+}
+
+// SecretCredentials returns a CredentialsExtractor that extracts credentials
+// from the referenced secret key.
+func SecretCredentials(k client.Client, ref *commonv1.SecretKeySelector) CredentialsExtractor {
+	return CredentialsExtractorFn(func(ctx context.Context) (Credentials, error) {
+		data, err := GetSecretBytes(ctx, k, ref)
+		if err != nil {
+			return Credentials{}, errors.Wrapf(err, errFmtFromSource, CredentialsSourceSecret)
+		}
+		return Credentials{
+			Data:   data,
+			Source: CredentialsSourceSecret,
+			Origin: ref.Namespace + "/" + ref.Name + "/" + ref.Key,
+		}, nil
+	})
+}
+
+// EnvCredentials returns a CredentialsExtractor that extracts credentials
+// from the selected environment variable.
+func EnvCredentials(sel *commonv1.EnvSelector) CredentialsExtractor {
+	return CredentialsExtractorFn(func(_ context.Context) (Credentials, error) {
+		if sel == nil {
+			return Credentials{}, errors.Wrapf(errors.New(errNoEnvSelected), errFmtFromSource, CredentialsSourceEnvironment)
+		}
+		v, ok := os.LookupEnv(sel.Name)
+		if !ok {
+			return Credentials{}, errors.Wrapf(errors.Errorf(errFmtEnvNotSet, sel.Name), errFmtFromSource, CredentialsSourceEnvironment)
+		}
+		return Credentials{
+			Data:   []byte(v),
+			Source: CredentialsSourceEnvironment,
+			Origin: sel.Name,
+		}, nil
+	})
+}
+
+// FsCredentials returns a CredentialsExtractor that extracts credentials
+// from the selected filesystem path.
+func FsCredentials(sel *commonv1.FsSelector) CredentialsExtractor {
+	return CredentialsExtractorFn(func(_ context.Context) (Credentials, error) {
+		if sel == nil {
+			return Credentials{}, errors.Wrapf(errors.New(errNoFsSelected), errFmtFromSource, CredentialsSourceFilesystem)
+		}
+		data, err := os.ReadFile(sel.Path)
+		if err != nil {
+			return Credentials{}, errors.Wrapf(errors.Wrapf(err, errFmtReadFs, sel.Path), errFmtFromSource, CredentialsSourceFilesystem)
+		}
+		return Credentials{
+			Data:   data,
+			Source: CredentialsSourceFilesystem,
+			Origin: sel.Path,
+		}, nil
+	})
+}
+
+// ServiceAccountTokenCredentials returns a CredentialsExtractor that requests
+// a bound token for the selected service account using the TokenRequest API,
+// with the selected audiences and expiry. This enables keyless authentication
+// flows such as OIDC federation.
+func ServiceAccountTokenCredentials(k client.Client, sel *commonv1.ServiceAccountTokenSelector) CredentialsExtractor {
+	return CredentialsExtractorFn(func(ctx context.Context) (Credentials, error) {
+		if sel == nil {
+			return Credentials{}, errors.Wrapf(errors.New(errNoSASelected), errFmtFromSource, CredentialsSourceServiceAccountToken)
+		}
+
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: sel.Namespace, Name: sel.Name}}
+		tr := &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences:         sel.Audiences,
+				ExpirationSeconds: sel.ExpirationSeconds,
+			},
+		}
+		if err := k.SubResource("token").Create(ctx, sa, tr); err != nil {
+			return Credentials{}, errors.Wrapf(errors.Wrapf(err, errFmtRequestToken, sel.Namespace, sel.Name), errFmtFromSource, CredentialsSourceServiceAccountToken)
+		}
+
+		return Credentials{
+			Data:      []byte(tr.Status.Token),
+			Source:    CredentialsSourceServiceAccountToken,
+			Origin:    sel.Namespace + "/" + sel.Name,
+			ExpiresAt: tr.Status.ExpirationTimestamp.Time,
+		}, nil
+	})
+}
+
+// InjectedIdentityCredentials returns a CredentialsExtractor that always
+// succeeds without extracting any data, indicating that the provider should
+// fall back to the identity injected into its runtime environment. It is
+// typically the last source in a chain.
+func InjectedIdentityCredentials() CredentialsExtractor {
+	return CredentialsExtractorFn(func(_ context.Context) (Credentials, error) {
+		return Credentials{Source: CredentialsSourceInjectedIdentity}, nil
+	})
+}
@@ -0,0 +1,151 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+const (
+	defaultCredentialsTTL = 5 * time.Minute
+
+	// credentialsExpirySkew is subtracted from the expiry of credentials that
+	// report one, so that they are refreshed shortly before they expire.
+	credentialsExpirySkew = 30 * time.Second
+)
+
+// Error strings.
+const (
+	errFmtGetSecretMetadata = "cannot get %s secret metadata"
+)
+
+var secretGVK = corev1.SchemeGroupVersion.WithKind("Secret")
+
+// A CredentialsCacheOption configures a CredentialsCache.
+type CredentialsCacheOption func(*CredentialsCache)
+
+// WithCredentialsTTL specifies how long extracted credentials are cached.
+// Credentials are cached for five minutes by default.
+func WithCredentialsTTL(d time.Duration) CredentialsCacheOption {
+	return func(c *CredentialsCache) {
+		c.ttl = d
+	}
+}
+
+type credentialsCacheEntry struct {
+	creds           Credentials
+	secret          types.NamespacedName
+	resourceVersion string
+	expires         time.Time
+}
+
+// A CredentialsCache caches credentials extracted from CredentialSelectors,
+// keyed by selector. Cached credentials are extracted again once their TTL
+// elapses, once they are about to expire, or once the resource version of
+// the secret they were extracted from changes.
+type CredentialsCache struct {
+	client client.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]credentialsCacheEntry
+}
+
+// NewCredentialsCache returns a CredentialsCache that extracts credentials
+// using the supplied client. The client is also used to check the resource
+// version of referenced secrets, and should typically be backed by an informer
+// cache (e.g. a controller manager's client) so that doing so does not call
+// the API server.
+func NewCredentialsCache(c client.Client, o ...CredentialsCacheOption) *CredentialsCache {
+	cc := &CredentialsCache{
+		client:  c,
+		ttl:     defaultCredentialsTTL,
+		now:     time.Now,
+		entries: map[string]credentialsCacheEntry{},
+	}
+	for _, fn := range o {
+		fn(cc)
+	}
+	return cc
+}
+
+// Extract credentials from the supplied selectors, returning cached
+// credentials if they are still valid.
+func (c *CredentialsCache) Extract(ctx context.Context, s commonv1.CredentialSelectors) (Credentials, error) {
+	key := credentialsCacheKey(s)
+
+	var nn types.NamespacedName
+	var rv string
+	if s.SecretRef != nil {
+		nn = types.NamespacedName{Namespace: s.SecretRef.Namespace, Name: s.SecretRef.Name}
+		pom := &metav1.PartialObjectMetadata{}
+		pom.SetGroupVersionKind(secretGVK)
+		err := c.client.Get(ctx, nn, pom)
+		if IgnoreNotFound(err) != nil {
+			return Credentials{}, errors.Wrapf(err, errFmtGetSecretMetadata, nn.Name)
+		}
+		rv = pom.GetResourceVersion()
+	}
+
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && c.now().Before(e.expires) && e.secret == nn && e.resourceVersion == rv {
+		return e.creds, nil
+	}
+
+	creds, err := ExtractCredentials(ctx, CredentialsFromSelectors(c.client, s)...)
+	if err != nil {
+		c.Invalidate(s)
+		return Credentials{}, err
+	}
+
+	expires := c.now().Add(c.ttl)
+	if !creds.ExpiresAt.IsZero() && creds.ExpiresAt.Add(-credentialsExpirySkew).Before(expires) {
+		expires = creds.ExpiresAt.Add(-credentialsExpirySkew)
+	}
+
+	c.mu.Lock()
+	c.entries[key] = credentialsCacheEntry{creds: creds, secret: nn, resourceVersion: rv, expires: expires}
+	c.mu.Unlock()
+
+	return creds, nil
+}
+
+// Invalidate any credentials cached for the supplied selectors.
+func (c *CredentialsCache) Invalidate(s commonv1.CredentialSelectors) {
+	key := credentialsCacheKey(s)
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// InvalidateSecret invalidates any credentials that were extracted from the
+// supplied secret. It may be called when a watched secret changes.
+func (c *CredentialsCache) InvalidateSecret(nn types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if e.secret == nn {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func credentialsCacheKey(s commonv1.CredentialSelectors) string {
+	// CredentialSelectors contains only strings, integers, and pointers to
+	// structs thereof, so marshalling it cannot fail.
+	b, _ := json.Marshal(s) //nolint:errchkjson // See above.
+	return string(b)
+}
@@ -0,0 +1,74 @@
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestCredentialsCache(t *testing.T) {
+	rv := "1"
+	gets := 0
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			switch o := o.(type) {
+			case *metav1.PartialObjectMetadata:
+				o.SetResourceVersion(rv)
+			case *corev1.Secret:
+				gets++
+				o.Data = map[string][]byte{"creds": []byte("v" + rv)}
+			}
+			return nil
+		}),
+	}
+
+	now := time.Now()
+	cache := NewCredentialsCache(c, WithCredentialsTTL(time.Minute))
+	cache.now = func() time.Time { return now }
+
+	sel := commonv1.CredentialSelectors{
+		SecretRef: &commonv1.SecretKeySelector{
+			Reference: commonv1.Reference{Name: "cool", Namespace: "coolns"},
+			Key:       "creds",
+		},
+	}
+
+	extract := func(want string, wantGets int) {
+		t.Helper()
+		got, err := cache.Extract(context.Background(), sel)
+		if err != nil {
+			t.Fatalf("Extract(...): %v", err)
+		}
+		if string(got.Data) != want {
+			t.Errorf("Extract(...): want %q, got %q", want, string(got.Data))
+		}
+		if gets != wantGets {
+			t.Errorf("Extract(...): want %d secret gets, got %d", wantGets, gets)
+		}
+	}
+
+	extract("v1", 1)
+
+	// Cached credentials should be returned while valid.
+	extract("v1", 1)
+
+	// Credentials should be extracted again if the secret changes.
+	rv = "2"
+	extract("v2", 2)
+
+	// Credentials should be extracted again once their TTL elapses.
+	now = now.Add(2 * time.Minute)
+	extract("v2", 3)
+
+	// Credentials should be extracted again once invalidated.
+	cache.InvalidateSecret(types.NamespacedName{Namespace: "coolns", Name: "cool"})
+	extract("v2", 4)
+}
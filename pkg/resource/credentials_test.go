@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestExtractCredentials(t *testing.T) {
+	errBoom := errors.New("boom")
+	fail := CredentialsExtractorFn(func(_ context.Context) (Credentials, error) { return Credentials{}, errBoom })
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "creds")
+	if err := os.WriteFile(path, []byte("fs"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KRATEO_TEST_CREDS", "env")
+
+	expiry := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	sa := &commonv1.ServiceAccountTokenSelector{
+		Reference: commonv1.Reference{Name: "cool", Namespace: "coolns"},
+		Audiences: []string{"sts.example.org"},
+	}
+	tokens := &test.MockClient{
+		MockSubResourceCreate: func(_ context.Context, _, sub client.Object, _ ...client.SubResourceCreateOption) error {
+			tr := sub.(*authenticationv1.TokenRequest)
+			if diff := cmp.Diff(sa.Audiences, tr.Spec.Audiences); diff != "" {
+				t.Errorf("Create(...): -want audiences, +got:\n%s", diff)
+			}
+			tr.Status.Token = "token"
+			tr.Status.ExpirationTimestamp = expiry
+			return nil
+		},
+	}
+
+	type want struct {
+		c   Credentials
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		sources []CredentialsExtractor
+		want    want
+	}{
+		"NoSources": {
+			reason: "An error should be returned if no sources are supplied.",
+			want:   want{err: errors.New(errNoCredentialsSources)},
+		},
+		"AllFail": {
+			reason:  "An error joining the failure of each source should be returned if no source succeeds.",
+			sources: []CredentialsExtractor{fail, fail},
+			want:    want{err: errors.Wrap(errors.Join(errBoom, errBoom), errExtractCredentials)},
+		},
+		"Env": {
+			reason:  "Credentials should be extracted from the first source that succeeds.",
+			sources: []CredentialsExtractor{fail, EnvCredentials(&commonv1.EnvSelector{Name: "KRATEO_TEST_CREDS"}), InjectedIdentityCredentials()},
+			want: want{c: Credentials{
+				Data:   []byte("env"),
+				Source: CredentialsSourceEnvironment,
+				Origin: "KRATEO_TEST_CREDS",
+			}},
+		},
+		"Fs": {
+			reason:  "Credentials should be extracted from the filesystem.",
+			sources: []CredentialsExtractor{FsCredentials(&commonv1.FsSelector{Path: path})},
+			want: want{c: Credentials{
+				Data:   []byte("fs"),
+				Source: CredentialsSourceFilesystem,
+				Origin: path,
+			}},
+		},
+		"ServiceAccountToken": {
+			reason:  "A bound token should be requested for the selected service account.",
+			sources: []CredentialsExtractor{ServiceAccountTokenCredentials(tokens, sa)},
+			want: want{c: Credentials{
+				Data:      []byte("token"),
+				Source:    CredentialsSourceServiceAccountToken,
+				Origin:    "coolns/cool",
+				ExpiresAt: expiry.Time,
+			}},
+		},
+		"InjectedIdentity": {
+			reason:  "The injected identity should be used if no other source succeeds.",
+			sources: []CredentialsExtractor{EnvCredentials(&commonv1.EnvSelector{Name: "KRATEO_TEST_UNSET"}), InjectedIdentityCredentials()},
+			want:    want{c: Credentials{Source: CredentialsSourceInjectedIdentity}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExtractCredentials(context.Background(), tc.sources...)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExtractCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\nExtractCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package resource
+
+import (
+	"context"
+	"regexp"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errFmtExpand = "cannot expand placeholder %s"
+)
+
+// Placeholder sources.
+const (
+	placeholderSecret    = "secret"
+	placeholderConfigMap = "configmap"
+)
+
+// placeholder matches ${secret:namespace/name/key} and
+// ${configmap:namespace/name/key}.
+var placeholder = regexp.MustCompile(`\$\{(secret|configmap):([^/}]+)/([^/}]+)/([^}]+)\}`)
+
+// ExpandPlaceholders replaces each ${secret:namespace/name/key} and
+// ${configmap:namespace/name/key} placeholder in the supplied string with the
+// value of the referenced secret or configmap key. Each distinct placeholder is
+// resolved once. Any other text, including other ${...} expressions, is left
+// unchanged. An error is returned if any placeholder cannot be resolved.
+func ExpandPlaceholders(ctx context.Context, k client.Client, s string) (string, error) {
+	resolved := map[string]string{}
+	for _, m := range placeholder.FindAllStringSubmatch(s, -1) {
+		if _, ok := resolved[m[0]]; ok {
+			continue
+		}
+
+		ref := commonv1.Reference{Namespace: m[2], Name: m[3]}
+		var v string
+		var err error
+		switch m[1] {
+		case placeholderSecret:
+			v, err = GetSecretString(ctx, k, &commonv1.SecretKeySelector{Reference: ref, Key: m[4]})
+		case placeholderConfigMap:
+			v, err = GetConfigMapString(ctx, k, &commonv1.ConfigMapKeySelector{Reference: ref, Key: m[4]})
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, errFmtExpand, m[0])
+		}
+		resolved[m[0]] = v
+	}
+
+	return placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		return resolved[m]
+	}), nil
+}
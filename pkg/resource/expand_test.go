@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestExpandPlaceholders(t *testing.T) {
+	errBoom := errors.New("boom")
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			switch o := o.(type) {
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"token": []byte("s3cr3t")}
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"host": "api.example.org"}
+			}
+			return nil
+		}),
+	}
+
+	type want struct {
+		s   string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		s      string
+		want   want
+	}{
+		"NoPlaceholders": {
+			reason: "Strings without placeholders should be returned unchanged.",
+			s:      "https://example.org/${notaplaceholder}",
+			want:   want{s: "https://example.org/${notaplaceholder}"},
+		},
+		"Expanded": {
+			reason: "Secret and configmap placeholders should be replaced with the referenced values.",
+			c:      c,
+			s:      "https://${configmap:coolns/cfg/host}/?token=${secret:coolns/creds/token}&again=${secret:coolns/creds/token}",
+			want:   want{s: "https://api.example.org/?token=s3cr3t&again=s3cr3t"},
+		},
+		"KeyNotFound": {
+			reason: "An error should be returned if a referenced key does not exist.",
+			c:      c,
+			s:      "${secret:coolns/creds/missing}",
+			want: want{err: errors.Wrapf(
+				errKeyNotFound{errors.Errorf(errFmtKeyNotFound, "missing", "coolns", "creds")},
+				errFmtExpand, "${secret:coolns/creds/missing}")},
+		},
+		"GetError": {
+			reason: "An error should be returned if a referenced configmap cannot be fetched.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			s:      "${configmap:coolns/cfg/host}",
+			want: want{err: errors.Wrapf(
+				errors.Wrapf(errBoom, errFmtGetConfigMap, "cfg"),
+				errFmtExpand, "${configmap:coolns/cfg/host}")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExpandPlaceholders(context.Background(), tc.c, tc.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExpandPlaceholders(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nExpandPlaceholders(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
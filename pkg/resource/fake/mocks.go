@@ -44,6 +44,84 @@ func (m *Conditioned) GetCondition(ct prv1.ConditionType) prv1.Condition {
 	return prv1.Condition{Type: ct, Status: metav1.ConditionUnknown}
 }
 
+// ConnectionSecretWriterTo is a mock that implements ConnectionSecretWriterTo
+// interface.
+type ConnectionSecretWriterTo struct{ Ref *prv1.Reference }
+
+// SetWriteConnectionSecretToReference sets the WriteConnectionSecretToReference.
+func (m *ConnectionSecretWriterTo) SetWriteConnectionSecretToReference(r *prv1.Reference) {
+	m.Ref = r
+}
+
+// GetWriteConnectionSecretToReference gets the WriteConnectionSecretToReference.
+func (m *ConnectionSecretWriterTo) GetWriteConnectionSecretToReference() *prv1.Reference {
+	return m.Ref
+}
+
+// ProviderConfigReferencer is a mock that implements ProviderConfigReferencer
+// interface.
+type ProviderConfigReferencer struct{ Ref *prv1.Reference }
+
+// SetProviderConfigReference sets the ProviderConfigReference.
+func (m *ProviderConfigReferencer) SetProviderConfigReference(p *prv1.Reference) { m.Ref = p }
+
+// GetProviderConfigReference gets the ProviderConfigReference.
+func (m *ProviderConfigReferencer) GetProviderConfigReference() *prv1.Reference { return m.Ref }
+
+// RequiredProviderConfigReferencer is a mock that implements the
+// RequiredProviderConfigReferencer interface.
+type RequiredProviderConfigReferencer struct{ Ref prv1.Reference }
+
+// SetProviderConfigReference sets the ProviderConfigReference.
+func (m *RequiredProviderConfigReferencer) SetProviderConfigReference(p prv1.Reference) {
+	m.Ref = p
+}
+
+// GetProviderConfigReference gets the ProviderConfigReference.
+func (m *RequiredProviderConfigReferencer) GetProviderConfigReference() prv1.Reference {
+	return m.Ref
+}
+
+// RequiredTypedResourceReferencer is a mock that implements the
+// RequiredTypedResourceReferencer interface.
+type RequiredTypedResourceReferencer struct{ Ref prv1.TypedReference }
+
+// SetResourceReference sets the ResourceReference.
+func (m *RequiredTypedResourceReferencer) SetResourceReference(p prv1.TypedReference) {
+	m.Ref = p
+}
+
+// GetResourceReference gets the ResourceReference.
+func (m *RequiredTypedResourceReferencer) GetResourceReference() prv1.TypedReference {
+	return m.Ref
+}
+
+// SyncTimer is a mock that satisfies SyncTimer interface.
+type SyncTimer struct{ prv1.SyncStatus }
+
+// UserCounter is a mock that satisfies UserCounter interface.
+type UserCounter struct{ Users int64 }
+
+// SetUsers sets the count of users.
+func (m *UserCounter) SetUsers(i int64) {
+	m.Users = i
+}
+
+// GetUsers gets the count of users.
+func (m *UserCounter) GetUsers() int64 {
+	return m.Users
+}
+
+// ReconcilePolicyHolder is a mock that satisfies ReconcilePolicyHolder
+// interface.
+type ReconcilePolicyHolder struct{ Policy *prv1.ReconcilePolicy }
+
+// SetReconcilePolicy sets the ReconcilePolicy.
+func (m *ReconcilePolicyHolder) SetReconcilePolicy(p *prv1.ReconcilePolicy) { m.Policy = p }
+
+// GetReconcilePolicy gets the ReconcilePolicy.
+func (m *ReconcilePolicyHolder) GetReconcilePolicy() *prv1.ReconcilePolicy { return m.Policy }
+
 // ManagedResourceReferencer is a mock that implements ManagedResourceReferencer interface.
 type ManagedResourceReferencer struct{ Ref *corev1.ObjectReference }
 
@@ -92,6 +170,8 @@ func (o *Object) DeepCopyObject() runtime.Object {
 // Managed is a mock that implements Managed interface.
 type Managed struct {
 	metav1.ObjectMeta
+	ProviderConfigReferencer
+	ConnectionSecretWriterTo
 	prv1.ConditionedStatus
 }
 
@@ -111,6 +191,55 @@ func (m *Managed) DeepCopyObject() runtime.Object {
 	return out
 }
 
+// ProviderConfig is a mock implementation of the ProviderConfig interface.
+type ProviderConfig struct {
+	metav1.ObjectMeta
+
+	UserCounter
+	prv1.ConditionedStatus
+}
+
+// GetObjectKind returns schema.ObjectKind.
+func (p *ProviderConfig) GetObjectKind() schema.ObjectKind {
+	return schema.EmptyObjectKind
+}
+
+// DeepCopyObject returns a copy of the object as runtime.Object
+func (p *ProviderConfig) DeepCopyObject() runtime.Object {
+	out := &ProviderConfig{}
+	j, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	_ = json.Unmarshal(j, out)
+	return out
+}
+
+// ProviderConfigUsage is a mock implementation of the ProviderConfigUsage
+// interface.
+type ProviderConfigUsage struct {
+	metav1.ObjectMeta
+
+	RequiredProviderConfigReferencer
+	RequiredTypedResourceReferencer
+}
+
+// GetObjectKind returns schema.ObjectKind.
+func (p *ProviderConfigUsage) GetObjectKind() schema.ObjectKind {
+	return schema.EmptyObjectKind
+}
+
+// DeepCopyObject returns a copy of the object as runtime.Object
+func (p *ProviderConfigUsage) DeepCopyObject() runtime.Object {
+	out := &ProviderConfigUsage{}
+	j, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	_ = json.Unmarshal(j, out)
+	return out
+}
+
 // Manager is a mock object that satisfies manager.Manager interface.
 type Manager struct {
 	manager.Manager
@@ -37,6 +37,128 @@ type Conditioned interface {
 	GetCondition(prv1.ConditionType) prv1.Condition
 }
 
+// A ConnectionSecretWriterTo may write a connection secret.
+type ConnectionSecretWriterTo interface {
+	SetWriteConnectionSecretToReference(r *prv1.Reference)
+	GetWriteConnectionSecretToReference() *prv1.Reference
+}
+
+// A ConnectionSecretOwner is a Kubernetes object that owns a connection secret.
+type ConnectionSecretOwner interface {
+	Object
+	ConnectionSecretWriterTo
+}
+
+// A ProviderConfigReferencer may reference a provider config resource.
+type ProviderConfigReferencer interface {
+	GetProviderConfigReference() *prv1.Reference
+	SetProviderConfigReference(p *prv1.Reference)
+}
+
+// A RequiredProviderConfigReferencer must reference a provider config resource.
+type RequiredProviderConfigReferencer interface {
+	GetProviderConfigReference() prv1.Reference
+	SetProviderConfigReference(p prv1.Reference)
+}
+
+// A RequiredTypedResourceReferencer must reference a resource.
+type RequiredTypedResourceReferencer interface {
+	SetResourceReference(r prv1.TypedReference)
+	GetResourceReference() prv1.TypedReference
+}
+
+// A ConditionsHasher can compute a stable hash of its conditions.
+type ConditionsHasher interface {
+	ConditionsHash() string
+}
+
+// A SyncTimer records when it was last synced with an external resource.
+type SyncTimer interface {
+	SetLastSyncAttemptTime(t metav1.Time)
+	GetLastSyncAttemptTime() *metav1.Time
+	SetLastSuccessfulSyncTime(t metav1.Time)
+	GetLastSuccessfulSyncTime() *metav1.Time
+}
+
+// An Orphanable resource may specify a DeletionPolicy.
+type Orphanable interface {
+	SetDeletionPolicy(p prv1.DeletionPolicy)
+	GetDeletionPolicy() prv1.DeletionPolicy
+}
+
+// A Manageable resource may specify its ManagementPolicies.
+type Manageable interface {
+	SetManagementPolicies(p prv1.ManagementPolicies)
+	GetManagementPolicies() prv1.ManagementPolicies
+}
+
+// A GenerationObserver records the latest generation it observed.
+type GenerationObserver interface {
+	SetObservedGeneration(g int64)
+	GetObservedGeneration() int64
+}
+
+// A FailureCounter can count how many consecutive times it failed to
+// reconcile.
+type FailureCounter interface {
+	SetFailures(i int64)
+	GetFailures() int64
+}
+
+// An ExternalIdentifiable records the identity of its external resource.
+type ExternalIdentifiable interface {
+	SetExternalID(id *prv1.ExternalID)
+	GetExternalID() *prv1.ExternalID
+}
+
+// A ReconcilePolicyHolder may specify a ReconcilePolicy.
+type ReconcilePolicyHolder interface {
+	SetReconcilePolicy(p *prv1.ReconcilePolicy)
+	GetReconcilePolicy() *prv1.ReconcilePolicy
+}
+
+// A UserCounter can count how many users it has.
+type UserCounter interface {
+	SetUsers(i int64)
+	GetUsers() int64
+}
+
+// A ProviderConfig configures a provider, in that it provides credentials
+// and other configuration required to connect to an external system.
+type ProviderConfig interface {
+	Object
+
+	UserCounter
+	Conditioned
+}
+
+// A CredentialsSelector selects the credentials that must be used to connect
+// to a provider.
+type CredentialsSelector interface {
+	GetCredentialSelectors() prv1.CredentialSelectors
+}
+
+// A ProviderConfigUsage indicates a usage of a provider config.
+type ProviderConfigUsage interface {
+	Object
+
+	RequiredProviderConfigReferencer
+	RequiredTypedResourceReferencer
+}
+
+// A ProviderConfigUsageList is a list of provider config usages.
+type ProviderConfigUsageList interface {
+	client.ObjectList
+
+	// GetItems returns the list of provider config usages.
+	GetItems() []ProviderConfigUsage
+}
+
+// An Applicator applies changes to an object.
+type Applicator interface {
+	Apply(context.Context, client.Object, ...ApplyOption) error
+}
+
 // A Finalizer manages the finalizers on the resource.
 type Finalizer interface {
 	AddFinalizer(ctx context.Context, obj Object) error
@@ -0,0 +1,59 @@
+package resource
+
+import (
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+// Standard printer column names. Managed resource kinds should present these
+// columns so that all providers look alike in kubectl get. Kinds generated
+// with controller-gen may do so using the following markers:
+//
+//	// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//	// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+//	// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.krateo\.io/external-name"
+//	// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+const (
+	PrintColumnReady        = "READY"
+	PrintColumnSynced       = "SYNCED"
+	PrintColumnExternalName = "EXTERNAL-NAME"
+	PrintColumnAge          = "AGE"
+)
+
+// PrintColumns returns the standard additional printer columns of managed
+// resource kinds, for providers that build their CRDs programmatically.
+func PrintColumns() []apiextensionsv1.CustomResourceColumnDefinition {
+	return []apiextensionsv1.CustomResourceColumnDefinition{
+		{Name: PrintColumnReady, Type: "string", JSONPath: conditionStatusPath(prv1.TypeReady)},
+		{Name: PrintColumnSynced, Type: "string", JSONPath: conditionStatusPath(prv1.TypeSynced)},
+		{Name: PrintColumnExternalName, Type: "string", JSONPath: ".metadata.annotations." + strings.ReplaceAll(meta.AnnotationKeyExternalName, ".", `\.`)},
+		{Name: PrintColumnAge, Type: "date", JSONPath: ".metadata.creationTimestamp"},
+	}
+}
+
+// PrintColumnValues returns the values of the standard printer columns of the
+// supplied managed resource at the supplied time, in the order they are
+// returned by PrintColumns. Conditions that are not set are reported as
+// Unknown.
+func PrintColumnValues(mg Managed, now time.Time) []string {
+	age := "<unknown>"
+	if ct := mg.GetCreationTimestamp(); !ct.IsZero() {
+		age = duration.HumanDuration(now.Sub(ct.Time))
+	}
+	return []string{
+		string(mg.GetCondition(prv1.TypeReady).Status),
+		string(mg.GetCondition(prv1.TypeSynced).Status),
+		meta.GetExternalName(mg),
+		age,
+	}
+}
+
+func conditionStatusPath(ct prv1.ConditionType) string {
+	return ".status.conditions[?(@.type=='" + string(ct) + "')].status"
+}
@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+)
+
+func TestPrintColumnValues(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		reason string
+		mg     Managed
+		want   []string
+	}{
+		"Unset": {
+			reason: "Unset conditions should be Unknown and an unset creation time should have an unknown age.",
+			mg:     &fake.Managed{},
+			want:   []string{"Unknown", "Unknown", "", "<unknown>"},
+		},
+		"Set": {
+			reason: "Column values should be computed from the managed resource.",
+			mg: func() Managed {
+				mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-90 * time.Minute))}}
+				meta.SetExternalName(mg, "cool")
+				mg.SetConditions(prv1.Available(), prv1.ReconcileSuccess())
+				return mg
+			}(),
+			want: []string{"True", "True", "cool", "90m"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := PrintColumnValues(tc.mg, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPrintColumnValues(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package resource
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Labels applied to provider config usages that identify the provider config
+// they record a usage of. The namespace label is empty for cluster scoped
+// provider configs.
+const (
+	LabelKeyProviderConfigName      = "krateo.io/provider-config"
+	LabelKeyProviderConfigNamespace = "krateo.io/provider-config-namespace"
+)
+
+// Error strings.
+const (
+	errMissingPCRef = "managed resource does not reference a provider config"
+	errNotPCRef     = "managed resource cannot reference a provider config"
+	errGetGVK       = "cannot determine managed resource kind"
+	errApplyPCU     = "cannot apply provider config usage"
+	errFmtGetPC     = "cannot get %s provider config"
+	errNotCredsSel  = "provider config does not select credentials"
+	errExtractPC    = "cannot extract provider config credentials"
+)
+
+// A Tracker tracks managed resources.
+type Tracker interface {
+	// Track the supplied managed resource.
+	Track(ctx context.Context, mg Managed) error
+}
+
+// A TrackerFn is a function that tracks managed resources.
+type TrackerFn func(ctx context.Context, mg Managed) error
+
+// Track the supplied managed resource.
+func (fn TrackerFn) Track(ctx context.Context, mg Managed) error {
+	return fn(ctx, mg)
+}
+
+// A ProviderConfigUsageTracker tracks usages of a provider config by creating
+// or updating the appropriate ProviderConfigUsage.
+type ProviderConfigUsageTracker struct {
+	c  client.Client
+	a  Applicator
+	of ProviderConfigUsage
+}
+
+// NewProviderConfigUsageTracker creates a ProviderConfigUsageTracker. The
+// supplied ProviderConfigUsage is used as a prototype for the usages that are
+// created.
+func NewProviderConfigUsageTracker(c client.Client, of ProviderConfigUsage) *ProviderConfigUsageTracker {
+	return &ProviderConfigUsageTracker{c: c, a: NewAPIUpdatingApplicator(c), of: of}
+}
+
+// Track that the supplied managed resource is using the provider config it
+// references by creating or updating a ProviderConfigUsage. Track should be
+// called _before_ attempting to use the provider config. This ensures the
+// managed resource's usage is updated if the managed resource is updated to
+// reference a misconfigured provider config. The managed resource must
+// satisfy the ProviderConfigReferencer interface.
+func (u *ProviderConfigUsageTracker) Track(ctx context.Context, mg Managed) error {
+	pcr, ok := mg.(ProviderConfigReferencer)
+	if !ok {
+		return errors.New(errNotPCRef)
+	}
+	ref := pcr.GetProviderConfigReference()
+	if ref == nil {
+		return errors.New(errMissingPCRef)
+	}
+
+	gvk, err := u.c.GroupVersionKindFor(mg)
+	if err != nil {
+		return errors.Wrap(err, errGetGVK)
+	}
+
+	pcu := u.of.DeepCopyObject().(ProviderConfigUsage)
+	pcu.SetName(string(mg.GetUID()))
+	pcu.SetNamespace(mg.GetNamespace())
+	pcu.SetLabels(map[string]string{
+		LabelKeyProviderConfigName:      ref.Name,
+		LabelKeyProviderConfigNamespace: ref.Namespace,
+	})
+	pcu.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(mg, gvk)})
+	pcu.SetProviderConfigReference(prv1.Reference{Name: ref.Name, Namespace: ref.Namespace})
+	pcu.SetResourceReference(prv1.TypedReferenceTo(mg, gvk))
+
+	err = u.a.Apply(ctx, pcu,
+		MustBeControllableBy(mg.GetUID()),
+		AllowUpdateIf(func(current, _ runtime.Object) bool {
+			// Usages created before they were labelled with the namespace of
+			// their provider config must be updated to be accounted for.
+			c := current.(ProviderConfigUsage)
+			l, ok := c.GetLabels()[LabelKeyProviderConfigNamespace]
+			return c.GetProviderConfigReference() != pcu.GetProviderConfigReference() || !ok || l != ref.Namespace
+		}),
+	)
+	return errors.Wrap(Ignore(IsNotAllowed, err), errApplyPCU)
+}
+
+// GetProviderConfig gets the provider config referenced by the supplied
+// managed resource into the supplied object. The managed resource must
+// satisfy the ProviderConfigReferencer interface.
+func GetProviderConfig(ctx context.Context, c client.Client, mg Managed, into ProviderConfig) error {
+	pcr, ok := mg.(ProviderConfigReferencer)
+	if !ok {
+		return errors.New(errNotPCRef)
+	}
+	ref := pcr.GetProviderConfigReference()
+	if ref == nil {
+		return errors.New(errMissingPCRef)
+	}
+
+	err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, into)
+	return errors.Wrapf(err, errFmtGetPC, ref.Name)
+}
+
+// GetProviderConfigCredentials gets the provider config referenced by the
+// supplied managed resource into the supplied object, and extracts the
+// credentials it selects. The provider config must satisfy the
+// CredentialsSelector interface.
+func GetProviderConfigCredentials(ctx context.Context, c client.Client, mg Managed, into ProviderConfig) (Credentials, error) {
+	if err := GetProviderConfig(ctx, c, mg, into); err != nil {
+		return Credentials{}, err
+	}
+
+	cs, ok := into.(CredentialsSelector)
+	if !ok {
+		return Credentials{}, errors.New(errNotCredsSel)
+	}
+
+	creds, err := ExtractCredentials(ctx, CredentialsFromSelectors(c, cs.GetCredentialSelectors())...)
+	return creds, errors.Wrap(err, errExtractPC)
+}
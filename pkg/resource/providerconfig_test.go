@@ -0,0 +1,206 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+var _ Tracker = &ProviderConfigUsageTracker{}
+
+func TestProviderConfigUsageTracker(t *testing.T) {
+	errBoom := errors.New("boom")
+	gvk := fake.GVK(&fake.Managed{})
+
+	mg := func(ref *prv1.Reference) Managed {
+		m := &fake.Managed{}
+		m.SetName("cool")
+		m.SetNamespace("coolns")
+		m.SetUID("mr-uid")
+		m.SetProviderConfigReference(ref)
+		return m
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		mg     Managed
+		want   error
+	}{
+		"MissingReference": {
+			reason: "An error should be returned if the managed resource does not reference a provider config.",
+			mg:     mg(nil),
+			want:   errors.New(errMissingPCRef),
+		},
+		"GVKError": {
+			reason: "An error should be returned if the managed resource's kind cannot be determined.",
+			c:      &test.MockClient{MockGroupVersionKindFor: test.NewMockGroupVersionKindForFn(errBoom, schema.GroupVersionKind{})},
+			mg:     mg(&prv1.Reference{Name: "default"}),
+			want:   errors.Wrap(errBoom, errGetGVK),
+		},
+		"ApplyError": {
+			reason: "Errors applying the usage should be returned.",
+			c: &test.MockClient{
+				MockGroupVersionKindFor: test.NewMockGroupVersionKindForFn(nil, gvk),
+				MockGet:                 test.NewMockGetFn(errBoom),
+			},
+			mg:   mg(&prv1.Reference{Name: "default"}),
+			want: errors.Wrap(errors.Wrap(errBoom, errGetObject), errApplyPCU),
+		},
+		"Created": {
+			reason: "A usage owned by the managed resource should be created.",
+			c: &test.MockClient{
+				MockGroupVersionKindFor: test.NewMockGroupVersionKindForFn(nil, gvk),
+				MockGet:                 test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "")),
+				MockCreate: test.NewMockCreateFn(nil, func(o client.Object) error {
+					pcu := o.(*fake.ProviderConfigUsage)
+					if diff := cmp.Diff("mr-uid", pcu.GetName()); diff != "" {
+						t.Errorf("Create(...): -want name, +got:\n%s", diff)
+					}
+					wantLabels := map[string]string{LabelKeyProviderConfigName: "default", LabelKeyProviderConfigNamespace: "pcns"}
+					if diff := cmp.Diff(wantLabels, pcu.GetLabels()); diff != "" {
+						t.Errorf("Create(...): -want labels, +got:\n%s", diff)
+					}
+					want := prv1.TypedReference{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Name: "cool", UID: "mr-uid"}
+					if diff := cmp.Diff(want, pcu.GetResourceReference()); diff != "" {
+						t.Errorf("Create(...): -want resource reference, +got:\n%s", diff)
+					}
+					return nil
+				}),
+			},
+			mg: mg(&prv1.Reference{Name: "default", Namespace: "pcns"}),
+		},
+		"Relabelled": {
+			reason: "A usage that isn't labelled with the namespace of its provider config should be updated.",
+			c: &test.MockClient{
+				MockGroupVersionKindFor: test.NewMockGroupVersionKindForFn(nil, gvk),
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetLabels(map[string]string{LabelKeyProviderConfigName: "default"})
+					o.(*fake.ProviderConfigUsage).SetProviderConfigReference(prv1.Reference{Name: "default"})
+					return nil
+				}),
+				MockUpdate: test.NewMockUpdateFn(nil, func(o client.Object) error {
+					want := map[string]string{LabelKeyProviderConfigName: "default", LabelKeyProviderConfigNamespace: ""}
+					if diff := cmp.Diff(want, o.GetLabels()); diff != "" {
+						t.Errorf("Update(...): -want labels, +got:\n%s", diff)
+					}
+					return nil
+				}),
+			},
+			mg: mg(&prv1.Reference{Name: "default"}),
+		},
+		"Unchanged": {
+			reason: "No error should be returned if the usage is already up to date.",
+			c: &test.MockClient{
+				MockGroupVersionKindFor: test.NewMockGroupVersionKindForFn(nil, gvk),
+				MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+					o.SetLabels(map[string]string{LabelKeyProviderConfigName: "default", LabelKeyProviderConfigNamespace: ""})
+					o.(*fake.ProviderConfigUsage).SetProviderConfigReference(prv1.Reference{Name: "default"})
+					return nil
+				}),
+			},
+			mg: mg(&prv1.Reference{Name: "default"}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := NewProviderConfigUsageTracker(tc.c, &fake.ProviderConfigUsage{})
+			err := u.Track(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nTrack(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type credentialedProviderConfig struct {
+	fake.ProviderConfig
+	Credentials prv1.CredentialSelectors
+}
+
+func (p *credentialedProviderConfig) GetCredentialSelectors() prv1.CredentialSelectors {
+	return p.Credentials
+}
+
+func TestGetProviderConfigCredentials(t *testing.T) {
+	errBoom := errors.New("boom")
+	t.Setenv("KRATEO_TEST_PC_CREDS", "creds")
+
+	mg := func(ref *prv1.Reference) Managed {
+		m := &fake.Managed{}
+		m.SetProviderConfigReference(ref)
+		return m
+	}
+
+	type want struct {
+		creds Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		mg     Managed
+		into   ProviderConfig
+		want   want
+	}{
+		"MissingReference": {
+			reason: "An error should be returned if the managed resource does not reference a provider config.",
+			mg:     mg(nil),
+			into:   &credentialedProviderConfig{},
+			want:   want{err: errors.New(errMissingPCRef)},
+		},
+		"GetError": {
+			reason: "An error should be returned if the provider config cannot be fetched.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			mg:     mg(&prv1.Reference{Name: "default"}),
+			into:   &credentialedProviderConfig{},
+			want:   want{err: errors.Wrapf(errBoom, errFmtGetPC, "default")},
+		},
+		"NotCredentialsSelector": {
+			reason: "An error should be returned if the provider config does not select credentials.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			mg:     mg(&prv1.Reference{Name: "default"}),
+			into:   &fake.ProviderConfig{},
+			want:   want{err: errors.New(errNotCredsSel)},
+		},
+		"Success": {
+			reason: "The credentials selected by the provider config should be extracted.",
+			c: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+				o.(*credentialedProviderConfig).Credentials = prv1.CredentialSelectors{
+					Env: &prv1.EnvSelector{Name: "KRATEO_TEST_PC_CREDS"},
+				}
+				return nil
+			})},
+			mg:   mg(&prv1.Reference{Name: "default"}),
+			into: &credentialedProviderConfig{},
+			want: want{creds: Credentials{
+				Data:   []byte("creds"),
+				Source: CredentialsSourceEnvironment,
+				Origin: "KRATEO_TEST_PC_CREDS",
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetProviderConfigCredentials(context.Background(), tc.c, tc.mg, tc.into)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetProviderConfigCredentials(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.creds, got); diff != "" {
+				t.Errorf("\n%s\nGetProviderConfigCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+const defaultWaitInterval = 1 * time.Second
+
+// Error strings.
+const (
+	errFmtWaitUntil = "cannot wait for %s to satisfy predicate"
+)
+
+// IsConditionTrue returns true if the supplied object's condition of the
+// supplied type has status True.
+func IsConditionTrue(o Conditioned, ct prv1.ConditionType) bool {
+	return o.GetCondition(ct).Status == metav1.ConditionTrue
+}
+
+// IsReady returns true if the supplied object's Ready condition has status
+// True.
+func IsReady(o Conditioned) bool {
+	return IsConditionTrue(o, prv1.TypeReady)
+}
+
+// An ObjectPredicate returns true if the supplied object satisfies some
+// condition.
+type ObjectPredicate func(o client.Object) bool
+
+// ConditionIsTrue returns an ObjectPredicate that is satisfied by objects
+// whose condition of the supplied type has status True. Objects that are not
+// Conditioned never satisfy the predicate.
+func ConditionIsTrue(ct prv1.ConditionType) ObjectPredicate {
+	return func(o client.Object) bool {
+		c, ok := o.(Conditioned)
+		return ok && IsConditionTrue(c, ct)
+	}
+}
+
+// Ready returns an ObjectPredicate that is satisfied by objects whose Ready
+// condition has status True.
+func Ready() ObjectPredicate {
+	return ConditionIsTrue(prv1.TypeReady)
+}
+
+// A WaitOption configures WaitUntil.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	interval time.Duration
+}
+
+// WithWaitInterval specifies how often WaitUntil gets the object. The object
+// is fetched every second by default.
+func WithWaitInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.interval = d
+	}
+}
+
+// WaitUntil gets the object with the supplied key into the supplied object
+// until it satisfies the supplied predicate, or the supplied context is done.
+// An object that does not yet exist is treated as not satisfying the
+// predicate; any other error getting the object is returned immediately.
+func WaitUntil(ctx context.Context, c client.Reader, key client.ObjectKey, into client.Object, fn ObjectPredicate, o ...WaitOption) error {
+	wo := &waitOptions{interval: defaultWaitInterval}
+	for _, f := range o {
+		f(wo)
+	}
+
+	err := wait.PollUntilContextCancel(ctx, wo.interval, true, func(ctx context.Context) (bool, error) {
+		if err := c.Get(ctx, key, into); err != nil {
+			return false, IgnoreNotFound(err)
+		}
+		return fn(into), nil
+	})
+	return errors.Wrapf(err, errFmtWaitUntil, key.Name)
+}
@@ -0,0 +1,101 @@
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func TestIsReady(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		c      []prv1.Condition
+		want   bool
+	}{
+		"NoCondition": {
+			reason: "An object without a Ready condition is not ready.",
+			want:   false,
+		},
+		"Creating": {
+			reason: "An object whose Ready condition is False is not ready.",
+			c:      []prv1.Condition{prv1.Creating()},
+			want:   false,
+		},
+		"Available": {
+			reason: "An object whose Ready condition is True is ready.",
+			c:      []prv1.Condition{prv1.Available()},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.c...)
+			if diff := cmp.Diff(tc.want, IsReady(mg)); diff != "" {
+				t.Errorf("\n%s\nIsReady(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWaitUntil(t *testing.T) {
+	errBoom := errors.New("boom")
+	key := client.ObjectKey{Namespace: "coolns", Name: "cool"}
+
+	// readyAfter returns a client that reports the object as not found, then
+	// as creating, then as available.
+	readyAfter := func() client.Reader {
+		calls := 0
+		return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			calls++
+			switch calls {
+			case 1:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			case 2:
+				obj.(*fake.Managed).SetConditions(prv1.Creating())
+			default:
+				obj.(*fake.Managed).SetConditions(prv1.Available())
+			}
+			return nil
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Reader
+		want   error
+	}{
+		"GetError": {
+			reason: "Errors other than not found should be returned immediately.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want:   errors.Wrapf(errBoom, errFmtWaitUntil, key.Name),
+		},
+		"EventuallyReady": {
+			reason: "WaitUntil should return once the object satisfies the predicate.",
+			c:      readyAfter(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := WaitUntil(ctx, tc.c, key, &fake.Managed{}, Ready(), WithWaitInterval(time.Millisecond))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWaitUntil(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
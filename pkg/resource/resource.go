@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/krateoplatformops/provider-runtime/pkg/errors"
@@ -9,14 +10,170 @@ import (
 	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// SecretTypeConnection is the type of Krateo connection secrets.
+const SecretTypeConnection corev1.SecretType = "connection.krateo.io/v1alpha1"
+
+// Error strings.
+const (
+	errFmtCreateKind    = "cannot create object of kind %s"
+	errFmtAmbiguousKind = "object is registered as multiple kinds: %v"
+)
+
+// Labels applied to connection secrets.
+const (
+	// LabelKeyManagedBy identifies the system that manages an object.
+	LabelKeyManagedBy = "app.kubernetes.io/managed-by"
+
+	// LabelKeyOwnerUID is the UID of the object that owns a connection
+	// secret.
+	LabelKeyOwnerUID = "krateo.io/owner-uid"
+
+	// LabelKeyOwnerKind is the kind of the object that owns a connection
+	// secret.
+	LabelKeyOwnerKind = "krateo.io/owner-kind"
+
+	// LabelValueManagedBy is the value of the LabelKeyManagedBy label for
+	// objects managed by Krateo providers.
+	LabelValueManagedBy = "krateo"
+)
+
 // A ManagedKind contains the type metadata for a kind of managed resource.
 type ManagedKind schema.GroupVersionKind
 
+// ConnectionDetails created or updated during an operation on an external
+// resource, for example usernames, passwords, endpoints, ports, etc.
+type ConnectionDetails map[string][]byte
+
+// ConnectionSecretFor creates a connection secret in the namespace specified
+// by the supplied ConnectionSecretOwner, or in the owner's namespace if none
+// is specified, containing the supplied connection details. The secret is
+// controlled by the supplied owner, which must be of the supplied kind. It
+// panics if the owner does not reference a connection secret.
+func ConnectionSecretFor(o ConnectionSecretOwner, kind schema.GroupVersionKind, data ConnectionDetails) *corev1.Secret {
+	ref := o.GetWriteConnectionSecretToReference()
+
+	ns := ref.Namespace
+	if ns == "" {
+		ns = o.GetNamespace()
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      ref.Name,
+			Labels: map[string]string{
+				LabelKeyManagedBy: LabelValueManagedBy,
+				LabelKeyOwnerUID:  string(o.GetUID()),
+				LabelKeyOwnerKind: kind.Kind,
+			},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(o, kind)},
+		},
+		Type: SecretTypeConnection,
+		Data: data,
+	}
+}
+
+// connectionSecretEqual returns true if the current secret has the type,
+// data, controller reference, and labels of the desired secret. Labels and
+// owner references that the desired secret doesn't set are ignored.
+func connectionSecretEqual(current, desired *corev1.Secret) bool {
+	if current.Type != desired.Type || len(current.Data) != len(desired.Data) {
+		return false
+	}
+	for k, v := range desired.Data {
+		w, ok := current.Data[k]
+		if !ok || !bytes.Equal(v, w) {
+			return false
+		}
+	}
+	for k, v := range desired.GetLabels() {
+		if w, ok := current.GetLabels()[k]; !ok || v != w {
+			return false
+		}
+	}
+	if want := metav1.GetControllerOf(desired); want != nil {
+		got := metav1.GetControllerOf(current)
+		if got == nil || got.UID != want.UID || got.APIVersion != want.APIVersion || got.Kind != want.Kind || got.Name != want.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// An ApplyOption is called before patching the current object to match the
+// desired object. ApplyOptions are not called if no current object exists.
+type ApplyOption func(ctx context.Context, current, desired runtime.Object) error
+
+// MustBeControllableBy requires that the current object is controlled by an
+// object with the supplied UID. An object is controlled if its controller
+// reference matches the supplied UID, or it has no controller reference.
+func MustBeControllableBy(u types.UID) ApplyOption {
+	return func(_ context.Context, current, _ runtime.Object) error {
+		mo, ok := current.(metav1.Object)
+		if !ok {
+			return nil
+		}
+		c := metav1.GetControllerOf(mo)
+		if c == nil {
+			return nil
+		}
+		if c.UID != u {
+			return errNotControllable{errors.Errorf("existing object is not controlled by UID %q", u)}
+		}
+		return nil
+	}
+}
+
+// AllowUpdateIf will only update the current object if the supplied fn returns
+// true. An error that satisfies IsNotAllowed will be returned if the supplied
+// function returns false. Creation of a desired object that does not currently
+// exist is always allowed.
+func AllowUpdateIf(fn func(current, desired runtime.Object) bool) ApplyOption {
+	return func(_ context.Context, current, desired runtime.Object) error {
+		if fn(current, desired) {
+			return nil
+		}
+		return errNotAllowed{errors.New("update not allowed")}
+	}
+}
+
+type errNotControllable struct{ error }
+
+func (e errNotControllable) NotControllable() bool {
+	return true
+}
+
+// IsNotControllable returns true if the supplied error indicates that a
+// resource is not controllable - i.e. that another resource is its controller
+// reference.
+func IsNotControllable(err error) bool {
+	_, ok := err.(interface { //nolint: errorlint // Skip errorlint for interface type
+		NotControllable() bool
+	})
+	return ok
+}
+
+type errNotAllowed struct{ error }
+
+func (e errNotAllowed) NotAllowed() bool {
+	return true
+}
+
+// IsNotAllowed returns true if the supplied error indicates that an operation
+// was not allowed.
+func IsNotAllowed(err error) bool {
+	_, ok := err.(interface { //nolint: errorlint // Skip errorlint for interface type
+		NotAllowed() bool
+	})
+	return ok
+}
+
 // IsMissingReference returns true if an error indicates that a managed
 // resource is missing a required reference..
 func IsMissingReference(err error) bool {
@@ -36,6 +193,33 @@ func MustCreateObject(kind schema.GroupVersionKind, oc runtime.ObjectCreater) ru
 	return obj
 }
 
+// CreateObject returns a new Object of the supplied kind. It returns an error
+// if the kind is unknown to the supplied ObjectCreator.
+func CreateObject(kind schema.GroupVersionKind, oc runtime.ObjectCreater) (runtime.Object, error) {
+	obj, err := oc.New(kind)
+	return obj, errors.Wrapf(err, errFmtCreateKind, kind)
+}
+
+// GVKForObject returns the kind of the supplied object. The kind recorded in
+// the object's type metadata is returned if it is set, as it always is for
+// unstructured objects. Otherwise the kind is looked up using the supplied
+// ObjectTyper. An error is returned if the object's kind is unknown, or is
+// ambiguous because the object is registered as more than one kind.
+func GVKForObject(o runtime.Object, ot runtime.ObjectTyper) (schema.GroupVersionKind, error) {
+	if gvk := o.GetObjectKind().GroupVersionKind(); gvk.Kind != "" && gvk.Version != "" {
+		return gvk, nil
+	}
+
+	gvks, _, err := ot.ObjectKinds(o)
+	if err != nil {
+		return schema.GroupVersionKind{}, errors.Wrap(err, errGetGVK)
+	}
+	if len(gvks) > 1 {
+		return schema.GroupVersionKind{}, errors.Errorf(errFmtAmbiguousKind, gvks)
+	}
+	return gvks[0], nil
+}
+
 // An ErrorIs function returns true if an error satisfies a particular condition.
 type ErrorIs func(err error) bool
 
@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	prv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+var (
+	_ Orphanable               = &prv1.ResourceSpec{}
+	_ Manageable               = &prv1.ResourceSpec{}
+	_ ProviderConfigReferencer = &prv1.ResourceSpec{}
+	_ ConnectionSecretWriterTo = &prv1.ResourceSpec{}
+	_ ReconcilePolicyHolder    = &prv1.ResourceSpec{}
+	_ Conditioned              = &prv1.ResourceStatus{}
+	_ ConditionsHasher         = &prv1.ResourceStatus{}
+	_ SyncTimer                = &prv1.ResourceStatus{}
+	_ GenerationObserver       = &prv1.ResourceStatus{}
+	_ FailureCounter           = &prv1.ResourceStatus{}
+	_ ExternalIdentifiable     = &prv1.ResourceStatus{}
+)
+
+func TestCreateObject(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	known := corev1.SchemeGroupVersion.WithKind("Secret")
+	unknown := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Unknown"}
+
+	type want struct {
+		o   runtime.Object
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kind   schema.GroupVersionKind
+		want   want
+	}{
+		"Known": {
+			reason: "A new object of a known kind should be returned.",
+			kind:   known,
+			want:   want{o: &corev1.Secret{}},
+		},
+		"Unknown": {
+			reason: "An error should be returned for an unknown kind.",
+			kind:   unknown,
+			want:   want{err: errors.Wrapf(runtime.NewNotRegisteredErrForKind(s.Name(), unknown), errFmtCreateKind, unknown)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := CreateObject(tc.kind, s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateObject(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nCreateObject(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGVKForObject(t *testing.T) {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Cool"})
+
+	type want struct {
+		gvk schema.GroupVersionKind
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      runtime.Object
+		want   want
+	}{
+		"Unstructured": {
+			reason: "The kind recorded in an object's type metadata should be returned.",
+			o:      u,
+			want:   want{gvk: u.GroupVersionKind()},
+		},
+		"Typed": {
+			reason: "The kind of a typed object should be looked up in the scheme.",
+			o:      &corev1.Secret{},
+			want:   want{gvk: corev1.SchemeGroupVersion.WithKind("Secret")},
+		},
+		"Unregistered": {
+			reason: "An error should be returned if the object's kind is unknown.",
+			o:      &unstructured.UnstructuredList{},
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GVKForObject(tc.o, s)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nGVKForObject(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.gvk, got); diff != "" {
+				t.Errorf("\n%s\nGVKForObject(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
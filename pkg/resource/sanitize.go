@@ -0,0 +1,61 @@
+package resource
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AnnotationKeyLastAppliedConfiguration is the annotation kubectl uses to
+// record the configuration it last applied to an object.
+const AnnotationKeyLastAppliedConfiguration = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Sanitize strips the supplied object of metadata that is set by the API
+// server, or by clients such as kubectl, rather than being part of the desired
+// state of the object. This includes managed fields, the resource version, UID,
+// generation, creation timestamp, self link, and kubectl's last applied
+// configuration. The object is modified in place and returned, so that
+// sanitized objects may be compared with desired objects or stored without
+// producing spurious differences.
+func Sanitize[T metav1.Object](o T) T {
+	o.SetManagedFields(nil)
+	o.SetResourceVersion("")
+	o.SetUID("")
+	o.SetGeneration(0)
+	o.SetCreationTimestamp(metav1.Time{})
+	o.SetSelfLink("")
+
+	if a := o.GetAnnotations(); a != nil {
+		delete(a, AnnotationKeyLastAppliedConfiguration)
+		if len(a) == 0 {
+			a = nil
+		}
+		o.SetAnnotations(a)
+	}
+
+	if u, ok := any(o).(*unstructured.Unstructured); ok {
+		if len(u.GetAnnotations()) == 0 {
+			unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+		}
+		unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	}
+
+	return o
+}
+
+// EquateSanitized returns options that cause cmp to ignore the metadata that
+// Sanitize strips, and to treat nil and empty slices and maps as equal. It may
+// be used to determine whether an observed object is up-to-date with a desired
+// object.
+func EquateSanitized() cmp.Option {
+	return cmp.Options{
+		cmpopts.EquateEmpty(),
+		cmp.Transformer("SanitizeObjectMeta", func(in metav1.ObjectMeta) metav1.ObjectMeta {
+			return *Sanitize(in.DeepCopy())
+		}),
+		cmp.Transformer("SanitizeUnstructured", func(in unstructured.Unstructured) map[string]any {
+			return Sanitize(in.DeepCopy()).Object
+		}),
+	}
+}
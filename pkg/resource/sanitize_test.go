@@ -0,0 +1,81 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSanitize(t *testing.T) {
+	observed := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "coolns",
+				Name:              "cool",
+				UID:               "some-uid",
+				ResourceVersion:   "42",
+				Generation:        3,
+				CreationTimestamp: metav1.NewTime(time.Now()),
+				ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				Annotations: map[string]string{
+					AnnotationKeyLastAppliedConfiguration: "{}",
+					"cool":                                "very",
+				},
+			},
+			Data: map[string]string{"key": "value"},
+		}
+	}
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "coolns",
+			Name:        "cool",
+			Annotations: map[string]string{"cool": "very"},
+		},
+		Data: map[string]string{"key": "value"},
+	}
+
+	t.Run("Typed", func(t *testing.T) {
+		if diff := cmp.Diff(desired, Sanitize(observed())); diff != "" {
+			t.Errorf("Sanitize(...): -want, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("EquateSanitized", func(t *testing.T) {
+		if diff := cmp.Diff(desired, observed(), EquateSanitized()); diff != "" {
+			t.Errorf("cmp.Diff(..., EquateSanitized()): -want, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("Unstructured", func(t *testing.T) {
+		u := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"namespace":         "coolns",
+				"name":              "cool",
+				"uid":               "some-uid",
+				"resourceVersion":   "42",
+				"creationTimestamp": "2024-01-01T00:00:00Z",
+				"managedFields":     []any{map[string]any{"manager": "kubectl"}},
+				"annotations": map[string]any{
+					AnnotationKeyLastAppliedConfiguration: "{}",
+				},
+			},
+		}}
+		want := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"namespace": "coolns",
+				"name":      "cool",
+			},
+		}}
+		if diff := cmp.Diff(want, Sanitize(u)); diff != "" {
+			t.Errorf("Sanitize(...): -want, +got:\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+const (
+	envPodNamespace             = "POD_NAMESPACE"
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Error strings.
+const (
+	errNoSecretReferenced = "no secret referenced"
+	errFmtGetSecret       = "cannot get %s secret"
+	errFmtKeyNotFound     = "key %s not found in %s/%s secret"
+	errFmtParseBool       = "cannot parse key %s of %s/%s secret as a boolean"
+	errFmtParseInt        = "cannot parse key %s of %s/%s secret as an integer"
+	errFmtUnmarshalJSON   = "cannot unmarshal key %s of %s/%s secret as JSON"
+)
+
+type errKeyNotFound struct{ error }
+
+func (e errKeyNotFound) KeyNotFound() bool {
+	return true
+}
+
+// IsKeyNotFound returns true if the supplied error indicates that a key was
+// not found in a referenced Secret or ConfigMap.
+func IsKeyNotFound(err error) bool {
+	_, ok := err.(interface { //nolint: errorlint // Skip errorlint for interface type
+		KeyNotFound() bool
+	})
+	return ok
+}
+
+// GetSecretData returns all the data of the referenced secret.
+func GetSecretData(ctx context.Context, k client.Client, ref *commonv1.Reference) (map[string][]byte, error) {
+	if ref == nil {
+		return nil, errors.New(errNoSecretReferenced)
+	}
+
+	s := &corev1.Secret{}
+	if err := k.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, errors.Wrapf(err, errFmtGetSecret, ref.Name)
+	}
+
+	return s.Data, nil
+}
+
+// GetSecretBytes returns the value of the referenced secret key. It returns an
+// error that satisfies IsKeyNotFound if the key does not exist, unless the
+// selector is optional, in which case nil is returned if the secret or key
+// does not exist.
+func GetSecretBytes(ctx context.Context, k client.Client, ref *commonv1.SecretKeySelector) ([]byte, error) {
+	v, _, err := getSecretBytes(ctx, k, ref)
+	return v, err
+}
+
+// getSecretBytes returns the value of the referenced secret key, and whether
+// it was found. Only optional selectors may return not found without error.
+func getSecretBytes(ctx context.Context, k client.Client, ref *commonv1.SecretKeySelector) ([]byte, bool, error) {
+	if ref == nil {
+		return nil, false, errors.New(errNoSecretReferenced)
+	}
+
+	data, err := GetSecretData(ctx, k, &ref.Reference)
+	if ref.Optional && kerrors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	v, ok := data[ref.Key]
+	if !ok && !ref.Optional {
+		return nil, false, errKeyNotFound{errors.Errorf(errFmtKeyNotFound, ref.Key, ref.Namespace, ref.Name)}
+	}
+
+	return v, ok, nil
+}
+
+// GetSecretString returns the value of the referenced secret key as a string.
+// It returns an error that satisfies IsKeyNotFound if the key does not exist,
+// unless the selector is optional.
+func GetSecretString(ctx context.Context, k client.Client, ref *commonv1.SecretKeySelector) (string, error) {
+	v, err := GetSecretBytes(ctx, k, ref)
+	return string(v), err
+}
+
+// GetSecretBool returns the value of the referenced secret key parsed as a
+// boolean. It returns an error that satisfies IsKeyNotFound if the key does
+// not exist, unless the selector is optional, in which case false is returned.
+func GetSecretBool(ctx context.Context, k client.Client, ref *commonv1.SecretKeySelector) (bool, error) {
+	v, found, err := getSecretBytes(ctx, k, ref)
+	if err != nil || !found {
+		return false, err
+	}
+
+	b, err := strconv.ParseBool(string(v))
+	return b, errors.Wrapf(err, errFmtParseBool, ref.Key, ref.Namespace, ref.Name)
+}
+
+// GetSecretInt returns the value of the referenced secret key parsed as an
+// integer. It returns an error that satisfies IsKeyNotFound if the key does
+// not exist, unless the selector is optional, in which case 0 is returned.
+func GetSecretInt(ctx context.Context, k client.Client, ref *commonv1.SecretKeySelector) (int, error) {
+	v, found, err := getSecretBytes(ctx, k, ref)
+	if err != nil || !found {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(string(v))
+	return i, errors.Wrapf(err, errFmtParseInt, ref.Key, ref.Namespace, ref.Name)
+}
+
+// GetSecretJSON unmarshals the JSON value of the referenced secret key into
+// the supplied object. It returns an error that satisfies IsKeyNotFound if the
+// key does not exist, unless the selector is optional, in which case the
+// supplied object is left unchanged.
+func GetSecretJSON(ctx context.Context, k client.Client, ref *commonv1.SecretKeySelector, into any) error {
+	v, found, err := getSecretBytes(ctx, k, ref)
+	if err != nil || !found {
+		return err
+	}
+
+	return errors.Wrapf(json.Unmarshal(v, into), errFmtUnmarshalJSON, ref.Key, ref.Namespace, ref.Name)
+}
+
+// ProviderNamespace returns the namespace in which the provider is running.
+// It is read from the POD_NAMESPACE environment variable, falling back to the
+// namespace of the pod's service account. An empty string is returned if the
+// namespace cannot be determined.
+func ProviderNamespace() string {
+	if ns, ok := os.LookupEnv(envPodNamespace); ok && ns != "" {
+		return ns
+	}
+	if b, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(b))
+	}
+	return ""
+}
+
+// SecretKeySelectorFor returns a SecretKeySelector that selects the key
+// selected by the supplied LocalSecretKeySelector in the namespace of the
+// supplied object. The provider's namespace is used if the object is cluster
+// scoped.
+func SecretKeySelectorFor(o metav1.Object, sel *commonv1.LocalSecretKeySelector) *commonv1.SecretKeySelector {
+	if sel == nil {
+		return nil
+	}
+	ns := o.GetNamespace()
+	if ns == "" {
+		ns = ProviderNamespace()
+	}
+	return sel.ToSecretKeySelector(ns)
+}
+
+// GetLocalSecretBytes returns the value of the secret key selected by the
+// supplied LocalSecretKeySelector, defaulting its namespace as described by
+// SecretKeySelectorFor. It returns an error that satisfies IsKeyNotFound if
+// the key does not exist.
+func GetLocalSecretBytes(ctx context.Context, k client.Client, o metav1.Object, sel *commonv1.LocalSecretKeySelector) ([]byte, error) {
+	return GetSecretBytes(ctx, k, SecretKeySelectorFor(o, sel))
+}
+
+// GetLocalSecretString returns the value of the secret key selected by the
+// supplied LocalSecretKeySelector as a string, defaulting its namespace as
+// described by SecretKeySelectorFor.
+func GetLocalSecretString(ctx context.Context, k client.Client, o metav1.Object, sel *commonv1.LocalSecretKeySelector) (string, error) {
+	return GetSecretString(ctx, k, SecretKeySelectorFor(o, sel))
+}
@@ -0,0 +1,188 @@
+package resource
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/krateoplatformops/provider-runtime/apis/common/v1"
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/test"
+)
+
+func secretWith(data map[string][]byte) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*corev1.Secret).Data = data
+			return nil
+		}),
+	}
+}
+
+func TestGetSecretData(t *testing.T) {
+	errBoom := errors.New("boom")
+	ref := &commonv1.Reference{Name: "cool", Namespace: "coolns"}
+
+	type want struct {
+		data map[string][]byte
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		ref    *commonv1.Reference
+		want   want
+	}{
+		"NoReference": {
+			reason: "An error should be returned if no secret is referenced.",
+			want:   want{err: errors.New(errNoSecretReferenced)},
+		},
+		"GetError": {
+			reason: "An error should be returned if the secret cannot be fetched.",
+			c:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			ref:    ref,
+			want:   want{err: errors.Wrapf(errBoom, errFmtGetSecret, ref.Name)},
+		},
+		"Success": {
+			reason: "All data of the referenced secret should be returned.",
+			c:      secretWith(map[string][]byte{"a": []byte("b"), "c": []byte("d")}),
+			ref:    ref,
+			want:   want{data: map[string][]byte{"a": []byte("b"), "c": []byte("d")}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetSecretData(context.Background(), tc.c, tc.ref)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetSecretData(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("\n%s\nGetSecretData(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetSecretTyped(t *testing.T) {
+	ref := func(key string) *commonv1.SecretKeySelector {
+		return &commonv1.SecretKeySelector{Reference: commonv1.Reference{Name: "cool", Namespace: "coolns"}, Key: key}
+	}
+	c := secretWith(map[string][]byte{
+		"string": []byte("value"),
+		"bool":   []byte("true"),
+		"int":    []byte("42"),
+		"json":   []byte(`{"name":"cool"}`),
+	})
+
+	t.Run("String", func(t *testing.T) {
+		got, err := GetSecretString(context.Background(), c, ref("string"))
+		if err != nil || got != "value" {
+			t.Errorf("GetSecretString(...): want %q, got %q, %v", "value", got, err)
+		}
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		got, err := GetSecretBool(context.Background(), c, ref("bool"))
+		if err != nil || !got {
+			t.Errorf("GetSecretBool(...): want true, got %t, %v", got, err)
+		}
+	})
+
+	t.Run("Int", func(t *testing.T) {
+		got, err := GetSecretInt(context.Background(), c, ref("int"))
+		if err != nil || got != 42 {
+			t.Errorf("GetSecretInt(...): want 42, got %d, %v", got, err)
+		}
+	})
+
+	t.Run("InvalidInt", func(t *testing.T) {
+		_, err := GetSecretInt(context.Background(), c, ref("string"))
+		_, cause := strconv.Atoi("value")
+		want := errors.Wrapf(cause, errFmtParseInt, "string", "coolns", "cool")
+		if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+			t.Errorf("GetSecretInt(...): -want error, +got error:\n%s", diff)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		got := struct {
+			Name string `json:"name"`
+		}{}
+		if err := GetSecretJSON(context.Background(), c, ref("json"), &got); err != nil || got.Name != "cool" {
+			t.Errorf("GetSecretJSON(...): want name %q, got %q, %v", "cool", got.Name, err)
+		}
+	})
+
+	t.Run("OptionalKeyNotFound", func(t *testing.T) {
+		sel := ref("missing")
+		sel.Optional = true
+		got, err := GetSecretInt(context.Background(), c, sel)
+		if err != nil || got != 0 {
+			t.Errorf("GetSecretInt(...): want 0, got %d, %v", got, err)
+		}
+	})
+
+	t.Run("OptionalNotFound", func(t *testing.T) {
+		sel := ref("string")
+		sel.Optional = true
+		nf := &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "cool"))}
+		got, err := GetSecretString(context.Background(), nf, sel)
+		if err != nil || got != "" {
+			t.Errorf("GetSecretString(...): want %q, got %q, %v", "", got, err)
+		}
+	})
+
+	t.Run("KeyNotFound", func(t *testing.T) {
+		_, err := GetSecretString(context.Background(), c, ref("missing"))
+		if !IsKeyNotFound(err) {
+			t.Errorf("GetSecretString(...): want key not found error, got %v", err)
+		}
+	})
+}
+
+func TestSecretKeySelectorFor(t *testing.T) {
+	t.Setenv(envPodNamespace, "provider-system")
+	sel := &commonv1.LocalSecretKeySelector{LocalReference: commonv1.LocalReference{Name: "cool"}, Key: "key"}
+
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		sel    *commonv1.LocalSecretKeySelector
+		want   *commonv1.SecretKeySelector
+	}{
+		"NoSelector": {
+			reason: "No selector should be returned if none is supplied.",
+			o:      &metav1.ObjectMeta{Namespace: "coolns"},
+		},
+		"Namespaced": {
+			reason: "The namespace of a namespaced object should be used.",
+			o:      &metav1.ObjectMeta{Namespace: "coolns"},
+			sel:    sel,
+			want:   &commonv1.SecretKeySelector{Reference: commonv1.Reference{Name: "cool", Namespace: "coolns"}, Key: "key"},
+		},
+		"ClusterScoped": {
+			reason: "The provider's namespace should be used for a cluster scoped object.",
+			o:      &metav1.ObjectMeta{},
+			sel:    sel,
+			want:   &commonv1.SecretKeySelector{Reference: commonv1.Reference{Name: "cool", Namespace: "provider-system"}, Key: "key"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SecretKeySelectorFor(tc.o, tc.sel)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSecretKeySelectorFor(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package resource
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RecordSyncAttempt records the supplied time as the last time the supplied
+// object was reconciled. It is a no-op if the object is not a SyncTimer.
+func RecordSyncAttempt(o Object, t metav1.Time) {
+	if st, ok := o.(SyncTimer); ok {
+		st.SetLastSyncAttemptTime(t)
+	}
+}
+
+// RecordSuccessfulSync records the supplied time as the last time the
+// supplied object was successfully reconciled. It is a no-op if the object is
+// not a SyncTimer.
+func RecordSuccessfulSync(o Object, t metav1.Time) {
+	if st, ok := o.(SyncTimer); ok {
+		st.SetLastSuccessfulSyncTime(t)
+	}
+}
+
+// GetLastSyncAttempt returns the last time the supplied object was
+// reconciled. It returns the zero time if the object has never been
+// reconciled, or is not a SyncTimer.
+func GetLastSyncAttempt(o Object) time.Time {
+	st, ok := o.(SyncTimer)
+	if !ok || st.GetLastSyncAttemptTime() == nil {
+		return time.Time{}
+	}
+	return st.GetLastSyncAttemptTime().Time
+}
+
+// GetLastSuccessfulSync returns the last time the supplied object was
+// successfully reconciled. It returns the zero time if the object has never
+// been successfully reconciled, or is not a SyncTimer.
+func GetLastSuccessfulSync(o Object) time.Time {
+	st, ok := o.(SyncTimer)
+	if !ok || st.GetLastSuccessfulSyncTime() == nil {
+		return time.Time{}
+	}
+	return st.GetLastSuccessfulSyncTime().Time
+}
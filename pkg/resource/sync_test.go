@@ -0,0 +1,56 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/resource/fake"
+)
+
+type syncTimerObject struct {
+	fake.Object
+	fake.SyncTimer
+}
+
+func TestSyncTimes(t *testing.T) {
+	attempted := metav1.NewTime(time.Now().Round(time.Second))
+	succeeded := metav1.NewTime(attempted.Add(-time.Minute))
+
+	type want struct {
+		attempt time.Time
+		success time.Time
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      Object
+		want   want
+	}{
+		"SyncTimer": {
+			reason: "Sync times should be recorded on objects that are SyncTimers.",
+			o:      &syncTimerObject{},
+			want:   want{attempt: attempted.Time, success: succeeded.Time},
+		},
+		"NotSyncTimer": {
+			reason: "Sync times should not be recorded on objects that are not SyncTimers.",
+			o:      &fake.Object{},
+			want:   want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			RecordSyncAttempt(tc.o, attempted)
+			RecordSuccessfulSync(tc.o, succeeded)
+			if diff := cmp.Diff(tc.want.attempt, GetLastSyncAttempt(tc.o)); diff != "" {
+				t.Errorf("\n%s\nGetLastSyncAttempt(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.success, GetLastSuccessfulSync(tc.o)); diff != "" {
+				t.Errorf("\n%s\nGetLastSuccessfulSync(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -37,3 +37,10 @@ func EquateErrors() cmp.Option {
 func EquateConditions() cmp.Option {
 	return cmpopts.SortSlices(func(i, j prv1.Condition) bool { return i.Type < j.Type })
 }
+
+// IgnoreObservedGeneration ignores the ObservedGeneration of any Condition
+// when comparing them, for tests that aren't concerned with which generation
+// a condition was observed at.
+func IgnoreObservedGeneration() cmp.Option {
+	return cmpopts.IgnoreFields(prv1.Condition{}, "ObservedGeneration")
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+)
+
+// Error strings.
+const (
+	errNewExporter = "cannot create OTLP trace exporter"
+	errNewResource = "cannot create trace resource"
+)
+
+type otlpConfig struct {
+	endpoint       string
+	insecure       bool
+	serviceName    string
+	serviceVersion string
+	sampleRatio    float64
+}
+
+// An OTLPOption configures the tracer provider returned by
+// NewOTLPTracerProvider.
+type OTLPOption func(*otlpConfig)
+
+// WithEndpoint configures the host and port of the OTLP gRPC endpoint to
+// which spans are exported. By default the endpoint is read from the
+// OTEL_EXPORTER_OTLP_ENDPOINT environment variable, or is localhost:4317.
+func WithEndpoint(endpoint string) OTLPOption {
+	return func(c *otlpConfig) {
+		c.endpoint = endpoint
+	}
+}
+
+// WithInsecure configures spans to be exported without TLS.
+func WithInsecure() OTLPOption {
+	return func(c *otlpConfig) {
+		c.insecure = true
+	}
+}
+
+// WithServiceName configures the name of the service that produces spans,
+// typically the name of the provider.
+func WithServiceName(name string) OTLPOption {
+	return func(c *otlpConfig) {
+		c.serviceName = name
+	}
+}
+
+// WithServiceVersion configures the version of the service that produces
+// spans, typically the version of the provider.
+func WithServiceVersion(version string) OTLPOption {
+	return func(c *otlpConfig) {
+		c.serviceVersion = version
+	}
+}
+
+// WithSampleRatio configures the fraction of reconciles that are traced,
+// between 0 and 1. Every reconcile is traced by default. Reconciles are
+// always traced if their parent span is sampled.
+func WithSampleRatio(r float64) OTLPOption {
+	return func(c *otlpConfig) {
+		c.sampleRatio = r
+	}
+}
+
+// NewOTLPTracerProvider returns a tracer provider that exports spans in
+// batches to an OTLP endpoint using gRPC. Callers should shut the provider
+// down before exiting, so that buffered spans are exported.
+func NewOTLPTracerProvider(ctx context.Context, o ...OTLPOption) (*sdktrace.TracerProvider, error) {
+	c := &otlpConfig{sampleRatio: 1}
+	for _, fn := range o {
+		fn(c)
+	}
+
+	eo := []otlptracegrpc.Option{}
+	if c.endpoint != "" {
+		eo = append(eo, otlptracegrpc.WithEndpoint(c.endpoint))
+	}
+	if c.insecure {
+		eo = append(eo, otlptracegrpc.WithInsecure())
+	}
+	exp, err := otlptracegrpc.New(ctx, eo...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewExporter)
+	}
+
+	ro := []resource.Option{resource.WithFromEnv(), resource.WithTelemetrySDK()}
+	if c.serviceName != "" {
+		ro = append(ro, resource.WithAttributes(semconv.ServiceName(c.serviceName)))
+	}
+	if c.serviceVersion != "" {
+		ro = append(ro, resource.WithAttributes(semconv.ServiceVersion(c.serviceVersion)))
+	}
+	res, err := resource.New(ctx, ro...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewResource)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.sampleRatio))),
+	), nil
+}
+
+// SetupOTLP configures the global tracer provider to export spans to an OTLP
+// endpoint, and the global propagator to propagate W3C trace context and
+// baggage. It returns a function that shuts the tracer provider down, which
+// callers should call before exiting.
+func SetupOTLP(ctx context.Context, o ...OTLPOption) (func(context.Context) error, error) {
+	tp, err := NewOTLPTracerProvider(ctx, o...)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp.Shutdown, nil
+}
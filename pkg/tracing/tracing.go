@@ -0,0 +1,54 @@
+// Package tracing traces reconciles using OpenTelemetry.
+package tracing
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the name of the tracer returned by Tracer.
+const TracerName = "github.com/krateoplatformops/provider-runtime"
+
+// Span names. A reconcile is traced as a Reconcile span, with a child span
+// for each call to the external system and each status update.
+const (
+	SpanReconcile    = "Reconcile"
+	SpanConnect      = "Connect"
+	SpanObserve      = "Observe"
+	SpanCreate       = "Create"
+	SpanUpdate       = "Update"
+	SpanDelete       = "Delete"
+	SpanUpdateStatus = "UpdateStatus"
+)
+
+// Attribute keys of Reconcile spans.
+const (
+	AttributeKeyReconcileID  = attribute.Key("krateo.reconcile_id")
+	AttributeKeyNamespace    = attribute.Key("krateo.resource.namespace")
+	AttributeKeyName         = attribute.Key("krateo.resource.name")
+	AttributeKeyExternalName = attribute.Key("krateo.resource.external_name")
+)
+
+// Tracer returns a tracer from the global tracer provider, which may be
+// configured using SetupOTLP.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// NewNopTracer returns a tracer that does nothing.
+func NewNopTracer() trace.Tracer {
+	return noop.NewTracerProvider().Tracer(TracerName)
+}
+
+// EndSpan ends the supplied span, recording the supplied error if it is not
+// nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
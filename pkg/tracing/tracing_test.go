@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndSpan(t *testing.T) {
+	type want struct {
+		status codes.Code
+		events int
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"Success": {
+			reason: "A span without an error should not record an error.",
+			want:   want{status: codes.Unset},
+		},
+		"Error": {
+			reason: "A span with an error should record the error and an error status.",
+			err:    errors.New("boom"),
+			want:   want{status: codes.Error, events: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			_, span := tp.Tracer(TracerName).Start(context.Background(), SpanObserve)
+
+			EndSpan(span, tc.err)
+
+			ended := sr.Ended()
+			if len(ended) != 1 {
+				t.Fatalf("\n%s\nEndSpan(...): want 1 ended span, got %d", tc.reason, len(ended))
+			}
+			got := want{status: ended[0].Status().Code, events: len(ended[0].Events())}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nEndSpan(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
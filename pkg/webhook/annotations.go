@@ -0,0 +1,50 @@
+// Package webhook contains admission webhook handlers for managed resources.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+// Error strings.
+const (
+	errDecodeObject = "cannot decode object"
+)
+
+// An AnnotationValidator is an admission handler that denies requests to
+// create or update resources with invalid krateo.io annotations. It may be
+// registered for any kind of resource, for example:
+//
+//	mgr.GetWebhookServer().Register("/validate-annotations", &admission.Webhook{Handler: webhook.NewAnnotationValidator()})
+type AnnotationValidator struct{}
+
+// NewAnnotationValidator returns a new AnnotationValidator.
+func NewAnnotationValidator() *AnnotationValidator {
+	return &AnnotationValidator{}
+}
+
+// Handle the supplied admission request by validating the annotations of the
+// object it creates or updates. Other requests are always allowed.
+func (v *AnnotationValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	o := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.Object.Raw, o); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeObject))
+	}
+
+	if err := meta.ValidateAnnotations(o); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
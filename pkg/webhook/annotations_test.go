@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+func TestAnnotationValidator(t *testing.T) {
+	request := func(op admissionv1.Operation, raw string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			Object:    runtime.RawExtension{Raw: []byte(raw)},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    admission.Request
+		want   bool
+	}{
+		"ValidCreate": {
+			reason: "Creating a resource with valid annotations should be allowed.",
+			req:    request(admissionv1.Create, `{"metadata":{"annotations":{"`+meta.AnnotationKeyManagementPolicy+`":"observe"}}}`),
+			want:   true,
+		},
+		"InvalidUpdate": {
+			reason: "Updating a resource with invalid annotations should be denied.",
+			req:    request(admissionv1.Update, `{"metadata":{"annotations":{"`+meta.AnnotationKeyManagementPolicy+`":"observe-crate-update"}}}`),
+			want:   false,
+		},
+		"Delete": {
+			reason: "Deleting a resource should be allowed.",
+			req:    request(admissionv1.Delete, ""),
+			want:   true,
+		},
+		"Undecodable": {
+			reason: "Requests with an object that can't be decoded should not be allowed.",
+			req:    request(admissionv1.Create, "nope"),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewAnnotationValidator().Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.want, got.Allowed); diff != "" {
+				t.Errorf("\nReason: %s\nHandle(...): -want allowed, +got allowed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
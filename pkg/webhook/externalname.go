@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/errors"
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+// Error strings.
+const (
+	errDecodeOldObject        = "cannot decode old object"
+	errFmtExternalNameChanged = "external name cannot be changed from %q to %q after the external resource was created"
+)
+
+// An ExternalNameValidator is an admission handler that denies requests to
+// change the external name of a managed resource after its external resource
+// was created, as recorded by the external-name-created annotation. It is
+// intended to be used with managed resources reconciled using the
+// WithImmutableExternalName reconciler option, for example:
+//
+//	mgr.GetWebhookServer().Register("/validate-external-name", &admission.Webhook{Handler: webhook.NewExternalNameValidator()})
+type ExternalNameValidator struct{}
+
+// NewExternalNameValidator returns a new ExternalNameValidator.
+func NewExternalNameValidator() *ExternalNameValidator {
+	return &ExternalNameValidator{}
+}
+
+// Handle the supplied admission request by comparing the external name of the
+// object it updates with the external name its external resource was created
+// with. Other requests are always allowed.
+func (v *ExternalNameValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	old := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeOldObject))
+	}
+	o := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.Object.Raw, o); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDecodeObject))
+	}
+
+	created := meta.GetExternalNameCreated(old)
+	if created != "" && meta.GetExternalName(o) != created {
+		return admission.Denied(fmt.Sprintf(errFmtExternalNameChanged, created, meta.GetExternalName(o)))
+	}
+	return admission.Allowed("")
+}
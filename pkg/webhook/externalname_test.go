@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/krateoplatformops/provider-runtime/pkg/meta"
+)
+
+func TestExternalNameValidator(t *testing.T) {
+	object := func(name, created string) string {
+		a := `{"` + meta.AnnotationKeyExternalName + `":"` + name + `"`
+		if created != "" {
+			a += `,"` + meta.AnnotationKeyExternalNameCreated + `":"` + created + `"`
+		}
+		return `{"metadata":{"annotations":` + a + `}}}`
+	}
+	request := func(op admissionv1.Operation, old, o string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: op,
+			OldObject: runtime.RawExtension{Raw: []byte(old)},
+			Object:    runtime.RawExtension{Raw: []byte(o)},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		req    admission.Request
+		want   bool
+	}{
+		"Create": {
+			reason: "Creating a resource should be allowed.",
+			req:    request(admissionv1.Create, "", object("cool", "")),
+			want:   true,
+		},
+		"NotCreated": {
+			reason: "Changing the external name of a resource whose external resource was not created should be allowed.",
+			req:    request(admissionv1.Update, object("cool", ""), object("new", "")),
+			want:   true,
+		},
+		"Unchanged": {
+			reason: "Updating a resource without changing its external name should be allowed.",
+			req:    request(admissionv1.Update, object("cool", "cool"), object("cool", "cool")),
+			want:   true,
+		},
+		"Changed": {
+			reason: "Changing the external name of a resource whose external resource was created should be denied.",
+			req:    request(admissionv1.Update, object("cool", "cool"), object("new", "cool")),
+			want:   false,
+		},
+		"Undecodable": {
+			reason: "Requests with an old object that can't be decoded should not be allowed.",
+			req:    request(admissionv1.Update, "nope", object("cool", "")),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewExternalNameValidator().Handle(context.Background(), tc.req)
+			if diff := cmp.Diff(tc.want, got.Allowed); diff != "" {
+				t.Errorf("\nReason: %s\nHandle(...): -want allowed, +got allowed:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package workqueue
 
 import (
 	"math"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -10,9 +11,9 @@ import (
 
 type FailureRequest struct {
 	// The number of times the request has been attempted
-	Attempts int
+	Attempts int `json:"attempts"`
 	// The time at which the request was last attempted
-	LastAttempt time.Time
+	LastAttempt time.Time `json:"lastAttempt"`
 }
 
 type TypedItemExponentialTimedFailureRateLimiter[T comparable] struct {
@@ -21,47 +22,162 @@ type TypedItemExponentialTimedFailureRateLimiter[T comparable] struct {
 
 	baseDelay time.Duration
 	maxDelay  time.Duration
+
+	jitter      float64
+	rand        func() float64
+	resetWindow time.Duration
+	maxAttempts int
+	onReset     func(item any)
+}
+
+// TimedFailureOptions configure a TypedItemExponentialTimedFailureRateLimiter.
+type TimedFailureOptions struct {
+	// Jitter is the percentage, between 0 and 100, by which each delay may be
+	// randomly reduced, so that many items that fail at once aren't all
+	// retried at once. Delays are not jittered by default.
+	Jitter float64
+
+	// ResetWindow is how long after an item was last attempted its failures
+	// are forgotten. The default is twice the maximum delay.
+	ResetWindow time.Duration
+
+	// MaxAttempts caps the number of attempts counted for an item. Once an
+	// item reaches the cap its delay stops growing. Attempts are not capped
+	// by default.
+	MaxAttempts int
+
+	// OnReset is called with an item when its failures are forgotten because
+	// it wasn't attempted within the reset window. It's called without any
+	// locks held, and may be nil.
+	OnReset func(item any)
+}
+
+// A TimedFailureOption configures a TypedItemExponentialTimedFailureRateLimiter.
+type TimedFailureOption func(*TimedFailureOptions)
+
+// WithJitter configures the rate limiter to reduce each delay by a random
+// amount of up to the supplied percentage of it, so that many items that fail
+// at once aren't all retried at once. Percentages are clamped between 0 and
+// 100. Delays are not jittered by default.
+func WithJitter(percent float64) TimedFailureOption {
+	return func(o *TimedFailureOptions) {
+		o.Jitter = percent
+	}
 }
 
-func NewExponentialTimedFailureRateLimiter[T comparable](baseDelay time.Duration, maxDelay time.Duration) workqueue.TypedRateLimiter[T] {
-	return &TypedItemExponentialTimedFailureRateLimiter[T]{
-		failures:  map[T]FailureRequest{},
-		baseDelay: baseDelay,
-		maxDelay:  maxDelay,
+// WithTimedFailureOptions configures the rate limiter using the supplied
+// options, replacing any options configured before it.
+func WithTimedFailureOptions(opts TimedFailureOptions) TimedFailureOption {
+	return func(o *TimedFailureOptions) {
+		*o = opts
 	}
 }
 
+func NewExponentialTimedFailureRateLimiter[T comparable](baseDelay time.Duration, maxDelay time.Duration, o ...TimedFailureOption) workqueue.TypedRateLimiter[T] {
+	opts := &TimedFailureOptions{}
+	for _, fn := range o {
+		fn(opts)
+	}
+	r := &TypedItemExponentialTimedFailureRateLimiter[T]{
+		failures:    map[T]FailureRequest{},
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+		jitter:      math.Min(math.Max(opts.Jitter, 0), 100) / 100,
+		rand:        rand.Float64,
+		resetWindow: opts.ResetWindow,
+		maxAttempts: max(opts.MaxAttempts, 0),
+		onReset:     opts.OnReset,
+	}
+	if r.resetWindow <= 0 {
+		r.resetWindow = 2 * maxDelay
+	}
+	return r
+}
+
 func (r *TypedItemExponentialTimedFailureRateLimiter[T]) When(item T) time.Duration {
 	r.failuresLock.Lock()
-	defer r.failuresLock.Unlock()
 
 	failreq, ok := r.failures[item]
 	if !ok {
 		r.failures[item] = FailureRequest{Attempts: 1, LastAttempt: time.Now()}
-		return r.baseDelay
+		r.failuresLock.Unlock()
+		return r.jittered(r.baseDelay)
 	}
 
-	if time.Since(failreq.LastAttempt) > 2*r.maxDelay {
+	// The item wasn't attempted within the reset window, so we forget its
+	// failures and let it through immediately.
+	if time.Since(failreq.LastAttempt) > r.resetWindow {
+		delete(r.failures, item)
+		r.failuresLock.Unlock()
+		r.reset(item)
 		return 0
 	}
 
 	exp := failreq.Attempts
-	failreq.Attempts = failreq.Attempts + 1
+	if r.maxAttempts == 0 || failreq.Attempts < r.maxAttempts {
+		failreq.Attempts = failreq.Attempts + 1
+	} else {
+		exp = r.maxAttempts - 1
+	}
 	failreq.LastAttempt = time.Now()
 	r.failures[item] = failreq
+	r.failuresLock.Unlock()
 
 	// The backoff is capped such that 'calculated' value never overflows.
 	backoff := float64(r.baseDelay.Nanoseconds()) * math.Pow(2, float64(exp))
 	if backoff > math.MaxInt64 {
-		return r.maxDelay
+		return r.jittered(r.maxDelay)
 	}
 
 	calculated := time.Duration(backoff)
 
 	if calculated > r.maxDelay {
-		return r.maxDelay
+		return r.jittered(r.maxDelay)
 	}
-	return calculated
+	return r.jittered(calculated)
+}
+
+// Failures returns a copy of the failures the rate limiter is tracking, by
+// item, for example so that they can be persisted across restarts.
+func (r *TypedItemExponentialTimedFailureRateLimiter[T]) Failures() map[T]FailureRequest {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	out := make(map[T]FailureRequest, len(r.failures))
+	for item, f := range r.failures {
+		out[item] = f
+	}
+	return out
+}
+
+// Restore the supplied failures, for example after a restart. Failures of
+// items the rate limiter is already tracking are ignored, because they're
+// more recent.
+func (r *TypedItemExponentialTimedFailureRateLimiter[T]) Restore(failures map[T]FailureRequest) {
+	r.failuresLock.Lock()
+	defer r.failuresLock.Unlock()
+
+	for item, f := range failures {
+		if _, ok := r.failures[item]; !ok {
+			r.failures[item] = f
+		}
+	}
+}
+
+// reset calls the OnReset callback, if any, for the supplied item.
+func (r *TypedItemExponentialTimedFailureRateLimiter[T]) reset(item T) {
+	if r.onReset != nil {
+		r.onReset(item)
+	}
+}
+
+// jittered reduces the supplied delay by a random amount of up to the
+// configured jitter percentage of it.
+func (r *TypedItemExponentialTimedFailureRateLimiter[T]) jittered(d time.Duration) time.Duration {
+	if r.jitter == 0 {
+		return d
+	}
+	return d - time.Duration(r.jitter*r.rand()*float64(d))
 }
 
 func (r *TypedItemExponentialTimedFailureRateLimiter[T]) NumRequeues(item T) int {
@@ -73,10 +189,13 @@ func (r *TypedItemExponentialTimedFailureRateLimiter[T]) NumRequeues(item T) int
 
 func (r *TypedItemExponentialTimedFailureRateLimiter[T]) Forget(item T) {
 	r.failuresLock.Lock()
-	defer r.failuresLock.Unlock()
 
-	if time.Since(r.failures[item].LastAttempt) > 2*r.maxDelay {
-		delete(r.failures, item)
+	failreq, ok := r.failures[item]
+	if !ok || time.Since(failreq.LastAttempt) <= r.resetWindow {
+		r.failuresLock.Unlock()
+		return
 	}
-
+	delete(r.failures, item)
+	r.failuresLock.Unlock()
+	r.reset(item)
 }
@@ -1,6 +1,7 @@
 package workqueue
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -84,3 +85,93 @@ func TestForget(t *testing.T) {
 		t.Errorf("Expected numRequeues %v, got %v", 0, numRequeues)
 	}
 }
+
+func TestJitter(t *testing.T) {
+	baseDelay := 1 * time.Second
+	maxDelay := 4 * time.Second
+	limiter := NewExponentialTimedFailureRateLimiter[string](baseDelay, maxDelay, WithJitter(50)).(*TypedItemExponentialTimedFailureRateLimiter[string])
+	limiter.rand = func() float64 { return 0.5 }
+
+	item := "testItem"
+	expected := []time.Duration{750 * time.Millisecond, 1500 * time.Millisecond, 3 * time.Second, 3 * time.Second}
+	for _, e := range expected {
+		if delay := limiter.When(item); delay != e {
+			t.Errorf("Expected delay %v, got %v", e, delay)
+		}
+	}
+}
+
+func TestJitterBounds(t *testing.T) {
+	baseDelay := 1 * time.Second
+	maxDelay := 4 * time.Second
+	limiter := NewExponentialTimedFailureRateLimiter[string](baseDelay, maxDelay, WithJitter(20))
+
+	for i := 0; i < 100; i++ {
+		item := fmt.Sprintf("testItem%d", i)
+		if delay := limiter.When(item); delay < 800*time.Millisecond || delay > baseDelay {
+			t.Errorf("Expected delay between %v and %v, got %v", 800*time.Millisecond, baseDelay, delay)
+		}
+	}
+}
+
+func TestMaxAttempts(t *testing.T) {
+	baseDelay := 1 * time.Second
+	maxDelay := 1 * time.Minute
+	limiter := NewExponentialTimedFailureRateLimiter[string](baseDelay, maxDelay, WithTimedFailureOptions(TimedFailureOptions{MaxAttempts: 3}))
+
+	item := "testItem"
+	expected := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second}
+	for _, e := range expected {
+		if delay := limiter.When(item); delay != e {
+			t.Errorf("Expected delay %v, got %v", e, delay)
+		}
+	}
+
+	numRequeues := limiter.NumRequeues(item)
+	if numRequeues != 3 {
+		t.Errorf("Expected numRequeues %v, got %v", 3, numRequeues)
+	}
+}
+
+func TestResetWindow(t *testing.T) {
+	baseDelay := 1 * time.Millisecond
+	maxDelay := 1 * time.Hour
+	reset := []any{}
+	limiter := NewExponentialTimedFailureRateLimiter[string](baseDelay, maxDelay, WithTimedFailureOptions(TimedFailureOptions{
+		ResetWindow: 20 * time.Millisecond,
+		OnReset:     func(item any) { reset = append(reset, item) },
+	}))
+
+	item := "testItem"
+	limiter.When(item)
+	limiter.When(item)
+
+	// Forgetting an item within the reset window should not reset it.
+	limiter.Forget(item)
+	if numRequeues := limiter.NumRequeues(item); numRequeues != 2 {
+		t.Errorf("Expected numRequeues %v, got %v", 2, numRequeues)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if delay := limiter.When(item); delay != 0 {
+		t.Errorf("Expected delay %v, got %v", 0, delay)
+	}
+	if len(reset) != 1 || reset[0] != item {
+		t.Errorf("Expected OnReset to be called with %v, got %v", item, reset)
+	}
+
+	// Failures after a reset should start again from the base delay.
+	if delay := limiter.When(item); delay != baseDelay {
+		t.Errorf("Expected delay %v, got %v", baseDelay, delay)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	limiter.Forget(item)
+	if numRequeues := limiter.NumRequeues(item); numRequeues != 0 {
+		t.Errorf("Expected numRequeues %v, got %v", 0, numRequeues)
+	}
+	if len(reset) != 2 {
+		t.Errorf("Expected OnReset to be called %v times, got %v", 2, len(reset))
+	}
+}